@@ -0,0 +1,276 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	. "github.com/mustan989/wallet/app/config"
+	"github.com/mustan989/wallet/app/internal/repository/memory"
+	repository "github.com/mustan989/wallet/app/internal/repository/postgres"
+	sqliterepo "github.com/mustan989/wallet/app/internal/repository/sqlite"
+	"github.com/mustan989/wallet/app/internal/service"
+	"github.com/mustan989/wallet/migrations"
+	sqlitemigrations "github.com/mustan989/wallet/migrations/sqlite"
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/pkg/config"
+	"github.com/mustan989/wallet/pkg/currency"
+	"github.com/mustan989/wallet/pkg/logger"
+	"github.com/mustan989/wallet/pkg/migrate"
+	"github.com/mustan989/wallet/pkg/postgres"
+	"github.com/mustan989/wallet/pkg/sqlite"
+	"github.com/mustan989/wallet/pkg/token"
+	repo "github.com/mustan989/wallet/repository"
+	svc "github.com/mustan989/wallet/service"
+)
+
+// drivers of the database
+const (
+	driverPostgres = "postgres"
+	driverSQLite   = "sqlite"
+)
+
+// storages of the wallets
+const (
+	storageDatabase = "database"
+	storageMemory   = "memory"
+	// storagePostgres is what storageDatabase was called before sqlite, kept so older configs still load
+	storagePostgres = "postgres"
+)
+
+// errMemoryStorage is returned by what needs the wallets in the database, next to their transactions and transfers
+var errMemoryStorage = errors.New(`wallets are kept in memory, transactions and transfers need storage "database"`)
+
+// app is what every command shares: the config, the logger and the database,
+// either the postgres pool or the sqlite db depending on the driver
+type app struct {
+	cfg    *Config
+	secret []byte
+	log    logger.Logger
+	pool   *pgxpool.Pool
+	db     *sql.DB
+}
+
+func bootstrap(ctx context.Context, configPath string, log logger.Logger) (*app, error) {
+	log.Infof("Starting app")
+	log.Infof("Getting config from %s", configPath)
+
+	var cfg Config
+
+	if err := config.ParseConfigFile(configPath, &cfg); err != nil {
+		return nil, fmt.Errorf("parse config file: %w", err)
+	}
+
+	secret, err := base64.StdEncoding.DecodeString(cfg.Auth.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("decode auth secret: %w", err)
+	}
+	if len(secret) == 0 {
+		return nil, errors.New("auth secret is empty")
+	}
+
+	for _, c := range cfg.Currencies {
+		if err = currency.Default().Register(currency.Currency{
+			Code: c.Code, Name: c.Name, Symbol: c.Symbol, MinorUnits: c.MinorUnits, Custom: true,
+		}); err != nil {
+			return nil, fmt.Errorf("register currency: %w", err)
+		}
+	}
+
+	if cfg.Purge == nil {
+		cfg.Purge = &Purge{}
+	}
+	if cfg.Purge.Interval <= 0 {
+		cfg.Purge.Interval = defaultPurgeInterval
+	}
+
+	switch cfg.Storage {
+	case "", storagePostgres:
+		cfg.Storage = storageDatabase
+	case storageDatabase, storageMemory:
+	default:
+		return nil, fmt.Errorf("unknown storage %q", cfg.Storage)
+	}
+
+	if cfg.Database == nil {
+		cfg.Database = &Database{}
+	}
+	switch cfg.Database.Driver {
+	case "":
+		cfg.Database.Driver = driverPostgres
+	case driverPostgres, driverSQLite:
+	default:
+		return nil, fmt.Errorf("unknown database driver %q", cfg.Database.Driver)
+	}
+
+	log.Infof("Config successfully loaded")
+
+	connCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	log.Infof("Connecting to %s database", cfg.Database.Driver)
+
+	a := &app{cfg: &cfg, secret: secret, log: log}
+	if cfg.Database.Driver == driverSQLite {
+		if a.db, err = sqlite.Connect(cfg.Database); err != nil {
+			return nil, fmt.Errorf("connect to database: %w", err)
+		}
+		err = a.db.PingContext(connCtx)
+	} else {
+		if a.pool, err = postgres.Connect(connCtx, cfg.Database); err != nil {
+			return nil, fmt.Errorf("connect to database: %w", err)
+		}
+		err = a.pool.Ping(connCtx)
+	}
+	if err != nil {
+		a.close()
+		return nil, fmt.Errorf("ping database: %w", err)
+	}
+
+	log.Infof("Successfully connected to database")
+
+	return a, nil
+}
+
+func (a *app) close() {
+	if a.db != nil {
+		if err := a.db.Close(); err != nil {
+			a.log.Errorf("Close database: %v", err)
+		}
+		return
+	}
+	a.pool.Close()
+}
+
+// migrate runs fn with a migrator holding its own connection
+func (a *app) migrate(ctx context.Context, fn func(migrator *migrate.Migrator) error) error {
+	if a.db != nil {
+		loaded, err := migrate.Load(sqlitemigrations.FS)
+		if err != nil {
+			return fmt.Errorf("load migrations: %w", err)
+		}
+
+		return fn(migrate.NewSQLite(a.db, loaded, migrate.WithLogger(a.log)))
+	}
+
+	loaded, err := migrate.Load(migrations.FS)
+	if err != nil {
+		return fmt.Errorf("load migrations: %w", err)
+	}
+
+	conn, err := a.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire database connection: %w", err)
+	}
+	defer conn.Release()
+
+	return fn(migrate.New(conn, loaded, migrate.WithLogger(a.log)))
+}
+
+type services struct {
+	users      repo.User
+	wallets    repo.Wallet
+	categories repo.Category
+	tx         repo.TxManager
+
+	wallet      svc.Wallet
+	member      svc.Member
+	transaction svc.Transaction
+	transfer    svc.Transfer
+	category    svc.Category
+	user        svc.User
+	auth        svc.Auth
+	currency    svc.Currency
+}
+
+// repositories are the repositories of the configured database driver
+type repositories struct {
+	wallet      repo.Wallet
+	member      repo.Member
+	transaction repo.Transaction
+	transfer    repo.Transfer
+	category    repo.Category
+	user        repo.User
+	token       repo.Token
+	tx          repo.TxManager
+}
+
+func (a *app) repositories() *repositories {
+	if a.db != nil {
+		return &repositories{
+			wallet:      sqliterepo.NewWallet(a.db),
+			member:      sqliterepo.NewMember(a.db),
+			transaction: sqliterepo.NewTransaction(a.db),
+			transfer:    sqliterepo.NewTransfer(a.db),
+			category:    sqliterepo.NewCategory(a.db),
+			user:        sqliterepo.NewUser(a.db),
+			token:       sqliterepo.NewToken(a.db),
+			tx:          sqliterepo.NewTxManager(a.db),
+		}
+	}
+
+	return &repositories{
+		wallet:      repository.NewWallet(a.pool),
+		member:      repository.NewMember(a.pool),
+		transaction: repository.NewTransaction(a.pool),
+		transfer:    repository.NewTransfer(a.pool),
+		category:    repository.NewCategory(a.pool),
+		user:        repository.NewUser(a.pool),
+		token:       repository.NewToken(a.pool),
+		tx:          repository.NewTxManager(a.pool),
+	}
+}
+
+func (a *app) services() *services {
+	r := a.repositories()
+	if a.cfg.Storage == storageMemory {
+		a.log.Warnf("Wallets are kept in memory and lost on exit, transactions and transfers are not served")
+		store := memory.NewStore()
+		r.wallet, r.member = memory.NewWallet(store), memory.NewMember(store)
+	}
+
+	return &services{
+		users:      r.user,
+		wallets:    r.wallet,
+		categories: r.category,
+		tx:         r.tx,
+
+		wallet:      service.NewWallet(r.wallet, r.member, service.WithLogger(a.log)),
+		member:      service.NewMember(r.member, r.wallet, service.WithMemberLogger(a.log)),
+		transaction: service.NewTransaction(r.transaction, r.wallet, r.category, r.member, service.WithTransactionLogger(a.log)),
+		transfer:    service.NewTransfer(r.transfer, r.wallet, r.member, service.WithTransferLogger(a.log)),
+		category:    service.NewCategory(r.category, service.WithCategoryLogger(a.log)),
+		user:        service.NewUser(r.user, service.WithUserLogger(a.log)),
+		auth: service.NewAuth(
+			r.user, r.token, token.NewSigner(a.secret),
+			service.WithAuthLogger(a.log), service.WithAuthTTL(a.cfg.Auth.AccessTTL, a.cfg.Auth.RefreshTTL),
+		),
+		currency: service.NewCurrency(currency.Default()),
+	}
+}
+
+// requireDatabase fails the commands working on the wallets outside of the server,
+// as the wallets they would keep in memory are lost when the command exits
+func (a *app) requireDatabase() error {
+	if a.cfg.Storage == storageMemory {
+		return errors.New(`wallets are kept in memory for the server alone, set storage to "database"`)
+	}
+	return nil
+}
+
+// actAs returns the context acting as the user with the email
+func (s *services) actAs(ctx context.Context, email string) (context.Context, *model.User, error) {
+	if email == "" {
+		return nil, nil, errors.New("email is required")
+	}
+	user, err := s.users.FindByEmail(ctx, email)
+	if err != nil {
+		return nil, nil, fmt.Errorf("find user %s: %w", email, err)
+	}
+	return model.WithUserID(ctx, user.ID), user, nil
+}
@@ -1,18 +1,48 @@
 package config
 
+import "time"
+
 type Config struct {
 	Database *Database `json:"database" yaml:"database"`
 	Server   *Server   `json:"server" yaml:"server"`
+	Auth     *Auth     `json:"auth" yaml:"auth"`
+	// Currencies are registered in addition to ISO 4217 ones, e.g. loyalty points
+	Currencies []*Currency `json:"currencies" yaml:"currencies"`
+	Purge      *Purge      `json:"purge" yaml:"purge"`
+	// Storage of the wallets is "database", the default, "postgres" as its former name, or "memory"
+	Storage string `json:"storage" yaml:"storage" env:"STORAGE"`
 }
 
 type Database struct {
-	Host string `json:"host" yaml:"host" env:"DATABASE_HOST"`
-	Port int    `json:"port" yaml:"port" env:"DATABASE_PORT"`
-	User string `json:"user" yaml:"user" env:"DATABASE_USER"`
-	Pass string `json:"pass" yaml:"pass" env:"DATABASE_PASS"`
-	Name string `json:"name" yaml:"name" env:"DATABASE_NAME"`
+	// Driver is "postgres", the default, or "sqlite" to keep everything in the file at Name without a server
+	Driver string `json:"driver" yaml:"driver" env:"DATABASE_DRIVER"`
+	Host   string `json:"host" yaml:"host" env:"DATABASE_HOST"`
+	Port   int    `json:"port" yaml:"port" env:"DATABASE_PORT"`
+	User   string `json:"user" yaml:"user" env:"DATABASE_USER"`
+	Pass   string `json:"pass" yaml:"pass" env:"DATABASE_PASS"`
+	Name   string `json:"name" yaml:"name" env:"DATABASE_NAME"`
 }
 
 type Server struct {
 	Port int `json:"port" yaml:"port" env:"SERVER_PORT"`
 }
+
+type Auth struct {
+	Secret     string        `json:"secret" yaml:"secret" env:"AUTH_SECRET"`
+	AccessTTL  time.Duration `json:"access_ttl" yaml:"access_ttl" env:"AUTH_ACCESS_TTL"`
+	RefreshTTL time.Duration `json:"refresh_ttl" yaml:"refresh_ttl" env:"AUTH_REFRESH_TTL"`
+}
+
+type Currency struct {
+	Code       string `json:"code" yaml:"code"`
+	Name       string `json:"name" yaml:"name"`
+	Symbol     string `json:"symbol" yaml:"symbol"`
+	MinorUnits uint8  `json:"minor_units" yaml:"minor_units"`
+}
+
+// Purge permanently deletes wallets that stayed deleted longer than Retention, zero Retention keeps them forever
+type Purge struct {
+	Retention time.Duration `json:"retention" yaml:"retention" env:"PURGE_RETENTION"`
+	// Interval is how often the server purges, an hour by default
+	Interval time.Duration `json:"interval" yaml:"interval" env:"PURGE_INTERVAL"`
+}
@@ -0,0 +1,344 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/service"
+)
+
+// dump is the format of export and import, it holds the wallets owned by a user with their history.
+// Transactions of transfers are not listed, import recreates them with the transfers
+type dump struct {
+	Wallets      []*model.Wallet      `json:"wallets"`
+	Categories   []*model.Category    `json:"categories"`
+	Transactions []*model.Transaction `json:"transactions"`
+	Transfers    []*model.Transfer    `json:"transfers"`
+}
+
+func exchangeFlags(name, fileUsage string, args []string) (email, file string, err error) {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.StringVar(&email, "email", "", "email of the user")
+	flags.StringVar(&file, "file", "", fileUsage)
+	err = flags.Parse(args)
+	return
+}
+
+func export(ctx context.Context, a *app, args []string) error {
+	email, file, err := exchangeFlags("export", "file to write, stdout by default", args)
+	if err != nil {
+		return err
+	}
+	if err = a.requireDatabase(); err != nil {
+		return err
+	}
+
+	s := a.services()
+
+	ctx, user, err := s.actAs(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	var d dump
+
+	wallets, err := s.wallet.GetAll(ctx, &service.WalletGetAllRequest{Filter: &model.WalletFilter{Deleted: model.DeletedInclude}})
+	if err != nil {
+		return err
+	}
+	// shared wallets belong to their owners
+	owned := map[uint64]bool{}
+	for _, wallet := range wallets.Data {
+		if wallet.OwnerID == user.ID {
+			owned[wallet.ID] = true
+			d.Wallets = append(d.Wallets, wallet)
+		}
+	}
+
+	categories, err := s.category.GetAll(ctx, &service.CategoryGetAllRequest{Filter: &model.CategoryFilter{}})
+	if err != nil {
+		return err
+	}
+	d.Categories = categories.Data
+
+	exported := map[uint64]bool{}
+	for _, wallet := range d.Wallets {
+		walletID := wallet.ID
+
+		transactions, err := s.transaction.GetAll(ctx, &service.TransactionGetAllRequest{
+			Filter: &model.TransactionFilter{WalletID: &walletID},
+		})
+		if err != nil {
+			return err
+		}
+		for _, transaction := range transactions.Data {
+			if transaction.TransferID == nil {
+				d.Transactions = append(d.Transactions, transaction)
+			}
+		}
+
+		transfers, err := s.transfer.GetAll(ctx, &service.TransferGetAllRequest{
+			Filter: &model.TransferFilter{WalletID: &walletID},
+		})
+		if err != nil {
+			return err
+		}
+		for _, transfer := range transfers.Data {
+			if exported[transfer.ID] || !owned[transfer.FromWalletID] || !owned[transfer.ToWalletID] {
+				continue
+			}
+			exported[transfer.ID] = true
+			d.Transfers = append(d.Transfers, transfer)
+		}
+	}
+
+	out := io.Writer(os.Stdout)
+	if file != "" {
+		f, err := os.Create(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(d); err != nil {
+		return err
+	}
+
+	a.log.Infof(
+		"Exported %d wallets, %d categories, %d transactions and %d transfers of user %d",
+		len(d.Wallets), len(d.Categories), len(d.Transactions), len(d.Transfers), user.ID,
+	)
+	return nil
+}
+
+// importCommand recreates the dump for the user, ids are assigned anew.
+// Wallets are created with the amounts they had before the history, which then brings them to the exported ones.
+// Categories are archived and wallets deleted last, so the history can still be filed under them
+func importCommand(ctx context.Context, a *app, args []string) error {
+	email, file, err := exchangeFlags("import", "file to read, stdin by default", args)
+	if err != nil {
+		return err
+	}
+	if err = a.requireDatabase(); err != nil {
+		return err
+	}
+
+	in := io.Reader(os.Stdin)
+	if file != "" {
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	var d dump
+	if err = json.NewDecoder(in).Decode(&d); err != nil {
+		return fmt.Errorf("decode dump: %w", err)
+	}
+
+	opening, err := openingAmounts(&d)
+	if err != nil {
+		return err
+	}
+
+	s := a.services()
+
+	ctx, user, err := s.actAs(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	// a failed import leaves nothing behind
+	if err = s.tx.InTx(ctx, func(ctx context.Context) error {
+		categoryIDs := map[uint64]uint64{}
+		archived := map[uint64]time.Time{}
+		// parents are created before their children
+		for pending := d.Categories; len(pending) > 0; {
+			var next []*model.Category
+			for _, category := range pending {
+				var parentID *uint64
+				if category.ParentID != nil {
+					id, ok := categoryIDs[*category.ParentID]
+					if !ok {
+						next = append(next, category)
+						continue
+					}
+					parentID = &id
+				}
+
+				created, err := s.category.Create(ctx, &service.CategoryCreateRequest{Data: &model.Category{
+					ParentID: parentID,
+					Name:     category.Name,
+					Type:     category.Type,
+				}})
+				if err != nil {
+					return fmt.Errorf("import category %d: %w", category.ID, err)
+				}
+				categoryIDs[category.ID] = created.Data.ID
+				if category.ArchivedAt != nil {
+					archived[created.Data.ID] = *category.ArchivedAt
+				}
+			}
+			if len(next) == len(pending) {
+				return fmt.Errorf("import category %d: parent %d is not exported", next[0].ID, *next[0].ParentID)
+			}
+			pending = next
+		}
+
+		walletIDs := map[uint64]uint64{}
+		for _, wallet := range d.Wallets {
+			created, err := s.wallet.Create(ctx, &service.WalletCreateRequest{Data: &model.Wallet{
+				Name:        wallet.Name,
+				Description: wallet.Description,
+				Currency:    wallet.Currency,
+				Amount:      opening[wallet.ID],
+				Personal:    wallet.Personal,
+			}})
+			if err != nil {
+				return fmt.Errorf("import wallet %d: %w", wallet.ID, err)
+			}
+			walletIDs[wallet.ID] = created.Data.ID
+		}
+
+		for _, transaction := range d.Transactions {
+			walletID, ok := walletIDs[transaction.WalletID]
+			if !ok {
+				return fmt.Errorf("import transaction %d: wallet %d is not exported", transaction.ID, transaction.WalletID)
+			}
+
+			var categoryID *uint64
+			if transaction.CategoryID != nil {
+				id, ok := categoryIDs[*transaction.CategoryID]
+				if !ok {
+					return fmt.Errorf("import transaction %d: category %d is not exported", transaction.ID, *transaction.CategoryID)
+				}
+				categoryID = &id
+			}
+
+			if _, err = s.transaction.Create(ctx, &service.TransactionCreateRequest{Data: &model.Transaction{
+				WalletID:    walletID,
+				CategoryID:  categoryID,
+				Type:        transaction.Type,
+				Amount:      transaction.Amount,
+				Description: transaction.Description,
+				Date:        transaction.Date,
+			}}); err != nil {
+				return fmt.Errorf("import transaction %d: %w", transaction.ID, err)
+			}
+		}
+
+		// new transactions can not be filed under archived categories, so they are archived afterwards as exported
+		for id, at := range archived {
+			if _, err = s.categories.Archive(ctx, id, at); err != nil {
+				return fmt.Errorf("archive category %d: %w", id, err)
+			}
+		}
+
+		for _, transfer := range d.Transfers {
+			fromID, fromOK := walletIDs[transfer.FromWalletID]
+			toID, toOK := walletIDs[transfer.ToWalletID]
+			if !fromOK || !toOK {
+				return fmt.Errorf("import transfer %d: wallets are not exported", transfer.ID)
+			}
+
+			if _, err = s.transfer.Create(ctx, &service.TransferCreateRequest{Data: &model.Transfer{
+				FromWalletID:   fromID,
+				ToWalletID:     toID,
+				Amount:         transfer.Amount,
+				ReceivedAmount: transfer.ReceivedAmount,
+				Rate:           transfer.Rate,
+				Commission:     transfer.Commission,
+				Description:    transfer.Description,
+				Date:           transfer.Date,
+			}}); err != nil {
+				return fmt.Errorf("import transfer %d: %w", transfer.ID, err)
+			}
+		}
+
+		// the service keeps the deletion on updates, so it is restored as exported through the repository
+		for _, wallet := range d.Wallets {
+			if wallet.DeletedAt == nil {
+				continue
+			}
+			// the imported history has moved the version on
+			restored, err := s.wallets.FindByID(ctx, walletIDs[wallet.ID])
+			if err != nil {
+				return fmt.Errorf("restore wallet %d: %w", wallet.ID, err)
+			}
+			restored.DeletedAt = wallet.DeletedAt
+			if err = s.wallets.Update(ctx, restored); err != nil {
+				return fmt.Errorf("restore wallet %d: %w", wallet.ID, err)
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	a.log.Infof(
+		"Imported %d wallets, %d categories, %d transactions and %d transfers for user %d",
+		len(d.Wallets), len(d.Categories), len(d.Transactions), len(d.Transfers), user.ID,
+	)
+	return nil
+}
+
+// openingAmounts takes the history of the dump off the exported wallet amounts,
+// the way transactions and transfers change them, e.g. a transfer takes its amount and commission off the source.
+// The wallets bind the amounts to their currencies on creation
+func openingAmounts(d *dump) (map[uint64]model.Money, error) {
+	amounts := make(map[uint64]model.Money, len(d.Wallets))
+	for _, wallet := range d.Wallets {
+		amount, err := wallet.Amount.In(model.Unbound)
+		if err != nil {
+			return nil, fmt.Errorf("import wallet %d: %w", wallet.ID, err)
+		}
+		amounts[wallet.ID] = amount
+	}
+
+	// amounts left out of the dump are zeros of no currency, so every amount is taken in model.Unbound
+	add := func(id uint64, deltas ...model.Money) error {
+		amount, ok := amounts[id]
+		if !ok {
+			// the history of wallets not exported fails the import later
+			return nil
+		}
+		for _, delta := range deltas {
+			delta, err := delta.In(model.Unbound)
+			if err == nil {
+				amount, err = amount.Add(delta)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		amounts[id] = amount
+		return nil
+	}
+	for _, transaction := range d.Transactions {
+		if err := add(transaction.WalletID, transaction.Delta().Neg()); err != nil {
+			return nil, fmt.Errorf("import transaction %d: %w", transaction.ID, err)
+		}
+	}
+	for _, transfer := range d.Transfers {
+		err := add(transfer.FromWalletID, transfer.Amount, transfer.Commission)
+		if err == nil {
+			err = add(transfer.ToWalletID, transfer.ReceivedAmount.Neg())
+		}
+		if err != nil {
+			return nil, fmt.Errorf("import transfer %d: %w", transfer.ID, err)
+		}
+	}
+	return amounts, nil
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/service"
+)
+
+func NewAuth(svc service.Auth) *Auth { return &Auth{svc} }
+
+// Auth exposes service.Auth over http
+type Auth struct{ svc service.Auth }
+
+// Register mounts auth routes to the group, e.g. /auth
+func (a *Auth) Register(g *echo.Group) {
+	g.POST("/register", a.SignUp)
+	g.POST("/login", a.Login)
+	g.POST("/refresh", a.Refresh)
+	g.POST("/logout", a.Logout)
+}
+
+// Authenticate puts the user of the bearer access token into the request context, it guards the groups that need the user.
+// Requests without the token pass through, calls that need the user fail with model.ErrNoUser
+func (a *Auth) Authenticate(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		accessToken, ok := bearer(c)
+		if !ok {
+			return next(c)
+		}
+
+		response, err := a.svc.Authenticate(c.Request().Context(), &service.AuthAuthenticateRequest{AccessToken: accessToken})
+		if err != nil {
+			return err
+		}
+
+		c.SetRequest(c.Request().WithContext(model.WithUserID(c.Request().Context(), response.UserID)))
+		return next(c)
+	}
+}
+
+func (a *Auth) SignUp(c echo.Context) error {
+	body := &struct {
+		model.User
+		Password string `json:"password"`
+	}{}
+	if err := (&echo.DefaultBinder{}).BindBody(c, body); err != nil {
+		return err
+	}
+
+	response, err := a.svc.Register(c.Request().Context(), &service.AuthRegisterRequest{Data: &body.User, Password: body.Password})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, response)
+}
+
+func (a *Auth) Login(c echo.Context) error {
+	request := &service.AuthLoginRequest{}
+	if err := (&echo.DefaultBinder{}).BindBody(c, request); err != nil {
+		return err
+	}
+
+	response, err := a.svc.Login(c.Request().Context(), request)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (a *Auth) Refresh(c echo.Context) error {
+	request := &service.AuthRefreshRequest{}
+	if err := (&echo.DefaultBinder{}).BindBody(c, request); err != nil {
+		return err
+	}
+
+	response, err := a.svc.Refresh(c.Request().Context(), request)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (a *Auth) Logout(c echo.Context) error {
+	request := &service.AuthLogoutRequest{}
+	if err := (&echo.DefaultBinder{}).BindBody(c, request); err != nil {
+		return err
+	}
+	request.AccessToken, _ = bearer(c)
+
+	if _, err := a.svc.Logout(c.Request().Context(), request); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// bearer returns the token of the Authorization header
+func bearer(c echo.Context) (string, bool) {
+	header := c.Request().Header.Get(echo.HeaderAuthorization)
+	scheme, accessToken, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || accessToken == "" {
+		return "", false
+	}
+	return accessToken, true
+}
@@ -0,0 +1,102 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/mustan989/wallet/app/internal/handler"
+	mock_service "github.com/mustan989/wallet/app/internal/service/mock"
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/service"
+)
+
+func TestAuth_Authenticate(t *testing.T) {
+	subtests := [...]struct {
+		name   string
+		header string
+		userID uint64
+		err    error
+		status int
+	}{
+		{"Valid", "Bearer token", 1, nil, http.StatusOK},
+		{"Lowercase scheme", "bearer token", 1, nil, http.StatusOK},
+		{"Invalid", "Bearer token", 0, service.ErrUnauthenticated, http.StatusUnauthorized},
+		{"No token", "", 0, nil, http.StatusUnauthorized},
+		{"Other scheme", "Basic dXNlcjpwYXNz", 0, nil, http.StatusUnauthorized},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			svc := mock_service.NewMockAuth(ctl)
+
+			if subtest.userID != 0 || subtest.err != nil {
+				response := &service.AuthAuthenticateResponse{UserID: subtest.userID}
+				if subtest.err != nil {
+					response = nil
+				}
+				svc.EXPECT().
+					Authenticate(gomock.Any(), &service.AuthAuthenticateRequest{AccessToken: "token"}).
+					Return(response, subtest.err)
+			}
+
+			e := echo.New()
+			e.HTTPErrorHandler = ErrorHandler(log)
+			e.Use(NewAuth(svc).Authenticate)
+			e.GET("/me", func(c echo.Context) error {
+				id, err := model.UserID(c.Request().Context())
+				if err != nil {
+					return err
+				}
+				require.Equal(t, subtest.userID, id)
+				return c.NoContent(http.StatusOK)
+			})
+
+			req := newRequest(http.MethodGet, "/me", "")
+			if subtest.header != "" {
+				req.Header.Set(echo.HeaderAuthorization, subtest.header)
+			}
+
+			require.Equal(t, subtest.status, record(e, req).Code)
+		})
+	}
+}
+
+func TestAuth_Login(t *testing.T) {
+	ctl := gomock.NewController(t)
+	svc := mock_service.NewMockAuth(ctl)
+
+	svc.EXPECT().
+		Login(gomock.Any(), &service.AuthLoginRequest{Email: "name@example.com", Password: "password"}).
+		Return(&service.AuthLoginResponse{Data: &model.Tokens{AccessToken: "access", RefreshToken: "refresh", TokenType: "Bearer", ExpiresIn: 900}}, nil)
+
+	e := echo.New()
+	e.HTTPErrorHandler = ErrorHandler(log)
+	NewAuth(svc).Register(e.Group("/auth"))
+
+	rec := serve(e, http.MethodPost, "/auth/login", `{"email":"name@example.com","password":"password"}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `{"data":{"access_token":"access","refresh_token":"refresh","token_type":"Bearer","expires_in":900}}`, rec.Body.String())
+}
+
+func TestAuth_Logout(t *testing.T) {
+	ctl := gomock.NewController(t)
+	svc := mock_service.NewMockAuth(ctl)
+
+	svc.EXPECT().
+		Logout(gomock.Any(), &service.AuthLogoutRequest{AccessToken: "access", RefreshToken: "refresh"}).
+		Return(&service.AuthLogoutResponse{}, nil)
+
+	e := echo.New()
+	e.HTTPErrorHandler = ErrorHandler(log)
+	NewAuth(svc).Register(e.Group("/auth"))
+
+	req := newRequest(http.MethodPost, "/auth/logout", `{"refresh_token":"refresh"}`)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer access")
+
+	require.Equal(t, http.StatusNoContent, record(e, req).Code)
+}
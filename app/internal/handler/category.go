@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/service"
+)
+
+func NewCategory(svc service.Category) *Category { return &Category{svc} }
+
+// Category exposes service.Category over http
+type Category struct{ svc service.Category }
+
+// Register mounts category routes to the group, e.g. /categories
+func (h *Category) Register(g *echo.Group) {
+	g.GET("", h.GetAll)
+	g.GET("/count", h.Count)
+	g.GET("/:id", h.GetByID)
+	g.POST("", h.Create)
+	g.PUT("/:id", h.Update)
+	g.DELETE("/:id", h.DeleteByID)
+}
+
+func (h *Category) Count(c echo.Context) error {
+	filter := &model.CategoryFilter{}
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, filter); err != nil {
+		return err
+	}
+
+	response, err := h.svc.Count(c.Request().Context(), &service.CategoryCountRequest{Filter: filter})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *Category) GetAll(c echo.Context) error {
+	filter := &model.CategoryFilter{}
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, filter); err != nil {
+		return err
+	}
+
+	response, err := h.svc.GetAll(c.Request().Context(), &service.CategoryGetAllRequest{Filter: filter})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *Category) GetByID(c echo.Context) error {
+	id, err := paramID(c)
+	if err != nil {
+		return err
+	}
+
+	response, err := h.svc.GetByID(c.Request().Context(), &service.CategoryGetByIDRequest{ID: id})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *Category) Create(c echo.Context) error {
+	data := &model.Category{}
+	if err := (&echo.DefaultBinder{}).BindBody(c, data); err != nil {
+		return err
+	}
+
+	response, err := h.svc.Create(c.Request().Context(), &service.CategoryCreateRequest{Data: data})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, response)
+}
+
+func (h *Category) Update(c echo.Context) error {
+	id, err := paramID(c)
+	if err != nil {
+		return err
+	}
+
+	data := &model.Category{}
+	if err = (&echo.DefaultBinder{}).BindBody(c, data); err != nil {
+		return err
+	}
+	data.ID = id
+
+	response, err := h.svc.Update(c.Request().Context(), &service.CategoryUpdateRequest{Data: data})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *Category) DeleteByID(c echo.Context) error {
+	id, err := paramID(c)
+	if err != nil {
+		return err
+	}
+
+	params := &struct {
+		ReassignTo *uint64 `query:"reassign_to"`
+		Archive    bool    `query:"archive"`
+	}{}
+	if err = (&echo.DefaultBinder{}).BindQueryParams(c, params); err != nil {
+		return err
+	}
+
+	response, err := h.svc.DeleteByID(c.Request().Context(), &service.CategoryDeleteByIDRequest{
+		ID:         id,
+		ReassignTo: params.ReassignTo,
+		Archive:    params.Archive,
+	})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/mustan989/wallet/service"
+)
+
+func NewCurrency(svc service.Currency) *Currency { return &Currency{svc} }
+
+// Currency exposes service.Currency over http, the list is public
+type Currency struct{ svc service.Currency }
+
+// Register mounts currency routes to the group, e.g. /currencies
+func (h *Currency) Register(g *echo.Group) {
+	g.GET("", h.GetAll)
+}
+
+func (h *Currency) GetAll(c echo.Context) error {
+	response, err := h.svc.GetAll(c.Request().Context(), &service.CurrencyGetAllRequest{})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response)
+}
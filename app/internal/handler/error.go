@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/pkg/logger"
+	"github.com/mustan989/wallet/repository"
+	"github.com/mustan989/wallet/service"
+)
+
+// Error is the body of every non-successful response
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type errorResponse struct {
+	Error Error `json:"error"`
+}
+
+// statuses maps domain errors to http status codes
+var statuses = []struct {
+	err  error
+	code int
+}{
+	{repository.ErrWalletNotFound, http.StatusNotFound},
+	{repository.ErrWalletConflict, http.StatusConflict},
+	{repository.ErrWalletStale, http.StatusPreconditionFailed},
+	{repository.ErrTransactionNotFound, http.StatusNotFound},
+	{repository.ErrTransactionConflict, http.StatusConflict},
+	{repository.ErrTransactionLinked, http.StatusConflict},
+	{repository.ErrTransferNotFound, http.StatusNotFound},
+	{repository.ErrTransferConflict, http.StatusConflict},
+	{repository.ErrCategoryNotFound, http.StatusNotFound},
+	{repository.ErrCategoryConflict, http.StatusConflict},
+	{repository.ErrCategoryInUse, http.StatusConflict},
+	{repository.ErrUserNotFound, http.StatusNotFound},
+	{repository.ErrUserConflict, http.StatusConflict},
+	{repository.ErrMemberNotFound, http.StatusNotFound},
+	{model.ErrNoUser, http.StatusUnauthorized},
+	{model.ErrDecimalOverflow, http.StatusBadRequest},
+	{model.ErrDivisionByZero, http.StatusBadRequest},
+	{model.ErrDecimalSyntax, http.StatusBadRequest},
+	{model.ErrDecimalPrecision, http.StatusBadRequest},
+	{model.ErrInvalidCursor, http.StatusBadRequest},
+	{service.ErrInvalidArgument, http.StatusBadRequest},
+	{service.ErrUnauthenticated, http.StatusUnauthorized},
+	{service.ErrForbidden, http.StatusForbidden},
+}
+
+// ErrorHandler converts errors returned by handlers to the JSON error envelope
+func ErrorHandler(log logger.Logger) echo.HTTPErrorHandler {
+	return func(err error, c echo.Context) {
+		if c.Response().Committed {
+			return
+		}
+
+		code, message := status(err)
+		if code == http.StatusInternalServerError {
+			log.Errorf("Error handling %s %s: %s", c.Request().Method, c.Request().URL.Path, err)
+		}
+
+		if c.Request().Method == http.MethodHead {
+			err = c.NoContent(code)
+		} else {
+			err = c.JSON(code, errorResponse{Error{Code: code, Message: message}})
+		}
+		if err != nil {
+			log.Errorf("Error sending error response: %s", err)
+		}
+	}
+}
+
+func status(err error) (code int, message string) {
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code, fmt.Sprint(httpErr.Message)
+	}
+
+	for _, s := range statuses {
+		if errors.Is(err, s.err) {
+			return s.code, err.Error()
+		}
+	}
+
+	return http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError)
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/service"
+)
+
+func NewMember(svc service.Member) *Member { return &Member{svc} }
+
+// Member exposes service.Member over http
+type Member struct{ svc service.Member }
+
+// Register mounts member routes to the wallet group, e.g. /wallets
+func (m *Member) Register(g *echo.Group) {
+	g.GET("/:id/members", m.GetAll)
+	g.PUT("/:id/members/:user_id", m.Put)
+	g.DELETE("/:id/members/:user_id", m.DeleteByID)
+}
+
+func (m *Member) GetAll(c echo.Context) error {
+	walletID, err := paramID(c)
+	if err != nil {
+		return err
+	}
+
+	response, err := m.svc.GetAll(c.Request().Context(), &service.MemberGetAllRequest{WalletID: walletID})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (m *Member) Put(c echo.Context) error {
+	walletID, err := paramID(c)
+	if err != nil {
+		return err
+	}
+	userID, err := paramUint(c, "user_id")
+	if err != nil {
+		return err
+	}
+
+	data := &model.Member{}
+	if err = (&echo.DefaultBinder{}).BindBody(c, data); err != nil {
+		return err
+	}
+	data.WalletID, data.UserID = walletID, userID
+
+	response, err := m.svc.Put(c.Request().Context(), &service.MemberPutRequest{Data: data})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (m *Member) DeleteByID(c echo.Context) error {
+	walletID, err := paramID(c)
+	if err != nil {
+		return err
+	}
+	userID, err := paramUint(c, "user_id")
+	if err != nil {
+		return err
+	}
+
+	response, err := m.svc.DeleteByID(c.Request().Context(), &service.MemberDeleteByIDRequest{WalletID: walletID, UserID: userID})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/service"
+)
+
+func NewTransaction(svc service.Transaction) *Transaction { return &Transaction{svc} }
+
+// Transaction exposes service.Transaction over http
+type Transaction struct{ svc service.Transaction }
+
+// Register mounts transaction routes to the group, e.g. /transactions
+func (t *Transaction) Register(g *echo.Group) {
+	g.GET("", t.GetAll)
+	g.GET("/count", t.Count)
+	g.GET("/:id", t.GetByID)
+	g.POST("", t.Create)
+	g.PUT("/:id", t.Update)
+	g.DELETE("/:id", t.DeleteByID)
+}
+
+func (t *Transaction) Count(c echo.Context) error {
+	filter := &model.TransactionFilter{}
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, filter); err != nil {
+		return err
+	}
+
+	response, err := t.svc.Count(c.Request().Context(), &service.TransactionCountRequest{Filter: filter})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (t *Transaction) GetAll(c echo.Context) error {
+	filter := &model.TransactionFilter{}
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, filter); err != nil {
+		return err
+	}
+
+	response, err := t.svc.GetAll(c.Request().Context(), &service.TransactionGetAllRequest{Filter: filter})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (t *Transaction) GetByID(c echo.Context) error {
+	id, err := paramID(c)
+	if err != nil {
+		return err
+	}
+
+	response, err := t.svc.GetByID(c.Request().Context(), &service.TransactionGetByIDRequest{ID: id})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (t *Transaction) Create(c echo.Context) error {
+	data := &model.Transaction{}
+	if err := (&echo.DefaultBinder{}).BindBody(c, data); err != nil {
+		return err
+	}
+
+	response, err := t.svc.Create(c.Request().Context(), &service.TransactionCreateRequest{Data: data})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, response)
+}
+
+func (t *Transaction) Update(c echo.Context) error {
+	id, err := paramID(c)
+	if err != nil {
+		return err
+	}
+
+	data := &model.Transaction{}
+	if err = (&echo.DefaultBinder{}).BindBody(c, data); err != nil {
+		return err
+	}
+	data.ID = id
+
+	response, err := t.svc.Update(c.Request().Context(), &service.TransactionUpdateRequest{Data: data})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (t *Transaction) DeleteByID(c echo.Context) error {
+	id, err := paramID(c)
+	if err != nil {
+		return err
+	}
+
+	response, err := t.svc.DeleteByID(c.Request().Context(), &service.TransactionDeleteByIDRequest{ID: id})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/service"
+)
+
+func NewTransfer(svc service.Transfer) *Transfer { return &Transfer{svc} }
+
+// Transfer exposes service.Transfer over http
+type Transfer struct{ svc service.Transfer }
+
+// Register mounts transfer routes to the group, e.g. /transfers
+func (t *Transfer) Register(g *echo.Group) {
+	g.GET("", t.GetAll)
+	g.GET("/count", t.Count)
+	g.GET("/:id", t.GetByID)
+	g.POST("", t.Create)
+	g.PUT("/:id", t.Update)
+	g.DELETE("/:id", t.DeleteByID)
+}
+
+func (t *Transfer) Count(c echo.Context) error {
+	filter := &model.TransferFilter{}
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, filter); err != nil {
+		return err
+	}
+
+	response, err := t.svc.Count(c.Request().Context(), &service.TransferCountRequest{Filter: filter})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (t *Transfer) GetAll(c echo.Context) error {
+	filter := &model.TransferFilter{}
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, filter); err != nil {
+		return err
+	}
+
+	response, err := t.svc.GetAll(c.Request().Context(), &service.TransferGetAllRequest{Filter: filter})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (t *Transfer) GetByID(c echo.Context) error {
+	id, err := paramID(c)
+	if err != nil {
+		return err
+	}
+
+	response, err := t.svc.GetByID(c.Request().Context(), &service.TransferGetByIDRequest{ID: id})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (t *Transfer) Create(c echo.Context) error {
+	data := &model.Transfer{}
+	if err := (&echo.DefaultBinder{}).BindBody(c, data); err != nil {
+		return err
+	}
+
+	response, err := t.svc.Create(c.Request().Context(), &service.TransferCreateRequest{Data: data})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, response)
+}
+
+func (t *Transfer) Update(c echo.Context) error {
+	id, err := paramID(c)
+	if err != nil {
+		return err
+	}
+
+	data := &model.Transfer{}
+	if err = (&echo.DefaultBinder{}).BindBody(c, data); err != nil {
+		return err
+	}
+	data.ID = id
+
+	response, err := t.svc.Update(c.Request().Context(), &service.TransferUpdateRequest{Data: data})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (t *Transfer) DeleteByID(c echo.Context) error {
+	id, err := paramID(c)
+	if err != nil {
+		return err
+	}
+
+	response, err := t.svc.DeleteByID(c.Request().Context(), &service.TransferDeleteByIDRequest{ID: id})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/service"
+)
+
+func NewUser(svc service.User) *User { return &User{svc} }
+
+// User exposes service.User over http
+type User struct{ svc service.User }
+
+// Register mounts user routes to the group, e.g. /users
+func (u *User) Register(g *echo.Group) {
+	g.GET("/me", u.Get)
+	g.PUT("/me", u.Update)
+}
+
+func (u *User) Get(c echo.Context) error {
+	response, err := u.svc.Get(c.Request().Context(), &service.UserGetRequest{})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (u *User) Update(c echo.Context) error {
+	data := &model.User{}
+	if err := (&echo.DefaultBinder{}).BindBody(c, data); err != nil {
+		return err
+	}
+
+	response, err := u.svc.Update(c.Request().Context(), &service.UserUpdateRequest{Data: data})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/service"
+)
+
+func NewWallet(svc service.Wallet) *Wallet { return &Wallet{svc} }
+
+// Wallet exposes service.Wallet over http
+type Wallet struct{ svc service.Wallet }
+
+// Register mounts wallet routes to the group, e.g. /wallets
+func (w *Wallet) Register(g *echo.Group) {
+	g.GET("", w.GetAll)
+	g.GET("/count", w.Count)
+	g.GET("/:id", w.GetByID)
+	g.POST("", w.Create)
+	g.PUT("/:id", w.Update)
+	g.DELETE("/:id", w.DeleteByID)
+	g.POST("/:id/restore", w.Restore)
+}
+
+func (w *Wallet) Count(c echo.Context) error {
+	filter := &model.WalletFilter{}
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, filter); err != nil {
+		return err
+	}
+
+	response, err := w.svc.Count(c.Request().Context(), &service.WalletCountRequest{Filter: filter})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (w *Wallet) GetAll(c echo.Context) error {
+	filter := &model.WalletFilter{}
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, filter); err != nil {
+		return err
+	}
+
+	response, err := w.svc.GetAll(c.Request().Context(), &service.WalletGetAllRequest{Filter: filter})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (w *Wallet) GetByID(c echo.Context) error {
+	id, err := paramID(c)
+	if err != nil {
+		return err
+	}
+
+	response, err := w.svc.GetByID(c.Request().Context(), &service.WalletGetByIDRequest{ID: id})
+	if err != nil {
+		return err
+	}
+	setETag(c, response.Data)
+	return c.JSON(http.StatusOK, response)
+}
+
+func (w *Wallet) Create(c echo.Context) error {
+	data := &model.Wallet{}
+	if err := (&echo.DefaultBinder{}).BindBody(c, data); err != nil {
+		return err
+	}
+
+	response, err := w.svc.Create(c.Request().Context(), &service.WalletCreateRequest{Data: data})
+	if err != nil {
+		return err
+	}
+	setETag(c, response.Data)
+	return c.JSON(http.StatusCreated, response)
+}
+
+func (w *Wallet) Update(c echo.Context) error {
+	id, err := paramID(c)
+	if err != nil {
+		return err
+	}
+
+	data := &model.Wallet{}
+	if err = (&echo.DefaultBinder{}).BindBody(c, data); err != nil {
+		return err
+	}
+	data.ID = id
+
+	// If-Match takes precedence over the version of the body
+	version, err := ifMatch(c)
+	if err != nil {
+		return err
+	}
+	if version != 0 {
+		data.Version = version
+	}
+
+	response, err := w.svc.Update(c.Request().Context(), &service.WalletUpdateRequest{Data: data})
+	if err != nil {
+		return err
+	}
+	setETag(c, response.Data)
+	return c.JSON(http.StatusOK, response)
+}
+
+func (w *Wallet) DeleteByID(c echo.Context) error {
+	id, err := paramID(c)
+	if err != nil {
+		return err
+	}
+
+	response, err := w.svc.DeleteByID(c.Request().Context(), &service.WalletDeleteByIDRequest{ID: id})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (w *Wallet) Restore(c echo.Context) error {
+	id, err := paramID(c)
+	if err != nil {
+		return err
+	}
+
+	response, err := w.svc.Restore(c.Request().Context(), &service.WalletRestoreRequest{ID: id})
+	if err != nil {
+		return err
+	}
+	setETag(c, response.Data)
+	return c.JSON(http.StatusOK, response)
+}
+
+const (
+	headerETag    = "ETag"
+	headerIfMatch = "If-Match"
+)
+
+// setETag sets the ETag header to the version of the wallet
+func setETag(c echo.Context, data *model.Wallet) {
+	c.Response().Header().Set(headerETag, strconv.Quote(strconv.FormatUint(data.Version, 10)))
+}
+
+// ifMatch returns the wallet version of the If-Match header, 0 when the header is absent or "*".
+// Weak tags never match as If-Match uses the strong comparison
+func ifMatch(c echo.Context) (uint64, error) {
+	tag := strings.TrimSpace(c.Request().Header.Get(headerIfMatch))
+	if tag == "" || tag == "*" {
+		return 0, nil
+	}
+
+	unquoted, err := strconv.Unquote(tag)
+	if err == nil {
+		var version uint64
+		if version, err = strconv.ParseUint(unquoted, 10, 64); err == nil && version != 0 {
+			return version, nil
+		}
+	}
+	return 0, echo.NewHTTPError(http.StatusPreconditionFailed, "If-Match must be the ETag of the wallet").SetInternal(err)
+}
+
+func paramID(c echo.Context) (uint64, error) { return paramUint(c, "id") }
+
+// paramUint parses the path parameter of the name as a positive integer
+func paramUint(c echo.Context, name string) (uint64, error) {
+	value, err := strconv.ParseUint(c.Param(name), 10, 64)
+	if err != nil {
+		return 0, echo.NewHTTPError(http.StatusBadRequest, name+" must be a positive integer").SetInternal(err)
+	}
+	return value, nil
+}
@@ -0,0 +1,377 @@
+package handler_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/mustan989/wallet/app/internal/handler"
+	mock_service "github.com/mustan989/wallet/app/internal/service/mock"
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/pkg/logger"
+	"github.com/mustan989/wallet/repository"
+	"github.com/mustan989/wallet/service"
+)
+
+var log logger.Logger = logger.NewLogger(logger.WithWriters(map[logger.Severity]io.Writer{
+	logger.Debug:   io.Discard,
+	logger.Info:    io.Discard,
+	logger.Warning: io.Discard,
+	logger.Error:   io.Discard,
+}))
+
+func boolp(b bool) *bool { return &b }
+
+func moneyp(m model.Money) *model.Money { return &m }
+
+// unbound is an amount as requests carry it, in ten-thousandths of any currency
+func unbound(amount int64) model.Money { return model.Money{Amount: amount, Currency: model.Unbound} }
+
+func timep(t time.Time) *time.Time { return &t }
+
+func newServer(svc service.Wallet) *echo.Echo {
+	e := echo.New()
+	e.HTTPErrorHandler = ErrorHandler(log)
+	NewWallet(svc).Register(e.Group("/wallets"))
+	return e
+}
+
+func serve(e *echo.Echo, method, target, body string) *httptest.ResponseRecorder {
+	return record(e, newRequest(method, target, body))
+}
+
+func newRequest(method, target, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	}
+	return req
+}
+
+func record(e *echo.Echo, req *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+var date = time.Date(1999, 2, 23, 4, 36, 0, 0, time.UTC)
+
+const walletJSON = `{"id":1,"owner_id":1,"name":"name","description":null,"currency":"KZT","amount":99.99,"personal":true,"created_at":"1999-02-23T04:36:00Z","updated_at":"1999-02-23T04:36:00Z","deleted_at":null,"version":2}`
+
+func walletData() *model.Wallet {
+	return &model.Wallet{ID: 1, OwnerID: 1, Name: "name", Currency: "KZT", Amount: model.Money{Amount: 9999, Currency: model.Currency{Code: "KZT", Exponent: 2}}, Personal: true, CreatedAt: date, UpdatedAt: date, Version: 2}
+}
+
+func TestWallet_Count(t *testing.T) {
+	subtests := [...]struct {
+		name   string
+		query  string
+		filter *model.WalletFilter
+	}{
+		{"None", "", &model.WalletFilter{}},
+		{"NameLike", "?name_like=name", &model.WalletFilter{NameLike: "name"}},
+		{"Currency Personal", "?currency=KZT&personal=true", &model.WalletFilter{Currency: "KZT", Personal: boolp(true)}},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			svc := mock_service.NewMockWallet(ctl)
+
+			svc.EXPECT().
+				Count(gomock.Any(), &service.WalletCountRequest{Filter: subtest.filter}).
+				Return(&service.WalletCountResponse{Count: 1}, nil)
+
+			rec := serve(newServer(svc), http.MethodGet, "/wallets/count"+subtest.query, "")
+			require.Equal(t, http.StatusOK, rec.Code)
+			require.JSONEq(t, `{"count":1}`, rec.Body.String())
+		})
+	}
+}
+
+func TestWallet_GetAll(t *testing.T) {
+	subtests := [...]struct {
+		name   string
+		query  string
+		filter *model.WalletFilter
+	}{
+		{"None", "", &model.WalletFilter{}},
+		{"DescriptionLike", "?description_like=desc", &model.WalletFilter{DescriptionLike: "desc"}},
+		{"Limit Offset", "?limit=3&offset=2", &model.WalletFilter{Filter: model.Filter{Limit: 3, Offset: 2}}},
+		{"Personal", "?personal=false", &model.WalletFilter{Personal: boolp(false)}},
+		{"Currencies", "?currencies=KZT&currencies=USD", &model.WalletFilter{Currencies: []string{"KZT", "USD"}}},
+		{"Amount", "?amount_min=10&amount_max=99.99", &model.WalletFilter{AmountMin: moneyp(unbound(100000)), AmountMax: moneyp(unbound(999900))}},
+		{
+			"Dates",
+			"?created_from=1999-02-23T04:36:00Z&created_to=1999-02-24T00:00:00Z&updated_from=1999-02-23T04:36:00Z",
+			&model.WalletFilter{CreatedFrom: timep(date), CreatedTo: timep(time.Date(1999, 2, 24, 0, 0, 0, 0, time.UTC)), UpdatedFrom: timep(date)},
+		},
+		{"Deleted Sort Order", "?deleted=only&sort=amount&order=desc", &model.WalletFilter{Deleted: model.DeletedOnly, Sort: model.WalletSortAmount, Order: model.Desc}},
+		{
+			"Cursor",
+			"?limit=2&sort=name&cursor=eyJzIjoibmFtZSBhc2MiLCJrIjoibmFtZSIsImkiOjN9",
+			&model.WalletFilter{Filter: model.Filter{Limit: 2, Cursor: model.NewCursor("name asc", "name", 3)}, Sort: model.WalletSortName},
+		},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			svc := mock_service.NewMockWallet(ctl)
+
+			svc.EXPECT().
+				GetAll(gomock.Any(), &service.WalletGetAllRequest{Filter: subtest.filter}).
+				Return(&service.WalletGetAllResponse{Data: []*model.Wallet{walletData()}, Total: 1}, nil)
+
+			rec := serve(newServer(svc), http.MethodGet, "/wallets"+subtest.query, "")
+			require.Equal(t, http.StatusOK, rec.Code)
+			require.JSONEq(t, `{"data":[`+walletJSON+`],"total":1}`, rec.Body.String())
+		})
+	}
+}
+
+func TestWallet_GetAllBadRequest(t *testing.T) {
+	ctl := gomock.NewController(t)
+	svc := mock_service.NewMockWallet(ctl)
+
+	for _, query := range []string{"?limit=many", "?amount_min=ten", "?created_from=yesterday", "?cursor=e30"} {
+		rec := serve(newServer(svc), http.MethodGet, "/wallets"+query, "")
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		require.Contains(t, rec.Body.String(), `"code":400`)
+	}
+}
+
+func TestWallet_GetByID(t *testing.T) {
+	ctl := gomock.NewController(t)
+	svc := mock_service.NewMockWallet(ctl)
+
+	svc.EXPECT().
+		GetByID(gomock.Any(), &service.WalletGetByIDRequest{ID: 1}).
+		Return(&service.WalletGetByIDResponse{Data: walletData()}, nil)
+
+	rec := serve(newServer(svc), http.MethodGet, "/wallets/1", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, `"2"`, rec.Header().Get("ETag"))
+	require.JSONEq(t, `{"data":`+walletJSON+`}`, rec.Body.String())
+}
+
+func TestWallet_GetByIDError(t *testing.T) {
+	subtests := [...]struct {
+		name   string
+		target string
+		err    error
+		code   int
+		body   string
+	}{
+		{"Not found", "/wallets/1", repository.ErrWalletNotFound, http.StatusNotFound, `{"error":{"code":404,"message":"wallet not found"}}`},
+		{"Internal", "/wallets/1", errors.New("connection error"), http.StatusInternalServerError, `{"error":{"code":500,"message":"Internal Server Error"}}`},
+		{"Bad id", "/wallets/one", nil, http.StatusBadRequest, `{"error":{"code":400,"message":"id must be a positive integer"}}`},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			svc := mock_service.NewMockWallet(ctl)
+
+			if subtest.err != nil {
+				svc.EXPECT().
+					GetByID(gomock.Any(), &service.WalletGetByIDRequest{ID: 1}).
+					Return(nil, subtest.err)
+			}
+
+			rec := serve(newServer(svc), http.MethodGet, subtest.target, "")
+			require.Equal(t, subtest.code, rec.Code)
+			require.JSONEq(t, subtest.body, rec.Body.String())
+		})
+	}
+}
+
+func TestWallet_Create(t *testing.T) {
+	ctl := gomock.NewController(t)
+	svc := mock_service.NewMockWallet(ctl)
+
+	svc.EXPECT().
+		Create(gomock.Any(), &service.WalletCreateRequest{Data: &model.Wallet{Name: "name", Currency: "KZT", Amount: unbound(999900), Personal: true}}).
+		DoAndReturn(func(_ context.Context, request *service.WalletCreateRequest) (*service.WalletCreateResponse, error) {
+			request.Data.ID = 1
+			request.Data.OwnerID = 1
+			request.Data.CreatedAt = date
+			request.Data.UpdatedAt = date
+			request.Data.Version = 2
+			return &service.WalletCreateResponse{Data: request.Data}, nil
+		})
+
+	rec := serve(newServer(svc), http.MethodPost, "/wallets", `{"name":"name","currency":"KZT","amount":99.99,"personal":true}`)
+	require.Equal(t, http.StatusCreated, rec.Code)
+	require.JSONEq(t, `{"data":`+walletJSON+`}`, rec.Body.String())
+}
+
+func TestWallet_CreateError(t *testing.T) {
+	subtests := [...]struct {
+		name string
+		body string
+		err  error
+		code int
+	}{
+		{"Conflict", `{"name":"name"}`, repository.ErrWalletConflict, http.StatusConflict},
+		{"Bad amount", `{"amount":"99.99.99"}`, nil, http.StatusBadRequest},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			svc := mock_service.NewMockWallet(ctl)
+
+			if subtest.err != nil {
+				svc.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil, subtest.err)
+			}
+
+			rec := serve(newServer(svc), http.MethodPost, "/wallets", subtest.body)
+			require.Equal(t, subtest.code, rec.Code)
+		})
+	}
+}
+
+func TestWallet_Update(t *testing.T) {
+	ctl := gomock.NewController(t)
+	svc := mock_service.NewMockWallet(ctl)
+
+	svc.EXPECT().
+		Update(gomock.Any(), &service.WalletUpdateRequest{Data: &model.Wallet{ID: 1, Name: "name", Currency: "KZT", Amount: unbound(999900), Personal: true}}).
+		DoAndReturn(func(_ context.Context, request *service.WalletUpdateRequest) (*service.WalletUpdateResponse, error) {
+			request.Data.OwnerID = 1
+			request.Data.CreatedAt = date
+			request.Data.UpdatedAt = date
+			request.Data.Version = 2
+			return &service.WalletUpdateResponse{Data: request.Data}, nil
+		})
+
+	rec := serve(newServer(svc), http.MethodPut, "/wallets/1", `{"id":2,"name":"name","currency":"KZT","amount":99.99,"personal":true}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, `"2"`, rec.Header().Get("ETag"))
+	require.JSONEq(t, `{"data":`+walletJSON+`}`, rec.Body.String())
+}
+
+func TestWallet_UpdateIfMatch(t *testing.T) {
+	subtests := [...]struct {
+		name    string
+		body    string
+		ifMatch string
+		version uint64
+		err     error
+		code    int
+	}{
+		{"Header", `{"name":"name","currency":"KZT"}`, `"1"`, 1, nil, http.StatusOK},
+		{"Header over body", `{"name":"name","currency":"KZT","version":5}`, `"1"`, 1, nil, http.StatusOK},
+		{"Body", `{"name":"name","currency":"KZT","version":1}`, "", 1, nil, http.StatusOK},
+		{"Any", `{"name":"name","currency":"KZT"}`, "*", 0, nil, http.StatusOK},
+		{"Stale", `{"name":"name","currency":"KZT"}`, `"1"`, 1, repository.ErrWalletStale, http.StatusPreconditionFailed},
+		{"Weak", `{"name":"name","currency":"KZT"}`, `W/"1"`, 0, nil, http.StatusPreconditionFailed},
+		{"Unquoted", `{"name":"name","currency":"KZT"}`, "1", 0, nil, http.StatusPreconditionFailed},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			svc := mock_service.NewMockWallet(ctl)
+
+			if subtest.code == http.StatusOK || subtest.err != nil {
+				svc.EXPECT().
+					Update(gomock.Any(), &service.WalletUpdateRequest{Data: &model.Wallet{ID: 1, Name: "name", Currency: "KZT", Version: subtest.version}}).
+					DoAndReturn(func(_ context.Context, request *service.WalletUpdateRequest) (*service.WalletUpdateResponse, error) {
+						if subtest.err != nil {
+							return nil, subtest.err
+						}
+						request.Data.Version = 2
+						return &service.WalletUpdateResponse{Data: request.Data}, nil
+					})
+			}
+
+			req := newRequest(http.MethodPut, "/wallets/1", subtest.body)
+			if subtest.ifMatch != "" {
+				req.Header.Set("If-Match", subtest.ifMatch)
+			}
+
+			rec := record(newServer(svc), req)
+			require.Equal(t, subtest.code, rec.Code)
+			if subtest.code == http.StatusOK {
+				require.Equal(t, `"2"`, rec.Header().Get("ETag"))
+			}
+		})
+	}
+}
+
+func TestWallet_DeleteByID(t *testing.T) {
+	subtests := [...]struct {
+		name string
+		err  error
+		code int
+	}{
+		{"Deleted", nil, http.StatusOK},
+		{"Not found", repository.ErrWalletNotFound, http.StatusNotFound},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			svc := mock_service.NewMockWallet(ctl)
+
+			var response *service.WalletDeleteByIDResponse
+			if subtest.err == nil {
+				response = &service.WalletDeleteByIDResponse{Data: walletData()}
+			}
+
+			svc.EXPECT().
+				DeleteByID(gomock.Any(), &service.WalletDeleteByIDRequest{ID: 1}).
+				Return(response, subtest.err)
+
+			rec := serve(newServer(svc), http.MethodDelete, "/wallets/1", "")
+			require.Equal(t, subtest.code, rec.Code)
+		})
+	}
+}
+
+func TestWallet_Restore(t *testing.T) {
+	subtests := [...]struct {
+		name string
+		err  error
+		code int
+	}{
+		{"Restored", nil, http.StatusOK},
+		{"Not deleted", service.ErrInvalidArgument, http.StatusBadRequest},
+		{"Not found", repository.ErrWalletNotFound, http.StatusNotFound},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			svc := mock_service.NewMockWallet(ctl)
+
+			var response *service.WalletRestoreResponse
+			if subtest.err == nil {
+				response = &service.WalletRestoreResponse{Data: walletData()}
+			}
+
+			svc.EXPECT().
+				Restore(gomock.Any(), &service.WalletRestoreRequest{ID: 1}).
+				Return(response, subtest.err)
+
+			rec := serve(newServer(svc), http.MethodPost, "/wallets/1/restore", "")
+			require.Equal(t, subtest.code, rec.Code)
+			if subtest.err == nil {
+				require.JSONEq(t, `{"data":`+walletJSON+`}`, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/repository"
+)
+
+// NewMember returns the members of the wallets in the store, users are not checked to exist
+func NewMember(store *Store) repository.Member { return &member{store} }
+
+type member struct{ store *Store }
+
+func (m *member) FindAll(ctx context.Context, walletID uint64) (data []*model.Member, err error) {
+	userID, err := model.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	m.store.mu.RLock()
+	defer m.store.mu.RUnlock()
+
+	data = []*model.Member{}
+	if wallet, ok := m.store.wallets[walletID]; !ok || !m.store.accessible(wallet, userID) {
+		return
+	}
+	for _, elem := range m.store.members[walletID] {
+		c := *elem
+		data = append(data, &c)
+	}
+	sort.Slice(data, func(i, j int) bool { return data[i].UserID < data[j].UserID })
+
+	return
+}
+
+func (m *member) FindRole(ctx context.Context, walletID uint64) (role model.Role, err error) {
+	userID, err := model.UserID(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	m.store.mu.RLock()
+	defer m.store.mu.RUnlock()
+
+	wallet, ok := m.store.wallets[walletID]
+	if !ok || !m.store.accessible(wallet, userID) {
+		return "", repository.ErrWalletNotFound
+	}
+	if wallet.OwnerID == userID {
+		return model.Owner, nil
+	}
+	return m.store.members[walletID][userID].Role, nil
+}
+
+func (m *member) Save(ctx context.Context, data *model.Member) error {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	if _, ok := m.store.wallets[data.WalletID]; !ok {
+		return repository.ErrWalletNotFound
+	}
+
+	members := m.store.members[data.WalletID]
+	if members == nil {
+		members = map[uint64]*model.Member{}
+		m.store.members[data.WalletID] = members
+	}
+
+	saved := *data
+	saved.UpdatedAt = now()
+	if old, ok := members[data.UserID]; ok {
+		saved.CreatedAt = old.CreatedAt
+	} else {
+		saved.CreatedAt = saved.UpdatedAt
+	}
+
+	members[data.UserID] = &saved
+	*data = saved
+	return nil
+}
+
+func (m *member) DeleteByID(ctx context.Context, walletID, userID uint64) (deleted *model.Member, err error) {
+	actingID, err := model.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	wallet, ok := m.store.wallets[walletID]
+	if !ok || !m.store.accessible(wallet, actingID) {
+		return nil, repository.ErrMemberNotFound
+	}
+	deleted, ok = m.store.members[walletID][userID]
+	if !ok {
+		return nil, repository.ErrMemberNotFound
+	}
+
+	delete(m.store.members[walletID], userID)
+	return deleted, nil
+}
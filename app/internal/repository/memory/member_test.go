@@ -0,0 +1,63 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/mustan989/wallet/app/internal/repository/memory"
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/repository"
+)
+
+func TestMember(t *testing.T) {
+	store := NewStore()
+	wallets, members := NewWallet(store), NewMember(store)
+	created := seed(t, wallets,
+		&model.Wallet{Name: "shared", Currency: "KZT"},
+		&model.Wallet{Name: "personal", Currency: "KZT", Personal: true},
+	)
+	shared, personal := created[0], created[1]
+
+	role, err := members.FindRole(userCtx, shared.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.Owner, role)
+
+	_, err = members.FindRole(strangerCtx, shared.ID)
+	assert.ErrorIs(t, err, repository.ErrWalletNotFound)
+
+	for _, walletID := range []uint64{shared.ID, personal.ID} {
+		require.NoError(t, members.Save(userCtx, &model.Member{WalletID: walletID, UserID: stranger, Role: model.Viewer}))
+	}
+	assert.ErrorIs(t, members.Save(userCtx, &model.Member{WalletID: 10, UserID: stranger, Role: model.Viewer}), repository.ErrWalletNotFound)
+
+	role, err = members.FindRole(strangerCtx, shared.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.Viewer, role)
+
+	// personal wallets are never shared even with members
+	_, err = members.FindRole(strangerCtx, personal.ID)
+	assert.ErrorIs(t, err, repository.ErrWalletNotFound)
+
+	data, err := wallets.FindAll(strangerCtx, &model.WalletFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{shared.ID}, ids(data))
+
+	updated := &model.Member{WalletID: shared.ID, UserID: stranger, Role: model.Editor}
+	require.NoError(t, members.Save(userCtx, updated))
+
+	found, err := members.FindAll(strangerCtx, shared.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []*model.Member{updated}, found)
+
+	deleted, err := members.DeleteByID(userCtx, shared.ID, stranger)
+	require.NoError(t, err)
+	assert.Equal(t, updated, deleted)
+
+	_, err = members.DeleteByID(userCtx, shared.ID, stranger)
+	assert.ErrorIs(t, err, repository.ErrMemberNotFound)
+
+	_, err = wallets.FindByID(strangerCtx, shared.ID)
+	assert.ErrorIs(t, err, repository.ErrWalletNotFound)
+}
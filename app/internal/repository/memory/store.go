@@ -0,0 +1,38 @@
+package memory
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mustan989/wallet/model"
+)
+
+// Store keeps wallets and their members in process, repositories made from one store share the data.
+// It is safe for concurrent use, the data is lost with the process
+type Store struct {
+	mu      sync.RWMutex
+	lastID  uint64
+	wallets map[uint64]*model.Wallet
+	// members of the wallets by wallet and user id
+	members map[uint64]map[uint64]*model.Member
+}
+
+func NewStore() *Store {
+	return &Store{
+		wallets: map[uint64]*model.Wallet{},
+		members: map[uint64]map[uint64]*model.Member{},
+	}
+}
+
+// accessible reports whether the wallet is owned by the user or shared with them, personal wallets are never shared.
+// The caller holds the lock
+func (s *Store) accessible(wallet *model.Wallet, userID uint64) bool {
+	if wallet.OwnerID == userID {
+		return true
+	}
+	_, member := s.members[wallet.ID][userID]
+	return !wallet.Personal && member
+}
+
+// now is the current time at the precision of postgres timestamps
+func now() time.Time { return time.Now().UTC().Truncate(time.Microsecond) }
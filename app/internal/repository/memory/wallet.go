@@ -0,0 +1,323 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/repository"
+)
+
+func NewWallet(store *Store) repository.Wallet { return &wallet{store} }
+
+type wallet struct{ store *Store }
+
+func (w *wallet) CountAll(ctx context.Context, filter *model.WalletFilter) (count uint64, err error) {
+	userID, err := model.UserID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	w.store.mu.RLock()
+	defer w.store.mu.RUnlock()
+
+	for _, elem := range w.store.wallets {
+		if w.store.accessible(elem, userID) && matches(elem, filter) {
+			count++
+		}
+	}
+	return
+}
+
+func (w *wallet) FindAll(ctx context.Context, filter *model.WalletFilter) (data []*model.Wallet, err error) {
+	userID, err := model.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the cursor is a wallet with only the sort key and the id set
+	var cursor *model.Wallet
+	if filter.Cursor != nil {
+		key, err := filter.After()
+		if err != nil {
+			return nil, err
+		}
+		cursor = &model.Wallet{ID: filter.Cursor.ID}
+		switch key := key.(type) {
+		case *string:
+			cursor.Name, cursor.Currency = *key, *key
+		case *model.Money:
+			cursor.Amount = *key
+		case *time.Time:
+			cursor.CreatedAt, cursor.UpdatedAt = *key, *key
+		}
+	}
+
+	sortBy, order := filter.Ordering()
+	less := func(a, b *model.Wallet) bool {
+		if c := compare(a, b, sortBy); c != 0 {
+			return c < 0 == (order == model.Asc)
+		}
+		return a.ID != b.ID && a.ID < b.ID == (order == model.Asc)
+	}
+
+	w.store.mu.RLock()
+	data = []*model.Wallet{}
+	for _, elem := range w.store.wallets {
+		if w.store.accessible(elem, userID) && matches(elem, filter) && (cursor == nil || less(cursor, elem)) {
+			data = append(data, clone(elem))
+		}
+	}
+	w.store.mu.RUnlock()
+
+	sort.Slice(data, func(i, j int) bool { return less(data[i], data[j]) })
+
+	if filter.Offset >= uint64(len(data)) {
+		return []*model.Wallet{}, nil
+	}
+	data = data[filter.Offset:]
+	if filter.Limit != 0 && filter.Limit < uint64(len(data)) {
+		data = data[:filter.Limit]
+	}
+	return data, nil
+}
+
+func (w *wallet) FindByID(ctx context.Context, id uint64) (data *model.Wallet, err error) {
+	userID, err := model.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	w.store.mu.RLock()
+	defer w.store.mu.RUnlock()
+
+	elem, ok := w.store.wallets[id]
+	if !ok || !w.store.accessible(elem, userID) {
+		return nil, repository.ErrWalletNotFound
+	}
+	return clone(elem), nil
+}
+
+func (w *wallet) Create(ctx context.Context, data *model.Wallet) error {
+	owner, err := model.UserID(ctx)
+	if err != nil {
+		return err
+	}
+	// like the databases the store returns the amount bound to the currency
+	if err = model.Bind(data.Currency, &data.Amount); err != nil {
+		return err
+	}
+	if !inRange(data.Amount) {
+		return model.ErrDecimalOverflow
+	}
+
+	w.store.mu.Lock()
+	defer w.store.mu.Unlock()
+
+	// like the database the store assigns the id, whatever the wallet says
+	w.store.lastID++
+	data.ID = w.store.lastID
+	data.OwnerID = owner
+	data.CreatedAt = now()
+	data.UpdatedAt = data.CreatedAt
+	data.Version = 1
+
+	w.store.wallets[data.ID] = clone(data)
+	return nil
+}
+
+func (w *wallet) Update(ctx context.Context, data *model.Wallet) error {
+	userID, err := model.UserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	w.store.mu.Lock()
+	defer w.store.mu.Unlock()
+
+	elem, ok := w.store.wallets[data.ID]
+	if !ok || !w.store.accessible(elem, userID) {
+		return repository.ErrWalletNotFound
+	}
+	if elem.Version != data.Version {
+		return repository.ErrWalletStale
+	}
+
+	updated := clone(data)
+	updated.OwnerID = elem.OwnerID
+	// the amount keeps its value in the currency the wallet changes to
+	if updated.Amount, err = elem.Amount.In(model.Unbound); err != nil {
+		return err
+	}
+	if err = model.Bind(updated.Currency, &updated.Amount); err != nil {
+		return err
+	}
+	updated.CreatedAt = elem.CreatedAt
+	updated.UpdatedAt = now()
+	updated.Version = elem.Version + 1
+
+	w.store.wallets[data.ID] = updated
+	*data = *clone(updated)
+	return nil
+}
+
+func (w *wallet) DeleteByID(ctx context.Context, id uint64) (deleted *model.Wallet, err error) {
+	userID, err := model.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	w.store.mu.Lock()
+	defer w.store.mu.Unlock()
+
+	elem, ok := w.store.wallets[id]
+	if !ok || !w.store.accessible(elem, userID) {
+		return nil, repository.ErrWalletNotFound
+	}
+
+	delete(w.store.wallets, id)
+	delete(w.store.members, id)
+	return elem, nil
+}
+
+func (w *wallet) Purge(ctx context.Context, before time.Time) (count uint64, err error) {
+	w.store.mu.Lock()
+	defer w.store.mu.Unlock()
+
+	for id, elem := range w.store.wallets {
+		if elem.DeletedAt != nil && elem.DeletedAt.Before(before) {
+			delete(w.store.wallets, id)
+			delete(w.store.members, id)
+			count++
+		}
+	}
+	return
+}
+
+// matches reports whether the wallet passes the conditions of the filter, the pagination is not applied
+func matches(w *model.Wallet, filter *model.WalletFilter) bool {
+	if filter.NameLike != "" && !like(w.Name, "%"+filter.NameLike+"%") {
+		return false
+	}
+	if filter.DescriptionLike != "" && (w.Description == nil || !like(*w.Description, "%"+filter.DescriptionLike+"%")) {
+		return false
+	}
+	if filter.Currency != "" && w.Currency != filter.Currency {
+		return false
+	}
+	if len(filter.Currencies) != 0 && !contains(filter.Currencies, w.Currency) {
+		return false
+	}
+	if filter.Personal != nil && w.Personal != *filter.Personal {
+		return false
+	}
+	if filter.AmountMin != nil && w.Amount.Cmp(*filter.AmountMin) < 0 || filter.AmountMax != nil && w.Amount.Cmp(*filter.AmountMax) > 0 {
+		return false
+	}
+	if filter.CreatedFrom != nil && w.CreatedAt.Before(*filter.CreatedFrom) || filter.CreatedTo != nil && !w.CreatedAt.Before(*filter.CreatedTo) {
+		return false
+	}
+	if filter.UpdatedFrom != nil && w.UpdatedAt.Before(*filter.UpdatedFrom) || filter.UpdatedTo != nil && !w.UpdatedAt.Before(*filter.UpdatedTo) {
+		return false
+	}
+
+	switch filter.Deleted {
+	case model.DeletedInclude:
+		return true
+	case model.DeletedOnly:
+		return w.DeletedAt != nil
+	default:
+		return w.DeletedAt == nil
+	}
+}
+
+// like reports whether s matches the pattern the way LIKE of the databases does, case-sensitively:
+// % matches any run of characters and _ a single character
+func like(s, pattern string) bool {
+	return likeRunes([]rune(s), []rune(pattern))
+}
+
+func likeRunes(s, pattern []rune) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '%':
+			for len(pattern) > 0 && pattern[0] == '%' {
+				pattern = pattern[1:]
+			}
+			for i := 0; i <= len(s); i++ {
+				if likeRunes(s[i:], pattern) {
+					return true
+				}
+			}
+			return false
+		case '_':
+			if len(s) == 0 {
+				return false
+			}
+		default:
+			if len(s) == 0 || s[0] != pattern[0] {
+				return false
+			}
+		}
+		s, pattern = s[1:], pattern[1:]
+	}
+	return len(s) == 0
+}
+
+// compare orders the wallets by the sort field alone, text by bytes like the BINARY collation of SQLite
+func compare(a, b *model.Wallet, by model.WalletSort) int {
+	switch by {
+	case model.WalletSortName:
+		return strings.Compare(a.Name, b.Name)
+	case model.WalletSortCurrency:
+		return strings.Compare(a.Currency, b.Currency)
+	case model.WalletSortAmount:
+		return a.Amount.Cmp(b.Amount)
+	case model.WalletSortCreatedAt:
+		return compareTime(a.CreatedAt, b.CreatedAt)
+	case model.WalletSortUpdatedAt:
+		return compareTime(a.UpdatedAt, b.UpdatedAt)
+	}
+	return 0
+}
+
+func compareTime(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	}
+	return 0
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// inRange mirrors the wallets_amount_range check of the databases
+func inRange(amount model.Money) bool {
+	stored, err := amount.In(model.Unbound)
+	return err == nil && -model.MaxStored <= stored.Amount && stored.Amount <= model.MaxStored
+}
+
+// clone copies the wallet so the stored one is never shared with the callers
+func clone(w *model.Wallet) *model.Wallet {
+	c := *w
+	if w.Description != nil {
+		description := *w.Description
+		c.Description = &description
+	}
+	if w.DeletedAt != nil {
+		deletedAt := *w.DeletedAt
+		c.DeletedAt = &deletedAt
+	}
+	return &c
+}
@@ -0,0 +1,126 @@
+package memory_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/mustan989/wallet/app/internal/repository/memory"
+	"github.com/mustan989/wallet/app/internal/repository/repositorytest"
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/repository"
+)
+
+func stringp(s string) *string { return &s }
+
+const (
+	owner    uint64 = 1
+	stranger uint64 = 2
+)
+
+var (
+	kzt = model.Currency{Code: "KZT", Exponent: 2}
+
+	userCtx     = model.WithUserID(context.Background(), owner)
+	strangerCtx = model.WithUserID(context.Background(), stranger)
+)
+
+// seed creates the wallets in order and returns them as stored
+func seed(t *testing.T, repo repository.Wallet, data ...*model.Wallet) []*model.Wallet {
+	for _, datum := range data {
+		require.NoError(t, repo.Create(userCtx, datum))
+	}
+	return data
+}
+
+func ids(data []*model.Wallet) (ids []uint64) {
+	for _, datum := range data {
+		ids = append(ids, datum.ID)
+	}
+	return
+}
+
+func TestWallet(t *testing.T) {
+	repositorytest.TestWallet(t, func(t *testing.T) repository.Wallet { return NewWallet(NewStore()) })
+}
+
+func TestWallet_FindByID(t *testing.T) {
+	repo := NewWallet(NewStore())
+	created := seed(t, repo, &model.Wallet{Name: "cash", Description: stringp("pocket"), Currency: "KZT"})[0]
+
+	data, err := repo.FindByID(userCtx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, created, data)
+
+	// the stored wallet is not shared with the caller
+	*data.Description = "changed"
+	data, err = repo.FindByID(userCtx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "pocket", *data.Description)
+
+	_, err = repo.FindByID(userCtx, 2)
+	assert.ErrorIs(t, err, repository.ErrWalletNotFound)
+
+	_, err = repo.FindByID(strangerCtx, created.ID)
+	assert.ErrorIs(t, err, repository.ErrWalletNotFound)
+}
+
+func TestWallet_Create(t *testing.T) {
+	repo := NewWallet(NewStore())
+
+	data := &model.Wallet{Name: "cash", Currency: "KZT", Amount: model.Money{Amount: 100, Currency: kzt}}
+	require.NoError(t, repo.Create(userCtx, data))
+	assert.Equal(t, uint64(1), data.ID)
+	assert.Equal(t, owner, data.OwnerID)
+	assert.Equal(t, uint64(1), data.Version)
+	assert.False(t, data.CreatedAt.IsZero())
+
+	subtests := []struct {
+		name string
+		data *model.Wallet
+		err  error
+	}{
+		{"Amount out of range", &model.Wallet{Name: "cash", Currency: "KZT", Amount: model.Money{Amount: model.MaxStored/100 + 1, Currency: kzt}}, model.ErrDecimalOverflow},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			assert.Equal(t, subtest.err, repo.Create(userCtx, subtest.data))
+		})
+	}
+
+	// the id of the wallet is ignored rather than taking over or skipping ahead
+	explicit := &model.Wallet{ID: 1, Name: "card", Currency: "KZT"}
+	require.NoError(t, repo.Create(userCtx, explicit))
+	assert.Equal(t, uint64(2), explicit.ID)
+	found, err := repo.FindByID(userCtx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, model.Money{Amount: 100, Currency: kzt}, found.Amount)
+
+	data = &model.Wallet{ID: 10, Name: "cash", Currency: "KZT"}
+	require.NoError(t, repo.Create(userCtx, data))
+	assert.Equal(t, uint64(3), data.ID)
+}
+
+func TestWallet_Concurrent(t *testing.T) {
+	repo := NewWallet(NewStore())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, repo.Create(userCtx, &model.Wallet{Name: "cash", Currency: "KZT"}))
+			_, err := repo.FindAll(userCtx, &model.WalletFilter{})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	count, err := repo.CountAll(userCtx, &model.WalletFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(50), count)
+}
@@ -7,6 +7,7 @@ package mock_repository
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	model "github.com/mustan989/wallet/model"
@@ -109,6 +110,21 @@ func (mr *MockWalletMockRecorder) FindByID(ctx, id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockWallet)(nil).FindByID), ctx, id)
 }
 
+// Purge mocks base method.
+func (m *MockWallet) Purge(ctx context.Context, before time.Time) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, before)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockWalletMockRecorder) Purge(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockWallet)(nil).Purge), ctx, before)
+}
+
 // Update mocks base method.
 func (m *MockWallet) Update(ctx context.Context, data *model.Wallet) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,211 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/repository"
+)
+
+func NewCategory(pool Pool) repository.Category { return &category{pool} }
+
+type category struct{ pool Pool }
+
+func scanCategory(row pgx.Row, data *model.Category) error {
+	return row.Scan(
+		&data.ID, &data.OwnerID, &data.ParentID, &data.Name, &data.Type, &data.CreatedAt, &data.UpdatedAt, &data.ArchivedAt,
+	)
+}
+
+func (c *category) CountAll(ctx context.Context, filter *model.CategoryFilter) (count uint64, err error) {
+	owner, err := model.UserID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	sql, args := dialect.CountCategories(owner, filter)
+
+	err = conn(ctx, c.pool).QueryRow(ctx, sql, args...).Scan(&count)
+
+	return
+}
+
+func (c *category) FindAll(ctx context.Context, filter *model.CategoryFilter) (data []*model.Category, err error) {
+	owner, err := model.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, args, err := dialect.FindCategories(owner, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn(ctx, c.pool).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data = []*model.Category{}
+	for rows.Next() {
+		elem := &model.Category{}
+
+		if err = scanCategory(rows, elem); err != nil {
+			return nil, err
+		}
+
+		data = append(data, elem)
+	}
+
+	return
+}
+
+func (c *category) FindByID(ctx context.Context, id uint64) (data *model.Category, err error) {
+	owner, err := model.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, args := dialect.FindCategory(owner, id)
+
+	data = &model.Category{}
+	if err = categoryError(scanCategory(conn(ctx, c.pool).QueryRow(ctx, sql, args...), data)); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (c *category) Create(ctx context.Context, data *model.Category) error {
+	owner, err := model.UserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	sql, args := dialect.CreateCategory(owner, data)
+
+	return categoryError(scanCategory(conn(ctx, c.pool).QueryRow(ctx, sql, args...), data))
+}
+
+func (c *category) Update(ctx context.Context, data *model.Category) error {
+	owner, err := model.UserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	sql, args := dialect.UpdateCategory(owner, data)
+
+	return categoryError(scanCategory(conn(ctx, c.pool).QueryRow(ctx, sql, args...), data))
+}
+
+func (c *category) Archive(ctx context.Context, id uint64, at time.Time) (archived *model.Category, err error) {
+	owner, err := model.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, args := dialect.ArchiveCategory(owner, id, at)
+
+	archived = &model.Category{}
+	if err = categoryError(scanCategory(conn(ctx, c.pool).QueryRow(ctx, sql, args...), archived)); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (c *category) Used(ctx context.Context, id uint64) (used bool, err error) {
+	sql, args := dialect.CategoryUsed(id)
+
+	err = conn(ctx, c.pool).QueryRow(ctx, sql, args...).Scan(&used)
+
+	return
+}
+
+func (c *category) DeleteByID(ctx context.Context, id uint64, reassignTo *uint64) (deleted *model.Category, err error) {
+	owner, err := model.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = inTx(ctx, c.pool, func(tx pgx.Tx) error {
+		if err := checkCategory(ctx, tx, reassignTo); err != nil {
+			return err
+		}
+
+		if reassignTo != nil {
+			sql, args := dialect.ReassignTransactions(id, *reassignTo)
+
+			if _, err := tx.Exec(ctx, sql, args...); err != nil {
+				return categoryError(err)
+			}
+		}
+
+		// children are moved to the parent of the category being deleted
+		sql, args := dialect.MoveChildren(owner, id)
+
+		if _, err := tx.Exec(ctx, sql, args...); err != nil {
+			return categoryError(err)
+		}
+
+		sql, args = dialect.DeleteCategory(owner, id)
+
+		deleted = &model.Category{}
+		return categoryError(scanCategory(tx.QueryRow(ctx, sql, args...), deleted))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// checkCategory makes sure the category, if any, is owned by the acting user
+func checkCategory(ctx context.Context, q querier, id *uint64) error {
+	if id == nil {
+		return nil
+	}
+
+	owner, err := model.UserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	sql, args := dialect.CategoryExists(owner, *id)
+
+	var found int
+	return categoryError(q.QueryRow(ctx, sql, args...).Scan(&found))
+}
+
+// transactionsCategoryFK is the name of the foreign key from transactions to categories
+const transactionsCategoryFK = "transactions_category_id_fkey"
+
+func categoryError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return repository.ErrCategoryNotFound
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation && pgErr.ConstraintName == transactionsCategoryFK {
+		return repository.ErrCategoryInUse
+	}
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+		return repository.ErrCategoryNotFound
+	}
+	if errors.As(err, &pgErr) && pgerrcode.IsIntegrityConstraintViolation(pgErr.Code) {
+		return repository.ErrCategoryConflict
+	}
+
+	return err
+}
@@ -0,0 +1,248 @@
+package postgres_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/mustan989/wallet/app/internal/repository/postgres"
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/repository"
+)
+
+var categoryRowsAll = []string{
+	"id", "owner_id", "parent_id", "name", "type", "created_at", "updated_at", "archived_at",
+}
+
+func categoryToRow(data *model.Category) []any {
+	return []any{
+		data.ID, data.OwnerID, data.ParentID, data.Name, data.Type, data.CreatedAt, data.UpdatedAt, data.ArchivedAt,
+	}
+}
+
+func TestCategory_CountAll(t *testing.T) {
+	subtests := [...]struct {
+		name   string
+		filter *model.CategoryFilter
+		query  string
+		args   []any
+	}{
+		{"None", &model.CategoryFilter{}, "SELECT COUNT(.+) FROM categories WHERE owner_id = \\$1$", nil},
+		{"Root", &model.CategoryFilter{ParentID: uint64p(0)}, "WHERE owner_id = \\$1 AND parent_id IS NULL", nil},
+		{"ParentID", &model.CategoryFilter{ParentID: uint64p(1)}, "WHERE owner_id = \\$1 AND parent_id = \\$2", []any{uint64(1)}},
+		{"Type Archived", &model.CategoryFilter{Type: model.Expense, Archived: boolp(false)}, "WHERE owner_id = \\$1 AND type = \\$2 AND archived_at IS NULL", []any{model.Expense}},
+	}
+
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			repo := NewCategory(pool)
+
+			pool.ExpectQuery(subtest.query).
+				WithArgs(append([]any{owner}, subtest.args...)...).
+				WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(uint64(1)))
+
+			count, err := repo.CountAll(userCtx, subtest.filter)
+			require.NoError(t, err)
+			require.Equal(t, uint64(1), count)
+		})
+	}
+}
+
+func TestCategory_FindAll(t *testing.T) {
+	date := time.Date(1999, 2, 23, 4, 36, 0, 0, time.UTC)
+
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	repo := NewCategory(pool)
+
+	expect := []*model.Category{
+		{ID: 1, OwnerID: owner, Name: "Food", Type: model.Expense, CreatedAt: date, UpdatedAt: date},
+		{ID: 2, OwnerID: owner, ParentID: uint64p(1), Name: "Groceries", Type: model.Expense, CreatedAt: date, UpdatedAt: date},
+	}
+
+	rows := pgxmock.NewRows(categoryRowsAll)
+	for _, datum := range expect {
+		rows.AddRow(categoryToRow(datum)...)
+	}
+
+	pool.ExpectQuery("SELECT (.+) FROM categories WHERE owner_id = \\$1 AND type = \\$2 ORDER BY name, id").
+		WithArgs(owner, model.Expense).
+		WillReturnRows(rows)
+
+	data, err := repo.FindAll(userCtx, &model.CategoryFilter{Type: model.Expense})
+	require.NoError(t, err)
+	require.Equal(t, expect, data)
+}
+
+func TestCategory_FindByIDError(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	repo := NewCategory(pool)
+
+	pool.ExpectQuery("SELECT (.+) FROM categories WHERE id = \\$1").
+		WithArgs(uint64(1), owner).
+		WillReturnError(getReturnError(repository.ErrWalletNotFound))
+
+	data, err := repo.FindByID(userCtx, 1)
+	require.Zero(t, data)
+	require.Equal(t, repository.ErrCategoryNotFound, err)
+}
+
+func TestCategory_Create(t *testing.T) {
+	subtests := [...]struct {
+		name   string
+		input  *model.Category
+		retErr error
+		err    error
+	}{
+		{"Success", &model.Category{ParentID: uint64p(1), Name: "Groceries", Type: model.Expense}, nil, nil},
+		{"Conflict", &model.Category{Name: "Food", Type: model.Expense}, &pgconn.PgError{Code: pgerrcode.UniqueViolation}, repository.ErrCategoryConflict},
+		{"Parent not found", &model.Category{ParentID: uint64p(9), Name: "Groceries", Type: model.Expense}, &pgconn.PgError{Code: pgerrcode.ForeignKeyViolation}, repository.ErrCategoryNotFound},
+	}
+
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			repo := NewCategory(pool)
+
+			now := time.Now()
+			data := subtest.input
+			created := *data
+			created.ID, created.OwnerID, created.CreatedAt, created.UpdatedAt = 1, owner, now, now
+
+			query := pool.ExpectQuery("INSERT INTO categories (.+) RETURNING").
+				WithArgs(owner, data.ParentID, data.Name, data.Type, data.ArchivedAt)
+			if subtest.retErr != nil {
+				query.WillReturnError(subtest.retErr)
+			} else {
+				query.WillReturnRows(pgxmock.NewRows(categoryRowsAll).AddRow(categoryToRow(&created)...))
+			}
+
+			err := repo.Create(userCtx, data)
+			require.Equal(t, subtest.err, err)
+			if subtest.err == nil {
+				require.Equal(t, &created, data)
+			}
+		})
+	}
+}
+
+func TestCategory_Archive(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	repo := NewCategory(pool)
+
+	now := time.Now()
+	expect := &model.Category{ID: 1, OwnerID: owner, Name: "Food", Type: model.Expense, CreatedAt: now, UpdatedAt: now, ArchivedAt: &now}
+
+	pool.ExpectQuery("UPDATE categories SET updated_at = default, archived_at = \\$1 WHERE id = \\$2 AND owner_id = \\$3 RETURNING").
+		WithArgs(now, expect.ID, owner).
+		WillReturnRows(pgxmock.NewRows(categoryRowsAll).AddRow(categoryToRow(expect)...))
+
+	data, err := repo.Archive(userCtx, expect.ID, now)
+	require.NoError(t, err)
+	require.Equal(t, expect, data)
+	require.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestCategory_Used(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	repo := NewCategory(pool)
+
+	pool.ExpectQuery("SELECT EXISTS \\(SELECT 1 FROM categories WHERE parent_id = \\$1\\) OR EXISTS \\(SELECT 1 FROM transactions WHERE category_id = \\$2\\)").
+		WithArgs(uint64(1), uint64(1)).
+		WillReturnRows(pgxmock.NewRows([]string{"?column?"}).AddRow(true))
+
+	used, err := repo.Used(userCtx, 1)
+	require.NoError(t, err)
+	require.True(t, used)
+	require.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestCategory_DeleteByID(t *testing.T) {
+	now := time.Now()
+
+	subtests := [...]struct {
+		name       string
+		reassignTo *uint64
+	}{
+		{"Unused", nil},
+		{"Reassign", uint64p(3)},
+	}
+
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			repo := NewCategory(pool)
+
+			expect := &model.Category{ID: 2, OwnerID: owner, ParentID: uint64p(1), Name: "Groceries", Type: model.Expense, CreatedAt: now, UpdatedAt: now}
+
+			pool.ExpectBegin()
+			if subtest.reassignTo != nil {
+				pool.ExpectQuery("SELECT 1 FROM categories WHERE id = \\$1 AND owner_id = \\$2").
+					WithArgs(*subtest.reassignTo, owner).
+					WillReturnRows(pgxmock.NewRows([]string{"?column?"}).AddRow(1))
+				pool.ExpectExec("UPDATE transactions SET category_id = \\$1 WHERE category_id = \\$2").
+					WithArgs(*subtest.reassignTo, expect.ID).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 4))
+			}
+			pool.ExpectExec("UPDATE categories SET parent_id = \\(SELECT parent_id FROM categories WHERE id = \\$1\\)(.+) WHERE parent_id = \\$2 AND owner_id = \\$3").
+				WithArgs(expect.ID, expect.ID, owner).
+				WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+			pool.ExpectQuery("DELETE FROM categories WHERE id = (.+) AND owner_id = (.+) RETURNING").
+				WithArgs(expect.ID, owner).
+				WillReturnRows(pgxmock.NewRows(categoryRowsAll).AddRow(categoryToRow(expect)...))
+			pool.ExpectCommit()
+
+			data, err := repo.DeleteByID(userCtx, expect.ID, subtest.reassignTo)
+			require.NoError(t, err)
+			require.Equal(t, expect, data)
+			require.NoError(t, pool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCategory_DeleteByIDInUse(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	repo := NewCategory(pool)
+
+	pool.ExpectBegin()
+	pool.ExpectExec("UPDATE categories").
+		WithArgs(uint64(2), uint64(2), owner).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	pool.ExpectQuery("DELETE FROM categories").
+		WithArgs(uint64(2), owner).
+		WillReturnError(&pgconn.PgError{Code: pgerrcode.ForeignKeyViolation, ConstraintName: "transactions_category_id_fkey"})
+	pool.ExpectRollback()
+
+	data, err := repo.DeleteByID(userCtx, 2, nil)
+	require.Zero(t, data)
+	require.Equal(t, repository.ErrCategoryInUse, err)
+	require.NoError(t, pool.ExpectationsWereMet())
+}
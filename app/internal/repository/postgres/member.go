@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/repository"
+)
+
+func NewMember(pool Pool) repository.Member { return &member{pool} }
+
+type member struct{ pool Pool }
+
+// foreign keys of the wallet members
+const (
+	membersWalletFK = "wallet_members_wallet_id_fkey"
+	membersUserFK   = "wallet_members_user_id_fkey"
+)
+
+func scanMember(row pgx.Row, data *model.Member) error {
+	return row.Scan(&data.WalletID, &data.UserID, &data.Role, &data.CreatedAt, &data.UpdatedAt)
+}
+
+func (m *member) FindAll(ctx context.Context, walletID uint64) (data []*model.Member, err error) {
+	userID, err := model.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, args := dialect.FindMembers(userID, walletID)
+
+	rows, err := conn(ctx, m.pool).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data = []*model.Member{}
+	for rows.Next() {
+		elem := &model.Member{}
+
+		if err = scanMember(rows, elem); err != nil {
+			return nil, err
+		}
+
+		data = append(data, elem)
+	}
+
+	return
+}
+
+func (m *member) FindRole(ctx context.Context, walletID uint64) (role model.Role, err error) {
+	userID, err := model.UserID(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	sql, args := dialect.FindRole(userID, walletID)
+
+	err = conn(ctx, m.pool).QueryRow(ctx, sql, args...).Scan(&role)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", repository.ErrWalletNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return
+}
+
+func (m *member) Save(ctx context.Context, data *model.Member) error {
+	sql, args := dialect.SaveMember(data)
+
+	return memberError(scanMember(conn(ctx, m.pool).QueryRow(ctx, sql, args...), data))
+}
+
+func (m *member) DeleteByID(ctx context.Context, walletID, userID uint64) (deleted *model.Member, err error) {
+	actingID, err := model.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, args := dialect.DeleteMember(actingID, walletID, userID)
+
+	deleted = &model.Member{}
+	if err = memberError(scanMember(conn(ctx, m.pool).QueryRow(ctx, sql, args...), deleted)); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func memberError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return repository.ErrMemberNotFound
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation && pgErr.ConstraintName == membersWalletFK {
+		return repository.ErrWalletNotFound
+	}
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation && pgErr.ConstraintName == membersUserFK {
+		return repository.ErrUserNotFound
+	}
+
+	return err
+}
@@ -0,0 +1,134 @@
+package postgres_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/mustan989/wallet/app/internal/repository/postgres"
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/repository"
+)
+
+var memberRowsAll = []string{
+	"wallet_id", "user_id", "role", "created_at", "updated_at",
+}
+
+func TestMember_FindAll(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	repo := NewMember(pool)
+
+	now := time.Now()
+	expect := []*model.Member{
+		{WalletID: 1, UserID: 2, Role: model.Viewer, CreatedAt: now, UpdatedAt: now},
+		{WalletID: 1, UserID: 3, Role: model.Editor, CreatedAt: now, UpdatedAt: now},
+	}
+
+	pool.ExpectQuery("SELECT (.+) FROM wallet_members WHERE wallet_id = \\$1 AND wallet_id IN (.+) ORDER BY user_id").
+		WithArgs(uint64(1), owner, owner).
+		WillReturnRows(pgxmock.NewRows(memberRowsAll).
+			AddRow(uint64(1), uint64(2), model.Viewer, now, now).
+			AddRow(uint64(1), uint64(3), model.Editor, now, now))
+
+	data, err := repo.FindAll(userCtx, 1)
+	require.NoError(t, err)
+	require.Equal(t, expect, data)
+}
+
+func TestMember_FindRole(t *testing.T) {
+	subtests := [...]struct {
+		name   string
+		role   model.Role
+		retErr error
+		err    error
+	}{
+		{"Owner", model.Owner, nil, nil},
+		{"Viewer", model.Viewer, nil, nil},
+		{"Not visible", "", pgx.ErrNoRows, repository.ErrWalletNotFound},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			pool, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer pool.Close()
+
+			repo := NewMember(pool)
+
+			query := pool.ExpectQuery("SELECT CASE WHEN w.owner_id = \\$1 THEN \\$2 ELSE m.role END FROM wallets w LEFT JOIN wallet_members m (.+) WHERE w.id = \\$4").
+				WithArgs(owner, model.Owner, owner, uint64(1), owner)
+			if subtest.retErr != nil {
+				query.WillReturnError(subtest.retErr)
+			} else {
+				query.WillReturnRows(pgxmock.NewRows([]string{"role"}).AddRow(subtest.role))
+			}
+
+			role, err := repo.FindRole(userCtx, 1)
+			require.Equal(t, subtest.err, err)
+			require.Equal(t, subtest.role, role)
+		})
+	}
+}
+
+func TestMember_Save(t *testing.T) {
+	subtests := [...]struct {
+		name   string
+		retErr error
+		err    error
+	}{
+		{"Saved", nil, nil},
+		{"User not found", &pgconn.PgError{Code: pgerrcode.ForeignKeyViolation, ConstraintName: "wallet_members_user_id_fkey"}, repository.ErrUserNotFound},
+		{"Wallet not found", &pgconn.PgError{Code: pgerrcode.ForeignKeyViolation, ConstraintName: "wallet_members_wallet_id_fkey"}, repository.ErrWalletNotFound},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			pool, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer pool.Close()
+
+			repo := NewMember(pool)
+
+			now := time.Now()
+			data := &model.Member{WalletID: 1, UserID: 2, Role: model.Editor}
+
+			query := pool.ExpectQuery("INSERT INTO wallet_members (.+) ON CONFLICT (.+) DO UPDATE SET (.+) RETURNING").
+				WithArgs(data.WalletID, data.UserID, data.Role)
+			if subtest.retErr != nil {
+				query.WillReturnError(subtest.retErr)
+			} else {
+				query.WillReturnRows(pgxmock.NewRows(memberRowsAll).AddRow(data.WalletID, data.UserID, data.Role, now, now))
+			}
+
+			err = repo.Save(userCtx, data)
+			require.Equal(t, subtest.err, err)
+			if subtest.err == nil {
+				require.Equal(t, &model.Member{WalletID: 1, UserID: 2, Role: model.Editor, CreatedAt: now, UpdatedAt: now}, data)
+			}
+		})
+	}
+}
+
+func TestMember_DeleteByIDNotFound(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	repo := NewMember(pool)
+
+	pool.ExpectQuery("DELETE FROM wallet_members WHERE wallet_id = (.+) AND user_id = (.+) AND wallet_id IN (.+) RETURNING").
+		WithArgs(uint64(1), uint64(2), owner, owner).
+		WillReturnError(pgx.ErrNoRows)
+
+	deleted, err := repo.DeleteByID(userCtx, 1, 2)
+	require.Zero(t, deleted)
+	require.Equal(t, repository.ErrMemberNotFound, err)
+}
@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/mustan989/wallet/repository"
+)
+
+func NewToken(pool Pool) repository.Token { return &token{pool} }
+
+type token struct{ pool Pool }
+
+func (t *token) Revoke(ctx context.Context, id string, expiresAt time.Time) (revoked bool, err error) {
+	// expired tokens are rejected anyway, so there is no need to keep them
+	sql, args := dialect.DeleteExpiredTokens(time.Now())
+
+	if _, err = conn(ctx, t.pool).Exec(ctx, sql, args...); err != nil {
+		return false, err
+	}
+
+	sql, args = dialect.RevokeToken(id, expiresAt)
+
+	tag, err := conn(ctx, t.pool).Exec(ctx, sql, args...)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+func (t *token) IsRevoked(ctx context.Context, id string) (revoked bool, err error) {
+	sql, args := dialect.TokenRevoked(id)
+
+	var found int
+	err = conn(ctx, t.pool).QueryRow(ctx, sql, args...).Scan(&found)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
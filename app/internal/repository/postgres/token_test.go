@@ -0,0 +1,84 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/mustan989/wallet/app/internal/repository/postgres"
+)
+
+func TestToken_Revoke(t *testing.T) {
+	subtests := [...]struct {
+		name     string
+		inserted int64
+		revoked  bool
+	}{
+		{"Revoked", 1, true},
+		{"Already revoked", 0, false},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			pool, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer pool.Close()
+
+			repo := NewToken(pool)
+
+			expiresAt := time.Now().Add(time.Hour)
+
+			pool.ExpectExec("DELETE FROM revoked_tokens WHERE expires_at < \\$1").
+				WithArgs(pgxmock.AnyArg()).
+				WillReturnResult(pgxmock.NewResult("DELETE", 0))
+			pool.ExpectExec("INSERT INTO revoked_tokens \\(id, expires_at\\) VALUES \\((.+)\\) ON CONFLICT DO NOTHING").
+				WithArgs("id", expiresAt).
+				WillReturnResult(pgxmock.NewResult("INSERT", subtest.inserted))
+
+			revoked, err := repo.Revoke(context.Background(), "id", expiresAt)
+			require.NoError(t, err)
+			require.Equal(t, subtest.revoked, revoked)
+			require.NoError(t, pool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestToken_IsRevoked(t *testing.T) {
+	subtests := [...]struct {
+		name    string
+		rows    *pgxmock.Rows
+		retErr  error
+		revoked bool
+		err     error
+	}{
+		{"Revoked", pgxmock.NewRows([]string{"?column?"}).AddRow(1), nil, true, nil},
+		{"Not revoked", nil, pgx.ErrNoRows, false, nil},
+		{"Error", nil, errors.New("error"), false, errors.New("error")},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			pool, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer pool.Close()
+
+			repo := NewToken(pool)
+
+			query := pool.ExpectQuery("SELECT 1 FROM revoked_tokens WHERE id = \\$1").WithArgs("id")
+			if subtest.retErr != nil {
+				query.WillReturnError(subtest.retErr)
+			} else {
+				query.WillReturnRows(subtest.rows)
+			}
+
+			revoked, err := repo.IsRevoked(context.Background(), "id")
+			require.Equal(t, subtest.err, err)
+			require.Equal(t, subtest.revoked, revoked)
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/repository"
+)
+
+func NewTransaction(pool Pool) repository.Transaction { return &transaction{pool} }
+
+type transaction struct{ pool Pool }
+
+// scanTransaction scans a transaction, binding its amount to the currency of its wallet
+func scanTransaction(row pgx.Row, data *model.Transaction) error {
+	var currency string
+	if err := row.Scan(
+		&data.ID, &data.WalletID, &data.CategoryID, &data.Type, &data.Amount, &data.Description, &data.Date, &data.TransferID, &data.CreatedAt, &data.UpdatedAt, &currency,
+	); err != nil {
+		return err
+	}
+	return model.Bind(currency, &data.Amount)
+}
+
+func (t *transaction) CountAll(ctx context.Context, filter *model.TransactionFilter) (count uint64, err error) {
+	userID, err := model.UserID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	sql, args := dialect.CountTransactions(userID, filter)
+
+	err = conn(ctx, t.pool).QueryRow(ctx, sql, args...).Scan(&count)
+
+	return
+}
+
+func (t *transaction) FindAll(ctx context.Context, filter *model.TransactionFilter) (data []*model.Transaction, err error) {
+	userID, err := model.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, args, err := dialect.FindTransactions(userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn(ctx, t.pool).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data = []*model.Transaction{}
+	for rows.Next() {
+		elem := &model.Transaction{}
+
+		if err = scanTransaction(rows, elem); err != nil {
+			return nil, err
+		}
+
+		data = append(data, elem)
+	}
+
+	return
+}
+
+func (t *transaction) FindByID(ctx context.Context, id uint64) (data *model.Transaction, err error) {
+	return t.findByID(ctx, conn(ctx, t.pool), id, false)
+}
+
+func (t *transaction) findByID(ctx context.Context, q querier, id uint64, lock bool) (data *model.Transaction, err error) {
+	userID, err := model.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, args := dialect.FindTransaction(userID, id, lock)
+
+	data = &model.Transaction{}
+	err = scanTransaction(q.QueryRow(ctx, sql, args...), data)
+	if err != nil && errors.Is(err, pgx.ErrNoRows) {
+		return nil, repository.ErrTransactionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (t *transaction) Create(ctx context.Context, data *model.Transaction) error {
+	return inTx(ctx, t.pool, func(tx pgx.Tx) error {
+		if err := addWalletAmount(ctx, tx, data.WalletID, data.Delta()); err != nil {
+			return err
+		}
+		if err := checkCategory(ctx, tx, data.CategoryID); err != nil {
+			return err
+		}
+
+		sql, args := dialect.CreateTransaction(data)
+
+		return transactionError(scanTransaction(tx.QueryRow(ctx, sql, args...), data))
+	})
+}
+
+func (t *transaction) Update(ctx context.Context, data *model.Transaction) error {
+	return inTx(ctx, t.pool, func(tx pgx.Tx) error {
+		old, err := t.findByID(ctx, tx, data.ID, true)
+		if err != nil {
+			return err
+		}
+		if old.TransferID != nil {
+			return repository.ErrTransactionLinked
+		}
+
+		if err = addWalletAmount(ctx, tx, old.WalletID, old.Delta().Neg()); err != nil {
+			return err
+		}
+		if err = addWalletAmount(ctx, tx, data.WalletID, data.Delta()); err != nil {
+			return err
+		}
+		if err = checkCategory(ctx, tx, data.CategoryID); err != nil {
+			return err
+		}
+
+		sql, args := dialect.UpdateTransaction(data)
+
+		return transactionError(scanTransaction(tx.QueryRow(ctx, sql, args...), data))
+	})
+}
+
+func (t *transaction) DeleteByID(ctx context.Context, id uint64) (deleted *model.Transaction, err error) {
+	err = inTx(ctx, t.pool, func(tx pgx.Tx) error {
+		old, err := t.findByID(ctx, tx, id, true)
+		if err != nil {
+			return err
+		}
+		if old.TransferID != nil {
+			return repository.ErrTransactionLinked
+		}
+
+		sql, args := dialect.DeleteTransaction(id)
+
+		deleted = &model.Transaction{}
+		if err = transactionError(scanTransaction(tx.QueryRow(ctx, sql, args...), deleted)); err != nil {
+			return err
+		}
+
+		return addWalletAmount(ctx, tx, deleted.WalletID, deleted.Delta().Neg())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func transactionError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return repository.ErrTransactionNotFound
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation && pgErr.ConstraintName == transactionsCategoryFK {
+		return repository.ErrCategoryNotFound
+	}
+	if errors.As(err, &pgErr) && pgerrcode.IsIntegrityConstraintViolation(pgErr.Code) {
+		return repository.ErrTransactionConflict
+	}
+
+	return err
+}
@@ -0,0 +1,374 @@
+package postgres_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/mustan989/wallet/app/internal/repository/postgres"
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/repository"
+)
+
+func uint64p(u uint64) *uint64 { return &u }
+
+var transactionRowsAll = []string{
+	"id", "wallet_id", "category_id", "type", "amount", "description", "date", "transfer_id", "created_at", "updated_at", "currency",
+}
+
+func transactionToRow(data *model.Transaction) []any {
+	return []any{
+		data.ID, data.WalletID, data.CategoryID, data.Type, data.Amount, data.Description, data.Date, data.TransferID, data.CreatedAt, data.UpdatedAt, data.Amount.Currency.Code,
+	}
+}
+
+func TestTransaction_CountAll(t *testing.T) {
+	subtests := [...]struct {
+		name   string
+		filter *model.TransactionFilter
+		args   []any
+		expect uint64
+	}{
+		{"None", &model.TransactionFilter{}, nil, 0},
+		{"WalletID", &model.TransactionFilter{WalletID: uint64p(1)}, []any{uint64(1)}, 1},
+		{"Type", &model.TransactionFilter{Type: model.Income}, []any{model.Income}, 1},
+		{"WalletID Type", &model.TransactionFilter{WalletID: uint64p(1), Type: model.Expense}, []any{uint64(1), model.Expense}, 1},
+		{"CategoryID", &model.TransactionFilter{CategoryID: uint64p(3)}, []any{uint64(3)}, 1},
+	}
+
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			repo := NewTransaction(pool)
+
+			pool.ExpectQuery("SELECT COUNT(.+) FROM transactions").
+				WithArgs(append([]any{owner, owner}, subtest.args...)...).
+				WillReturnRows(pgxmock.NewRows([]string{"count"}).
+					AddRow(subtest.expect))
+
+			count, err := repo.CountAll(userCtx, subtest.filter)
+			require.NoError(t, err)
+			require.Equal(t, subtest.expect, count)
+		})
+	}
+}
+
+func TestTransaction_FindAll(t *testing.T) {
+	date := time.Date(1999, 2, 23, 4, 36, 0, 0, time.UTC)
+
+	subtests := [...]struct {
+		name   string
+		filter *model.TransactionFilter
+		args   []any
+		expect []*model.Transaction
+	}{
+		{"None", &model.TransactionFilter{}, nil, []*model.Transaction{}},
+		{"Some WalletID", &model.TransactionFilter{WalletID: uint64p(1)}, []any{uint64(1)}, []*model.Transaction{
+			{ID: 2, WalletID: 1, Type: model.Expense, Amount: money(100, kzt), Date: date, CreatedAt: date, UpdatedAt: date},
+			{ID: 1, WalletID: 1, Type: model.Income, Amount: money(9999, kzt), Description: stringp("salary"), Date: date, CreatedAt: date, UpdatedAt: date},
+		}},
+	}
+
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			repo := NewTransaction(pool)
+
+			rows := pgxmock.NewRows(transactionRowsAll)
+			for _, datum := range subtest.expect {
+				rows.AddRow(transactionToRow(datum)...)
+			}
+
+			pool.ExpectQuery("SELECT (.+) FROM transactions(.*) ORDER BY date DESC, id DESC").
+				WithArgs(append([]any{owner, owner}, subtest.args...)...).
+				WillReturnRows(rows)
+
+			data, err := repo.FindAll(userCtx, subtest.filter)
+			require.NoError(t, err)
+			require.Equal(t, subtest.expect, data)
+		})
+	}
+}
+
+func TestTransaction_FindAllCursor(t *testing.T) {
+	date := time.Date(1999, 2, 23, 4, 36, 0, 0, time.UTC)
+
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	repo := NewTransaction(pool)
+
+	filter := &model.TransactionFilter{Filter: model.Filter{Limit: 2}}
+	filter.Cursor = filter.Next(&model.Transaction{ID: 3, Date: date})
+
+	pool.ExpectQuery("AND \\(date, id\\) < \\(\\$3, \\$4\\) ORDER BY date DESC, id DESC LIMIT 2$").
+		WithArgs(owner, owner, date, uint64(3)).
+		WillReturnRows(pgxmock.NewRows(transactionRowsAll))
+
+	data, err := repo.FindAll(userCtx, filter)
+	require.NoError(t, err)
+	require.Empty(t, data)
+}
+
+func TestTransaction_FindByIDError(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	repo := NewTransaction(pool)
+
+	pool.ExpectQuery("SELECT (.+) FROM transactions (.+) LIMIT 1").
+		WithArgs(uint64(1), owner, owner).
+		WillReturnError(getReturnError(repository.ErrWalletNotFound))
+
+	data, err := repo.FindByID(userCtx, 1)
+	require.Zero(t, data)
+	require.Equal(t, repository.ErrTransactionNotFound, err)
+}
+
+func TestTransaction_Create(t *testing.T) {
+	subtests := [...]struct {
+		name  string
+		input *model.Transaction
+		delta model.Money
+	}{
+		{"Income", &model.Transaction{WalletID: 1, Type: model.Income, Amount: money(9999, kzt)}, money(9999, kzt)},
+		{"Expense", &model.Transaction{WalletID: 1, Type: model.Expense, Amount: money(9999, kzt)}, money(-9999, kzt)},
+	}
+
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			repo := NewTransaction(pool)
+
+			data := subtest.input
+			now := time.Now()
+
+			pool.ExpectBegin()
+			pool.ExpectExec("UPDATE wallets SET amount = amount \\+ \\$1").
+				WithArgs(subtest.delta, data.WalletID, owner, owner).
+				WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+			pool.ExpectQuery("INSERT INTO transactions (.+) RETURNING").
+				WithArgs(data.WalletID, data.CategoryID, data.Type, data.Amount, data.Description, data.Date).
+				WillReturnRows(pgxmock.NewRows(transactionRowsAll).
+					AddRow(uint64(1), data.WalletID, data.CategoryID, data.Type, data.Amount, data.Description, now, nil, now, now, "KZT"))
+			pool.ExpectCommit()
+
+			err := repo.Create(userCtx, data)
+			require.NoError(t, err)
+			require.Equal(t, uint64(1), data.ID)
+			require.NoError(t, pool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTransaction_CreateError(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	repo := NewTransaction(pool)
+	data := &model.Transaction{WalletID: 1, Type: model.Income, Amount: money(9999, kzt)}
+
+	t.Run("Wallet not found", func(t *testing.T) {
+		pool.ExpectBegin()
+		pool.ExpectExec("UPDATE wallets").
+			WithArgs(data.Amount, data.WalletID, owner, owner).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+		pool.ExpectRollback()
+
+		err := repo.Create(userCtx, data)
+		require.Equal(t, repository.ErrWalletNotFound, err)
+		require.NoError(t, pool.ExpectationsWereMet())
+	})
+
+	t.Run("Wallet amount overflow", func(t *testing.T) {
+		pool.ExpectBegin()
+		pool.ExpectExec("UPDATE wallets").
+			WithArgs(data.Amount, data.WalletID, owner, owner).
+			WillReturnError(&pgconn.PgError{Code: pgerrcode.CheckViolation, ConstraintName: "wallets_amount_range"})
+		pool.ExpectRollback()
+
+		err := repo.Create(userCtx, data)
+		require.ErrorIs(t, err, model.ErrDecimalOverflow)
+		require.NoError(t, pool.ExpectationsWereMet())
+	})
+
+	t.Run("Insert error", func(t *testing.T) {
+		pool.ExpectBegin()
+		pool.ExpectExec("UPDATE wallets").
+			WithArgs(data.Amount, data.WalletID, owner, owner).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		pool.ExpectQuery("INSERT INTO transactions").
+			WithArgs(data.WalletID, data.CategoryID, data.Type, data.Amount, data.Description, data.Date).
+			WillReturnError(connErr)
+		pool.ExpectRollback()
+
+		err := repo.Create(userCtx, data)
+		require.Equal(t, connErr, err)
+		require.NoError(t, pool.ExpectationsWereMet())
+	})
+
+	t.Run("Category of other user", func(t *testing.T) {
+		data := &model.Transaction{WalletID: 1, CategoryID: uint64p(3), Type: model.Income, Amount: money(9999, kzt)}
+
+		pool.ExpectBegin()
+		pool.ExpectExec("UPDATE wallets").
+			WithArgs(data.Amount, data.WalletID, owner, owner).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		pool.ExpectQuery("SELECT 1 FROM categories").
+			WithArgs(*data.CategoryID, owner).
+			WillReturnError(getReturnError(repository.ErrWalletNotFound))
+		pool.ExpectRollback()
+
+		err := repo.Create(userCtx, data)
+		require.Equal(t, repository.ErrCategoryNotFound, err)
+		require.NoError(t, pool.ExpectationsWereMet())
+	})
+}
+
+func TestTransaction_Update(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	repo := NewTransaction(pool)
+
+	now := time.Now()
+	old := &model.Transaction{ID: 1, WalletID: 1, Type: model.Income, Amount: money(9999, kzt), Date: now, CreatedAt: now, UpdatedAt: now}
+	data := &model.Transaction{ID: 1, WalletID: 2, Type: model.Expense, Amount: money(100, kzt), Date: now}
+
+	pool.ExpectBegin()
+	pool.ExpectQuery("SELECT (.+) FROM transactions (.+) FOR UPDATE").
+		WithArgs(data.ID, owner, owner).
+		WillReturnRows(pgxmock.NewRows(transactionRowsAll).AddRow(transactionToRow(old)...))
+	pool.ExpectExec("UPDATE wallets").
+		WithArgs(money(-9999, kzt), old.WalletID, owner, owner).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	pool.ExpectExec("UPDATE wallets").
+		WithArgs(money(-100, kzt), data.WalletID, owner, owner).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	pool.ExpectQuery("UPDATE transactions").
+		WithArgs(data.WalletID, data.CategoryID, data.Type, data.Amount, data.Description, data.Date, data.ID).
+		WillReturnRows(pgxmock.NewRows(transactionRowsAll).
+			AddRow(data.ID, data.WalletID, data.CategoryID, data.Type, data.Amount, data.Description, data.Date, nil, now, now, "KZT"))
+	pool.ExpectCommit()
+
+	require.NoError(t, repo.Update(userCtx, data))
+	require.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestTransaction_UpdateNotFound(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	repo := NewTransaction(pool)
+
+	pool.ExpectBegin()
+	pool.ExpectQuery("SELECT (.+) FROM transactions (.+) FOR UPDATE").
+		WithArgs(uint64(1), owner, owner).
+		WillReturnError(getReturnError(repository.ErrWalletNotFound))
+	pool.ExpectRollback()
+
+	err = repo.Update(userCtx, &model.Transaction{ID: 1, WalletID: 1, Type: model.Income, Amount: money(1, kzt)})
+	require.Equal(t, repository.ErrTransactionNotFound, err)
+	require.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestTransaction_DeleteByID(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	repo := NewTransaction(pool)
+
+	now := time.Now()
+	expect := &model.Transaction{ID: 1, WalletID: 1, Type: model.Expense, Amount: money(9999, kzt), Date: now, CreatedAt: now, UpdatedAt: now}
+
+	pool.ExpectBegin()
+	pool.ExpectQuery("SELECT (.+) FROM transactions (.+) FOR UPDATE").
+		WithArgs(expect.ID, owner, owner).
+		WillReturnRows(pgxmock.NewRows(transactionRowsAll).AddRow(transactionToRow(expect)...))
+	pool.ExpectQuery("DELETE FROM transactions").
+		WithArgs(expect.ID).
+		WillReturnRows(pgxmock.NewRows(transactionRowsAll).AddRow(transactionToRow(expect)...))
+	pool.ExpectExec("UPDATE wallets").
+		WithArgs(money(9999, kzt), expect.WalletID, owner, owner).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	pool.ExpectCommit()
+
+	data, err := repo.DeleteByID(userCtx, expect.ID)
+	require.NoError(t, err)
+	require.Equal(t, expect, data)
+	require.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestTransaction_DeleteByIDError(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	repo := NewTransaction(pool)
+
+	pool.ExpectBegin()
+	pool.ExpectQuery("SELECT (.+) FROM transactions (.+) FOR UPDATE").
+		WithArgs(uint64(1), owner, owner).
+		WillReturnError(getReturnError(repository.ErrWalletNotFound))
+	pool.ExpectRollback()
+
+	data, err := repo.DeleteByID(userCtx, 1)
+	require.Zero(t, data)
+	require.Equal(t, repository.ErrTransactionNotFound, err)
+	require.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestTransaction_Linked(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	repo := NewTransaction(pool)
+
+	now := time.Now()
+	linked := &model.Transaction{ID: 1, WalletID: 1, Type: model.Expense, Amount: money(100, kzt), Date: now, TransferID: uint64p(1), CreatedAt: now, UpdatedAt: now}
+
+	t.Run("Update", func(t *testing.T) {
+		pool.ExpectBegin()
+		pool.ExpectQuery("SELECT (.+) FROM transactions (.+) FOR UPDATE").
+			WithArgs(linked.ID, owner, owner).
+			WillReturnRows(pgxmock.NewRows(transactionRowsAll).AddRow(transactionToRow(linked)...))
+		pool.ExpectRollback()
+
+		err := repo.Update(userCtx, &model.Transaction{ID: 1, WalletID: 1, Type: model.Expense, Amount: money(1, kzt)})
+		require.Equal(t, repository.ErrTransactionLinked, err)
+		require.NoError(t, pool.ExpectationsWereMet())
+	})
+
+	t.Run("DeleteByID", func(t *testing.T) {
+		pool.ExpectBegin()
+		pool.ExpectQuery("SELECT (.+) FROM transactions (.+) FOR UPDATE").
+			WithArgs(linked.ID, owner, owner).
+			WillReturnRows(pgxmock.NewRows(transactionRowsAll).AddRow(transactionToRow(linked)...))
+		pool.ExpectRollback()
+
+		data, err := repo.DeleteByID(userCtx, linked.ID)
+		require.Zero(t, data)
+		require.Equal(t, repository.ErrTransactionLinked, err)
+		require.NoError(t, pool.ExpectationsWereMet())
+	})
+}
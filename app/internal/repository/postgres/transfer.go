@@ -0,0 +1,249 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/repository"
+)
+
+func NewTransfer(pool Pool) repository.Transfer { return &transfer{pool} }
+
+type transfer struct{ pool Pool }
+
+// scanTransfer scans a transfer joined with its commission transaction
+func scanTransfer(row pgx.Row, data *model.Transfer) error {
+	var from, to string
+	if err := row.Scan(
+		&data.ID, &data.FromWalletID, &data.ToWalletID, &data.Amount, &data.ReceivedAmount, &data.Rate, &data.Commission, &data.CommissionID, &data.Description, &data.Date, &data.CreatedAt, &data.UpdatedAt, &from, &to,
+	); err != nil {
+		return err
+	}
+	return bindTransfer(data, from, to)
+}
+
+// scanTransferReturning scans a transfer returned without its commission
+func scanTransferReturning(row pgx.Row, data *model.Transfer) error {
+	var from, to string
+	if err := row.Scan(
+		&data.ID, &data.FromWalletID, &data.ToWalletID, &data.Amount, &data.ReceivedAmount, &data.Rate, &data.Description, &data.Date, &data.CreatedAt, &data.UpdatedAt, &from, &to,
+	); err != nil {
+		return err
+	}
+	return bindTransfer(data, from, to)
+}
+
+// bindTransfer binds the amount and the commission to the currency of the source wallet
+// and the received amount to the currency of the destination
+func bindTransfer(data *model.Transfer, from, to string) error {
+	if err := model.Bind(from, &data.Amount, &data.Commission); err != nil {
+		return err
+	}
+	return model.Bind(to, &data.ReceivedAmount)
+}
+
+func (t *transfer) CountAll(ctx context.Context, filter *model.TransferFilter) (count uint64, err error) {
+	userID, err := model.UserID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	sql, args := dialect.CountTransfers(userID, filter)
+
+	err = conn(ctx, t.pool).QueryRow(ctx, sql, args...).Scan(&count)
+
+	return
+}
+
+func (t *transfer) FindAll(ctx context.Context, filter *model.TransferFilter) (data []*model.Transfer, err error) {
+	userID, err := model.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, args, err := dialect.FindTransfers(userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn(ctx, t.pool).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data = []*model.Transfer{}
+	for rows.Next() {
+		elem := &model.Transfer{}
+
+		if err = scanTransfer(rows, elem); err != nil {
+			return nil, err
+		}
+
+		data = append(data, elem)
+	}
+
+	return
+}
+
+func (t *transfer) FindByID(ctx context.Context, id uint64) (data *model.Transfer, err error) {
+	return t.findByID(ctx, conn(ctx, t.pool), id, false)
+}
+
+func (t *transfer) findByID(ctx context.Context, q querier, id uint64, lock bool) (data *model.Transfer, err error) {
+	userID, err := model.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, args := dialect.FindTransfer(userID, id, lock)
+
+	data = &model.Transfer{}
+	err = scanTransfer(q.QueryRow(ctx, sql, args...), data)
+	if err != nil && errors.Is(err, pgx.ErrNoRows) {
+		return nil, repository.ErrTransferNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (t *transfer) Create(ctx context.Context, data *model.Transfer) error {
+	return inTx(ctx, t.pool, func(tx pgx.Tx) error {
+		if err := t.move(ctx, tx, data, false); err != nil {
+			return err
+		}
+
+		sql, args := dialect.CreateTransfer(data)
+
+		if err := transferError(scanTransferReturning(tx.QueryRow(ctx, sql, args...), data)); err != nil {
+			return err
+		}
+
+		return t.createCommission(ctx, tx, data)
+	})
+}
+
+func (t *transfer) Update(ctx context.Context, data *model.Transfer) error {
+	return inTx(ctx, t.pool, func(tx pgx.Tx) error {
+		old, err := t.findByID(ctx, tx, data.ID, true)
+		if err != nil {
+			return err
+		}
+
+		if err = t.move(ctx, tx, old, true); err != nil {
+			return err
+		}
+		if err = t.deleteCommission(ctx, tx, old); err != nil {
+			return err
+		}
+		if err = t.move(ctx, tx, data, false); err != nil {
+			return err
+		}
+
+		sql, args := dialect.UpdateTransfer(data)
+
+		if err = transferError(scanTransferReturning(tx.QueryRow(ctx, sql, args...), data)); err != nil {
+			return err
+		}
+
+		return t.createCommission(ctx, tx, data)
+	})
+}
+
+func (t *transfer) DeleteByID(ctx context.Context, id uint64) (deleted *model.Transfer, err error) {
+	err = inTx(ctx, t.pool, func(tx pgx.Tx) error {
+		deleted, err = t.findByID(ctx, tx, id, true)
+		if err != nil {
+			return err
+		}
+
+		if err = t.move(ctx, tx, deleted, true); err != nil {
+			return err
+		}
+		if err = t.deleteCommission(ctx, tx, deleted); err != nil {
+			return err
+		}
+
+		sql, args := dialect.DeleteTransfer(id)
+
+		_, err = tx.Exec(ctx, sql, args...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// move applies the balance changes of the transfer to its wallets, or reverts them
+func (t *transfer) move(ctx context.Context, q querier, data *model.Transfer, revert bool) error {
+	spent, err := data.Amount.Add(data.Commission)
+	if err != nil {
+		return err
+	}
+	debit, credit := spent.Neg(), data.ReceivedAmount
+	if revert {
+		debit, credit = spent, credit.Neg()
+	}
+
+	if err = addWalletAmount(ctx, q, data.FromWalletID, debit); err != nil {
+		return err
+	}
+	return addWalletAmount(ctx, q, data.ToWalletID, credit)
+}
+
+// createCommission records the commission of the transfer as an expense of the source wallet.
+// Wallet amounts are expected to be already changed by move
+func (t *transfer) createCommission(ctx context.Context, q querier, data *model.Transfer) error {
+	data.CommissionID = nil
+	if data.Commission.Sign() == 0 {
+		return nil
+	}
+
+	sql, args := dialect.CreateCommission(data)
+
+	var id uint64
+	if err := q.QueryRow(ctx, sql, args...).Scan(&id); err != nil {
+		return transferError(err)
+	}
+	data.CommissionID = &id
+
+	return nil
+}
+
+func (t *transfer) deleteCommission(ctx context.Context, q querier, data *model.Transfer) error {
+	if data.CommissionID == nil {
+		return nil
+	}
+
+	sql, args := dialect.DeleteCommission(*data.CommissionID)
+
+	_, err := q.Exec(ctx, sql, args...)
+	return err
+}
+
+func transferError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return repository.ErrTransferNotFound
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgerrcode.IsIntegrityConstraintViolation(pgErr.Code) {
+		return repository.ErrTransferConflict
+	}
+
+	return err
+}
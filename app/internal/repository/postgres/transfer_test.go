@@ -0,0 +1,230 @@
+package postgres_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/mustan989/wallet/app/internal/repository/postgres"
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/repository"
+)
+
+var transferRowsAll = []string{
+	"id", "from_wallet_id", "to_wallet_id", "amount", "received_amount", "rate", "commission", "commission_id", "description", "date", "created_at", "updated_at", "from_currency", "to_currency",
+}
+
+var transferRowsReturning = []string{
+	"id", "from_wallet_id", "to_wallet_id", "amount", "received_amount", "rate", "description", "date", "created_at", "updated_at", "from_currency", "to_currency",
+}
+
+func transferToRow(data *model.Transfer) []any {
+	return []any{
+		data.ID, data.FromWalletID, data.ToWalletID, data.Amount, data.ReceivedAmount, data.Rate, data.Commission, data.CommissionID, data.Description, data.Date, data.CreatedAt, data.UpdatedAt, data.Amount.Currency.Code, data.ReceivedAmount.Currency.Code,
+	}
+}
+
+func transferToReturningRow(data *model.Transfer) []any {
+	return []any{
+		data.ID, data.FromWalletID, data.ToWalletID, data.Amount, data.ReceivedAmount, data.Rate, data.Description, data.Date, data.CreatedAt, data.UpdatedAt, data.Amount.Currency.Code, data.ReceivedAmount.Currency.Code,
+	}
+}
+
+func expectWalletAmount(pool pgxmock.PgxPoolIface, id uint64, delta model.Money) {
+	pool.ExpectExec("UPDATE wallets SET amount = amount \\+ \\$1").
+		WithArgs(delta, id, owner, owner).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+}
+
+func TestTransfer_FindAll(t *testing.T) {
+	date := time.Date(1999, 2, 23, 4, 36, 0, 0, time.UTC)
+
+	subtests := [...]struct {
+		name   string
+		filter *model.TransferFilter
+		args   []any
+		expect []*model.Transfer
+	}{
+		{"None", &model.TransferFilter{}, nil, []*model.Transfer{}},
+		{"Some WalletID", &model.TransferFilter{WalletID: uint64p(1)}, []any{uint64(1), uint64(1)}, []*model.Transfer{
+			{ID: 2, FromWalletID: 2, ToWalletID: 1, Amount: money(100, kzt), ReceivedAmount: money(100, kzt), Rate: model.OneRate, Commission: money(0, kzt), Date: date, CreatedAt: date, UpdatedAt: date},
+			{ID: 1, FromWalletID: 1, ToWalletID: 2, Amount: money(9999, kzt), ReceivedAmount: money(9999, kzt), Rate: model.OneRate, Commission: money(100, kzt), CommissionID: uint64p(5), Date: date, CreatedAt: date, UpdatedAt: date},
+		}},
+	}
+
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			repo := NewTransfer(pool)
+
+			rows := pgxmock.NewRows(transferRowsAll)
+			for _, datum := range subtest.expect {
+				rows.AddRow(transferToRow(datum)...)
+			}
+
+			pool.ExpectQuery("SELECT (.+) FROM transfers t LEFT JOIN transactions c ON c.transfer_id = t.id(.*) ORDER BY t.date DESC, t.id DESC").
+				WithArgs(append([]any{owner, owner, owner, owner}, subtest.args...)...).
+				WillReturnRows(rows)
+
+			data, err := repo.FindAll(userCtx, subtest.filter)
+			require.NoError(t, err)
+			require.Equal(t, subtest.expect, data)
+		})
+	}
+}
+
+func TestTransfer_Create(t *testing.T) {
+	now := time.Now()
+
+	subtests := [...]struct {
+		name         string
+		input        *model.Transfer
+		commissionID *uint64
+	}{
+		{"Without commission", &model.Transfer{FromWalletID: 1, ToWalletID: 2, Amount: money(9999, kzt), ReceivedAmount: money(9999, kzt), Rate: model.OneRate, Commission: money(0, kzt), Date: now}, nil},
+		{"With commission", &model.Transfer{FromWalletID: 1, ToWalletID: 2, Amount: money(9999, kzt), ReceivedAmount: money(9999, kzt), Rate: model.OneRate, Commission: money(100, kzt), Date: now}, uint64p(5)},
+	}
+
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			repo := NewTransfer(pool)
+
+			data := subtest.input
+			created := *data
+			created.ID, created.CreatedAt, created.UpdatedAt = 1, now, now
+
+			spent, err := data.Amount.Add(data.Commission)
+			require.NoError(t, err)
+
+			pool.ExpectBegin()
+			expectWalletAmount(pool, data.FromWalletID, spent.Neg())
+			expectWalletAmount(pool, data.ToWalletID, data.ReceivedAmount)
+			pool.ExpectQuery("INSERT INTO transfers (.+) RETURNING").
+				WithArgs(data.FromWalletID, data.ToWalletID, data.Amount, data.ReceivedAmount, data.Rate, data.Description, data.Date).
+				WillReturnRows(pgxmock.NewRows(transferRowsReturning).AddRow(transferToReturningRow(&created)...))
+			if subtest.commissionID != nil {
+				pool.ExpectQuery("INSERT INTO transactions (.+) RETURNING").
+					WithArgs(data.FromWalletID, model.Expense, data.Commission, data.Description, data.Date, uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(*subtest.commissionID))
+			}
+			pool.ExpectCommit()
+
+			require.NoError(t, repo.Create(userCtx, data))
+			require.Equal(t, uint64(1), data.ID)
+			require.Equal(t, subtest.commissionID, data.CommissionID)
+			require.NoError(t, pool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTransfer_CreateError(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	repo := NewTransfer(pool)
+	data := &model.Transfer{FromWalletID: 1, ToWalletID: 2, Amount: money(9999, kzt), ReceivedAmount: money(9999, kzt), Rate: model.OneRate, Commission: money(100, kzt)}
+
+	pool.ExpectBegin()
+	expectWalletAmount(pool, data.FromWalletID, money(-10099, kzt))
+	pool.ExpectExec("UPDATE wallets").
+		WithArgs(data.ReceivedAmount, data.ToWalletID, owner, owner).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	pool.ExpectRollback()
+
+	err = repo.Create(userCtx, data)
+	require.Equal(t, repository.ErrWalletNotFound, err)
+	require.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestTransfer_Update(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	repo := NewTransfer(pool)
+
+	now := time.Now()
+	old := &model.Transfer{ID: 1, FromWalletID: 1, ToWalletID: 2, Amount: money(9999, kzt), ReceivedAmount: money(9999, kzt), Rate: model.OneRate, Commission: money(100, kzt), CommissionID: uint64p(5), Date: now, CreatedAt: now, UpdatedAt: now}
+	data := &model.Transfer{ID: 1, FromWalletID: 2, ToWalletID: 3, Amount: money(500, usd), ReceivedAmount: money(225000, kzt), Rate: 45000000000, Commission: money(0, usd), Date: now}
+	updated := *data
+	updated.CreatedAt, updated.UpdatedAt = now, now
+
+	pool.ExpectBegin()
+	pool.ExpectQuery("SELECT (.+) FROM transfers t (.+) FOR UPDATE OF t").
+		WithArgs(data.ID, owner, owner, owner, owner).
+		WillReturnRows(pgxmock.NewRows(transferRowsAll).AddRow(transferToRow(old)...))
+	expectWalletAmount(pool, old.FromWalletID, money(10099, kzt))
+	expectWalletAmount(pool, old.ToWalletID, old.ReceivedAmount.Neg())
+	pool.ExpectExec("DELETE FROM transactions").
+		WithArgs(*old.CommissionID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	expectWalletAmount(pool, data.FromWalletID, data.Amount.Neg())
+	expectWalletAmount(pool, data.ToWalletID, data.ReceivedAmount)
+	pool.ExpectQuery("UPDATE transfers").
+		WithArgs(data.FromWalletID, data.ToWalletID, data.Amount, data.ReceivedAmount, data.Rate, data.Description, data.Date, data.ID).
+		WillReturnRows(pgxmock.NewRows(transferRowsReturning).AddRow(transferToReturningRow(&updated)...))
+	pool.ExpectCommit()
+
+	require.NoError(t, repo.Update(userCtx, data))
+	require.Nil(t, data.CommissionID)
+	require.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestTransfer_DeleteByID(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	repo := NewTransfer(pool)
+
+	now := time.Now()
+	expect := &model.Transfer{ID: 1, FromWalletID: 1, ToWalletID: 2, Amount: money(9999, kzt), ReceivedAmount: money(9999, kzt), Rate: model.OneRate, Commission: money(100, kzt), CommissionID: uint64p(5), Date: now, CreatedAt: now, UpdatedAt: now}
+
+	pool.ExpectBegin()
+	pool.ExpectQuery("SELECT (.+) FROM transfers t (.+) FOR UPDATE OF t").
+		WithArgs(expect.ID, owner, owner, owner, owner).
+		WillReturnRows(pgxmock.NewRows(transferRowsAll).AddRow(transferToRow(expect)...))
+	expectWalletAmount(pool, expect.FromWalletID, money(10099, kzt))
+	expectWalletAmount(pool, expect.ToWalletID, expect.ReceivedAmount.Neg())
+	pool.ExpectExec("DELETE FROM transactions").
+		WithArgs(*expect.CommissionID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	pool.ExpectExec("DELETE FROM transfers").
+		WithArgs(expect.ID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	pool.ExpectCommit()
+
+	data, err := repo.DeleteByID(userCtx, expect.ID)
+	require.NoError(t, err)
+	require.Equal(t, expect, data)
+	require.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestTransfer_DeleteByIDError(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	repo := NewTransfer(pool)
+
+	pool.ExpectBegin()
+	pool.ExpectQuery("SELECT (.+) FROM transfers t (.+) FOR UPDATE OF t").
+		WithArgs(uint64(1), owner, owner, owner, owner).
+		WillReturnError(getReturnError(repository.ErrWalletNotFound))
+	pool.ExpectRollback()
+
+	data, err := repo.DeleteByID(userCtx, 1)
+	require.Zero(t, data)
+	require.Equal(t, repository.ErrTransferNotFound, err)
+	require.NoError(t, pool.ExpectationsWereMet())
+}
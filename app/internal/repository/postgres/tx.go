@@ -0,0 +1,142 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/mustan989/wallet/app/internal/repository/sqlquery"
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/repository"
+)
+
+// dialect builds the queries for PostgreSQL, whose driver takes the model values as they are
+var dialect = sqlquery.Dialect{Flavor: sqlbuilder.PostgreSQL, Now: "default"}
+
+// querier is implemented by both Pool and pgx.Tx
+type querier interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+func NewTxManager(pool Pool) repository.TxManager { return &txManager{pool} }
+
+type txManager struct{ pool Pool }
+
+func (m *txManager) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return inTx(ctx, m.pool, func(tx pgx.Tx) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+type txKey struct{}
+
+// conn returns the transaction of the context, the pool when there is none
+func conn(ctx context.Context, pool Pool) querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return pool
+}
+
+// inTx runs fn inside a database transaction, committing on success and rolling back on error or panic.
+// Within the transaction of the context it runs in a savepoint, so its rollback leaves the outer transaction going
+func inTx(ctx context.Context, pool Pool, fn func(tx pgx.Tx) error) (err error) {
+	var tx pgx.Tx
+	if outer, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		tx, err = outer.Begin(ctx)
+	} else {
+		tx, err = pool.BeginTx(ctx, pgx.TxOptions{})
+	}
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(ctx)
+			panic(p)
+		}
+	}()
+
+	if err = fn(tx); err != nil {
+		if rbErr := tx.Rollback(ctx); rbErr != nil {
+			return fmt.Errorf("%w (rollback: %s)", err, rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// walletsAmountRange is the name of the check keeping wallet amounts within ±model.MaxStored
+const walletsAmountRange = "wallets_amount_range"
+
+// addWalletAmount adds delta to the amount of the wallet accessible to the acting user
+func addWalletAmount(ctx context.Context, q querier, id uint64, delta model.Money) error {
+	userID, err := model.UserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	sql, args := dialect.AddWalletAmount(userID, id, delta)
+
+	return execWalletAmount(ctx, q, sql, args, id)
+}
+
+// revertTransfers takes the transfers between the wallets and the other ones off the amounts of the other wallets,
+// as the transfers are deleted along with the wallets. It acts for no user, so purge can run it in the background
+func revertTransfers(ctx context.Context, q querier, ids []uint64) error {
+	sql, args := dialect.WalletsTransfers(ids)
+
+	rows, err := q.Query(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	reverts := sqlquery.NewReverts(ids)
+	for rows.Next() {
+		var fromID, toID uint64
+		var amount, received, commission model.Money
+		if err = rows.Scan(&fromID, &toID, &amount, &received, &commission); err != nil {
+			return err
+		}
+		reverts.Add(fromID, toID, amount, received, commission)
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	for _, id := range reverts.Wallets() {
+		sql, args := dialect.RevertWalletAmount(id, reverts.Delta(id))
+
+		if err = execWalletAmount(ctx, q, sql, args, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func execWalletAmount(ctx context.Context, q querier, sql string, args []any, id uint64) error {
+	tag, err := q.Exec(ctx, sql, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.CheckViolation && pgErr.ConstraintName == walletsAmountRange {
+			return fmt.Errorf("%w: amount of wallet %d", model.ErrDecimalOverflow, id)
+		}
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return repository.ErrWalletNotFound
+	}
+
+	return nil
+}
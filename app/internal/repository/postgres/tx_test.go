@@ -0,0 +1,174 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/mustan989/wallet/app/internal/repository/postgres"
+	"github.com/mustan989/wallet/model"
+)
+
+func TestTxManager_InTx(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	wallets := NewWallet(pool)
+	transactions := NewTransaction(pool)
+
+	wallet := &model.Wallet{ID: 1, OwnerID: owner, Name: "name", Currency: "KZT", Amount: money(0, kzt), Version: 1}
+	data := &model.Transaction{WalletID: 1, Type: model.Income, Amount: money(100, kzt)}
+	now := time.Now()
+
+	pool.ExpectBegin()
+	pool.ExpectQuery("SELECT (.+) FROM wallets").
+		WithArgs(uint64(1), owner, owner).
+		WillReturnRows(dataToReturnRows(wallet))
+	// the transaction repository runs in a savepoint of the context transaction
+	pool.ExpectBegin()
+	pool.ExpectExec("UPDATE wallets SET amount = amount \\+ \\$1").
+		WithArgs(money(100, kzt), uint64(1), owner, owner).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	pool.ExpectQuery("INSERT INTO transactions (.+) RETURNING").
+		WithArgs(data.WalletID, data.CategoryID, data.Type, data.Amount, data.Description, data.Date).
+		WillReturnRows(pgxmock.NewRows(transactionRowsAll).
+			AddRow(uint64(1), data.WalletID, data.CategoryID, data.Type, data.Amount, data.Description, now, nil, now, now, "KZT"))
+	pool.ExpectCommit()
+	pool.ExpectCommit()
+
+	err = NewTxManager(pool).InTx(userCtx, func(ctx context.Context) error {
+		found, err := wallets.FindByID(ctx, 1)
+		if err != nil {
+			return err
+		}
+		require.Equal(t, wallet, found)
+		return transactions.Create(ctx, data)
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), data.ID)
+	require.NoError(t, pool.ExpectationsWereMet())
+}
+
+// txOnly fails the queries run on the pool rather than on the transaction begun from it
+type txOnly struct {
+	pgxmock.PgxPoolIface
+	t *testing.T
+}
+
+func (p txOnly) Exec(context.Context, string, ...any) (pgconn.CommandTag, error) {
+	p.t.Fatal("exec outside the transaction")
+	return pgconn.CommandTag{}, nil
+}
+
+func (p txOnly) Query(context.Context, string, ...any) (pgx.Rows, error) {
+	p.t.Fatal("query outside the transaction")
+	return nil, nil
+}
+
+func (p txOnly) QueryRow(context.Context, string, ...any) pgx.Row {
+	p.t.Fatal("query outside the transaction")
+	return nil
+}
+
+func TestTxManager_InTxFindByID(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	pool := txOnly{mock, t}
+	transactions, transfers := NewTransaction(pool), NewTransfer(pool)
+
+	now := time.Now()
+	transaction := &model.Transaction{ID: 1, WalletID: 1, Type: model.Expense, Amount: money(9999, kzt), Date: now, CreatedAt: now, UpdatedAt: now}
+	transfer := &model.Transfer{ID: 1, FromWalletID: 1, ToWalletID: 2, Amount: money(9999, kzt), ReceivedAmount: money(9999, kzt), Rate: model.OneRate, Commission: money(0, kzt), Date: now, CreatedAt: now, UpdatedAt: now}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM transactions").
+		WithArgs(uint64(1), owner, owner).
+		WillReturnRows(pgxmock.NewRows(transactionRowsAll).AddRow(transactionToRow(transaction)...))
+	mock.ExpectQuery("SELECT (.+) FROM transfers t").
+		WithArgs(uint64(1), owner, owner, owner, owner).
+		WillReturnRows(pgxmock.NewRows(transferRowsAll).AddRow(transferToRow(transfer)...))
+	mock.ExpectCommit()
+
+	// the reads see what the transaction of the context wrote so far
+	err = NewTxManager(pool).InTx(userCtx, func(ctx context.Context) error {
+		found, err := transactions.FindByID(ctx, 1)
+		require.NoError(t, err)
+		require.Equal(t, transaction, found)
+
+		moved, err := transfers.FindByID(ctx, 1)
+		require.NoError(t, err)
+		require.Equal(t, transfer, moved)
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTxManager_InTxRollback(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	manager := NewTxManager(pool)
+
+	pool.ExpectBegin()
+	pool.ExpectRollback()
+
+	err = manager.InTx(userCtx, func(ctx context.Context) error { return connErr })
+	require.Equal(t, connErr, err)
+
+	pool.ExpectBegin()
+	pool.ExpectRollback()
+
+	require.PanicsWithValue(t, "panic", func() {
+		_ = manager.InTx(userCtx, func(ctx context.Context) error { panic("panic") })
+	})
+	require.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestTxManager_InTxNested(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	manager := NewTxManager(pool)
+	inner := errors.New("inner")
+
+	pool.ExpectBegin()
+	pool.ExpectBegin()
+	pool.ExpectRollback()
+	pool.ExpectCommit()
+
+	// the failed savepoint is rolled back alone and the outer transaction commits
+	err = manager.InTx(userCtx, func(ctx context.Context) error {
+		require.Equal(t, inner, manager.InTx(ctx, func(ctx context.Context) error { return inner }))
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestTxManager_InTxBeginError(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	pool.ExpectBegin().WillReturnError(connErr)
+
+	called := false
+	err = NewTxManager(pool).InTx(userCtx, func(ctx context.Context) error {
+		called = true
+		return nil
+	})
+	require.Equal(t, connErr, err)
+	require.False(t, called)
+}
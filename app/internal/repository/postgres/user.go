@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/repository"
+)
+
+func NewUser(pool Pool) repository.User { return &user{pool} }
+
+type user struct{ pool Pool }
+
+func scanUser(row pgx.Row, data *model.User) error {
+	return row.Scan(&data.ID, &data.Name, &data.Email, &data.PasswordHash, &data.CreatedAt, &data.UpdatedAt)
+}
+
+func (u *user) FindByID(ctx context.Context, id uint64) (data *model.User, err error) {
+	sql, args := dialect.FindUser(id)
+
+	data = &model.User{}
+	if err = userError(scanUser(conn(ctx, u.pool).QueryRow(ctx, sql, args...), data)); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (u *user) FindByEmail(ctx context.Context, email string) (data *model.User, err error) {
+	sql, args := dialect.FindUserByEmail(email)
+
+	data = &model.User{}
+	if err = userError(scanUser(conn(ctx, u.pool).QueryRow(ctx, sql, args...), data)); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (u *user) Create(ctx context.Context, data *model.User) error {
+	sql, args := dialect.CreateUser(data)
+
+	return userError(scanUser(conn(ctx, u.pool).QueryRow(ctx, sql, args...), data))
+}
+
+func (u *user) Update(ctx context.Context, data *model.User) error {
+	sql, args := dialect.UpdateUser(data)
+
+	return userError(scanUser(conn(ctx, u.pool).QueryRow(ctx, sql, args...), data))
+}
+
+func userError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return repository.ErrUserNotFound
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgerrcode.IsIntegrityConstraintViolation(pgErr.Code) {
+		return repository.ErrUserConflict
+	}
+
+	return err
+}
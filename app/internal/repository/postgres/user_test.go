@@ -0,0 +1,93 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/mustan989/wallet/app/internal/repository/postgres"
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/repository"
+)
+
+var userRowsAll = []string{
+	"id", "name", "email", "password_hash", "created_at", "updated_at",
+}
+
+func TestUser_FindByEmail(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	repo := NewUser(pool)
+
+	now := time.Now()
+	expect := &model.User{ID: 1, Name: "name", Email: "name@example.com", PasswordHash: []byte("hash"), CreatedAt: now, UpdatedAt: now}
+
+	pool.ExpectQuery("SELECT (.+) FROM users WHERE lower\\(email\\) = \\$1").
+		WithArgs("name@example.com").
+		WillReturnRows(pgxmock.NewRows(userRowsAll).AddRow(expect.ID, expect.Name, expect.Email, expect.PasswordHash, now, now))
+
+	data, err := repo.FindByEmail(context.Background(), "Name@Example.com")
+	require.NoError(t, err)
+	require.Equal(t, expect, data)
+}
+
+func TestUser_FindByIDError(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	repo := NewUser(pool)
+
+	pool.ExpectQuery("SELECT (.+) FROM users WHERE id = \\$1").
+		WithArgs(uint64(1)).
+		WillReturnError(getReturnError(repository.ErrWalletNotFound))
+
+	data, err := repo.FindByID(context.Background(), 1)
+	require.Zero(t, data)
+	require.Equal(t, repository.ErrUserNotFound, err)
+}
+
+func TestUser_Create(t *testing.T) {
+	subtests := [...]struct {
+		name   string
+		retErr error
+		err    error
+	}{
+		{"Success", nil, nil},
+		{"Conflict", &pgconn.PgError{Code: pgerrcode.UniqueViolation}, repository.ErrUserConflict},
+	}
+
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			repo := NewUser(pool)
+
+			now := time.Now()
+			data := &model.User{Name: "name", Email: "name@example.com", PasswordHash: []byte("hash")}
+
+			query := pool.ExpectQuery("INSERT INTO users (.+) RETURNING").
+				WithArgs(data.Name, data.Email, data.PasswordHash)
+			if subtest.retErr != nil {
+				query.WillReturnError(subtest.retErr)
+			} else {
+				query.WillReturnRows(pgxmock.NewRows(userRowsAll).AddRow(uint64(1), data.Name, data.Email, data.PasswordHash, now, now))
+			}
+
+			err := repo.Create(context.Background(), data)
+			require.Equal(t, subtest.err, err)
+			if subtest.err == nil {
+				require.Equal(t, uint64(1), data.ID)
+			}
+		})
+	}
+}
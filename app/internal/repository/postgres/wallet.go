@@ -3,9 +3,8 @@ package postgres
 import (
 	"context"
 	"errors"
-	"fmt"
+	"time"
 
-	"github.com/huandu/go-sqlbuilder"
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -18,64 +17,41 @@ func NewWallet(pool Pool) repository.Wallet { return &wallet{pool} }
 
 type wallet struct{ pool Pool }
 
-const (
-	walletsTable   = "wallets"
-	walletsBuilder = sqlbuilder.PostgreSQL
-)
+// scanWallet scans a wallet, binding its amount to its currency
+func scanWallet(row pgx.Row, data *model.Wallet) error {
+	if err := row.Scan(
+		&data.ID, &data.OwnerID, &data.Name, &data.Description, &data.Currency, &data.Amount, &data.Personal, &data.CreatedAt, &data.UpdatedAt, &data.DeletedAt, &data.Version,
+	); err != nil {
+		return err
+	}
+	return model.Bind(data.Currency, &data.Amount)
+}
 
 func (w *wallet) CountAll(ctx context.Context, filter *model.WalletFilter) (count uint64, err error) {
-	sb := walletsBuilder.NewSelectBuilder().
-		Select("COUNT(*)").
-		From(walletsTable)
-
-	if filter.NameLike != "" {
-		sb.Where(sb.Like("name", fmt.Sprint("%", filter.NameLike, "%")))
-	}
-	if filter.DescriptionLike != "" {
-		sb.Where(sb.Like("description", fmt.Sprint("%", filter.DescriptionLike, "%")))
-	}
-	if filter.Currency != "" {
-		sb.Where(sb.Equal("currency", filter.Currency))
-	}
-	if filter.Personal != nil {
-		sb.Where(sb.Equal("personal", filter.Personal))
+	userID, err := model.UserID(ctx)
+	if err != nil {
+		return 0, err
 	}
 
-	sql, args := sb.Build()
+	sql, args := dialect.CountWallets(userID, filter)
 
-	err = w.pool.QueryRow(ctx, sql, args...).Scan(&count)
+	err = conn(ctx, w.pool).QueryRow(ctx, sql, args...).Scan(&count)
 
 	return
 }
 
 func (w *wallet) FindAll(ctx context.Context, filter *model.WalletFilter) (data []*model.Wallet, err error) {
-	sb := walletsBuilder.NewSelectBuilder().
-		Select("id", "name", "description", "currency", "amount", "personal", "created_at", "updated_at", "deleted_at").
-		From(walletsTable)
-
-	if filter.NameLike != "" {
-		sb.Where(sb.Like("name", fmt.Sprint("%", filter.NameLike, "%")))
-	}
-	if filter.DescriptionLike != "" {
-		sb.Where(sb.Like("description", fmt.Sprint("%", filter.DescriptionLike, "%")))
-	}
-	if filter.Currency != "" {
-		sb.Where(sb.Equal("currency", filter.Currency))
-	}
-	if filter.Personal != nil {
-		sb.Where(sb.Equal("personal", filter.Personal))
+	userID, err := model.UserID(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	if filter.Limit != 0 {
-		sb.Limit(int(filter.Limit))
-	}
-	if filter.Offset != 0 {
-		sb.Offset(int(filter.Offset))
+	sql, args, err := dialect.FindWallets(userID, filter)
+	if err != nil {
+		return nil, err
 	}
 
-	sql, args := sb.OrderBy("id DESC").Build()
-
-	rows, err := w.pool.Query(ctx, sql, args...)
+	rows, err := conn(ctx, w.pool).Query(ctx, sql, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -85,9 +61,7 @@ func (w *wallet) FindAll(ctx context.Context, filter *model.WalletFilter) (data
 	for rows.Next() {
 		elem := &model.Wallet{}
 
-		if err = rows.Scan(
-			&elem.ID, &elem.Name, &elem.Description, &elem.Currency, &elem.Amount, &elem.Personal, &elem.CreatedAt, &elem.UpdatedAt, &elem.DeletedAt,
-		); err != nil {
+		if err = scanWallet(rows, elem); err != nil {
 			return nil, err
 		}
 
@@ -98,17 +72,15 @@ func (w *wallet) FindAll(ctx context.Context, filter *model.WalletFilter) (data
 }
 
 func (w *wallet) FindByID(ctx context.Context, id uint64) (data *model.Wallet, err error) {
-	sb := walletsBuilder.NewSelectBuilder().
-		Select("id", "name", "description", "currency", "amount", "personal", "created_at", "updated_at", "deleted_at").
-		From(walletsTable)
-	sb.Where(sb.E("id", id)).Limit(1)
+	userID, err := model.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	sql, args := sb.Build()
+	sql, args := dialect.FindWallet(userID, id)
 
 	data = &model.Wallet{}
-	err = w.pool.QueryRow(ctx, sql, args...).Scan(
-		&data.ID, &data.Name, &data.Description, &data.Currency, &data.Amount, &data.Personal, &data.CreatedAt, &data.UpdatedAt, &data.DeletedAt,
-	)
+	err = scanWallet(conn(ctx, w.pool).QueryRow(ctx, sql, args...), data)
 	if err != nil && errors.Is(err, pgx.ErrNoRows) {
 		return nil, repository.ErrWalletNotFound
 	}
@@ -120,83 +92,112 @@ func (w *wallet) FindByID(ctx context.Context, id uint64) (data *model.Wallet, e
 }
 
 func (w *wallet) Create(ctx context.Context, data *model.Wallet) error {
-	ib := walletsBuilder.NewInsertBuilder().
-		InsertInto(walletsTable).
-		Cols("name", "description", "currency", "amount", "personal").
-		Values(data.Name, data.Description, data.Currency, data.Amount, data.Personal)
-
-	sql, args := sqlbuilder.Build(
-		`$? RETURNING "id", "name", "description", "currency", "amount", "personal", "created_at", "updated_at", "deleted_at"`, ib,
-	).Build()
+	owner, err := model.UserID(ctx)
+	if err != nil {
+		return err
+	}
 
-	if err := w.pool.QueryRow(ctx, sql, args...).Scan(
-		&data.ID, &data.Name, &data.Description, &data.Currency, &data.Amount, &data.Personal, &data.CreatedAt, &data.UpdatedAt, &data.DeletedAt,
-	); err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) && pgerrcode.IsIntegrityConstraintViolation(pgErr.Code) {
-			return repository.ErrWalletConflict
-		}
+	sql, args := dialect.CreateWallet(owner, data)
 
-		return err
+	if err = scanWallet(conn(ctx, w.pool).QueryRow(ctx, sql, args...), data); err != nil {
+		return walletError(err)
 	}
 
 	return nil
 }
 
 func (w *wallet) Update(ctx context.Context, data *model.Wallet) error {
-	ub := walletsBuilder.NewUpdateBuilder().
-		Update(walletsTable)
-	ub.Set(
-		ub.Assign("name", data.Name),
-		ub.Assign("description", data.Description),
-		ub.Assign("currency", data.Currency),
-		ub.Assign("amount", data.Amount),
-		ub.Assign("personal", data.Personal),
-		"updated_at = default",
-		ub.Assign("deleted_at", data.DeletedAt),
-	).Where(ub.E("id", data.ID))
-
-	sql, args := sqlbuilder.Build(
-		`$? RETURNING "id", "name", "description", "currency", "amount", "personal", "created_at", "updated_at", "deleted_at"`, ub,
-	).Build()
-
-	if err := w.pool.QueryRow(ctx, sql, args...).Scan(
-		&data.ID, &data.Name, &data.Description, &data.Currency, &data.Amount, &data.Personal, &data.CreatedAt, &data.UpdatedAt, &data.DeletedAt,
-	); err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return repository.ErrWalletNotFound
-		}
+	userID, err := model.UserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	sql, args := dialect.UpdateWallet(userID, data)
 
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) && pgerrcode.IsIntegrityConstraintViolation(pgErr.Code) {
-			return repository.ErrWalletConflict
+	if err = scanWallet(conn(ctx, w.pool).QueryRow(ctx, sql, args...), data); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// the wallet is either gone or at another version
+			if _, err = w.FindByID(ctx, data.ID); err != nil {
+				return err
+			}
+			return repository.ErrWalletStale
 		}
 
-		return err
+		return walletError(err)
 	}
 
 	return nil
 }
 
 func (w *wallet) DeleteByID(ctx context.Context, id uint64) (deleted *model.Wallet, err error) {
-	db := walletsBuilder.NewDeleteBuilder().
-		DeleteFrom(walletsTable)
-	db.Where(db.E("id", id))
+	userID, err := model.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	sql, args := sqlbuilder.Build(
-		`$? RETURNING "id", "name", "description", "currency", "amount", "personal", "created_at", "updated_at", "deleted_at"`, db,
-	).Build()
+	sql, args := dialect.DeleteWallet(userID, id)
 
 	deleted = &model.Wallet{}
-	err = w.pool.QueryRow(ctx, sql, args...).Scan(
-		&deleted.ID, &deleted.Name, &deleted.Description, &deleted.Currency, &deleted.Amount, &deleted.Personal, &deleted.CreatedAt, &deleted.UpdatedAt, &deleted.DeletedAt,
-	)
-	if err != nil && errors.Is(err, pgx.ErrNoRows) {
-		return nil, repository.ErrWalletNotFound
-	}
+	err = inTx(ctx, w.pool, func(tx pgx.Tx) error {
+		// before the transfers go with the wallet; the rollback undoes it if the wallet is not found
+		if err := revertTransfers(ctx, tx, []uint64{id}); err != nil {
+			return err
+		}
+		err := scanWallet(tx.QueryRow(ctx, sql, args...), deleted)
+		if err != nil && errors.Is(err, pgx.ErrNoRows) {
+			return repository.ErrWalletNotFound
+		}
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	return
 }
+
+func (w *wallet) Purge(ctx context.Context, before time.Time) (count uint64, err error) {
+	sql, args := dialect.PurgedWallets(before, true)
+
+	err = inTx(ctx, w.pool, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, sql, args...)
+		if err != nil {
+			return err
+		}
+		ids, err := pgx.CollectRows(rows, pgx.RowTo[uint64])
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		// before the transfers go with the wallets
+		if err = revertTransfers(ctx, tx, ids); err != nil {
+			return err
+		}
+
+		sql, args := dialect.PurgeWallets(ids)
+
+		tag, err := tx.Exec(ctx, sql, args...)
+		if err != nil {
+			return err
+		}
+		count = uint64(tag.RowsAffected())
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// walletError maps the amount out of the range of model.Decimal to model.ErrDecimalOverflow
+// and the other integrity violations to repository.ErrWalletConflict
+func walletError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.CheckViolation && pgErr.ConstraintName == walletsAmountRange {
+		return model.ErrDecimalOverflow
+	}
+	if errors.As(err, &pgErr) && pgerrcode.IsIntegrityConstraintViolation(pgErr.Code) {
+		return repository.ErrWalletConflict
+	}
+	return err
+}
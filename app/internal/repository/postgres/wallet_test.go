@@ -23,9 +23,24 @@ func stringp(s string) *string     { return &s }
 func boolp(b bool) *bool           { return &b }
 func timep(t time.Time) *time.Time { return &t }
 
+func moneyp(m model.Money) *model.Money { return &m }
+
+var (
+	kzt = model.Currency{Code: "KZT", Exponent: 2}
+	usd = model.Currency{Code: "USD", Exponent: 2}
+	eur = model.Currency{Code: "EUR", Exponent: 2}
+)
+
+func money(amount int64, currency model.Currency) model.Money {
+	return model.Money{Amount: amount, Currency: currency}
+}
+
+// stored is an amount as the database returns it, in ten-thousandths
+func stored(amount int64) model.Money { return model.Money{Amount: amount, Currency: model.Unbound} }
+
 func dataToReturnRows(data ...*model.Wallet) *pgxmock.Rows {
 	return pgxmock.NewRows([]string{
-		"id", "name", "description", "currency", "amount", "personal", "created_at", "updated_at", "deleted_at",
+		"id", "owner_id", "name", "description", "currency", "amount", "personal", "created_at", "updated_at", "deleted_at", "version",
 	}).AddRows(dataToRows(data)...)
 }
 
@@ -38,7 +53,7 @@ func dataToRows(data []*model.Wallet) (rows [][]any) {
 
 func dataToRow(data *model.Wallet) []any {
 	return []any{
-		data.ID, data.Name, data.Description, data.Currency, data.Amount, data.Personal, data.CreatedAt, data.UpdatedAt, data.DeletedAt,
+		data.ID, data.OwnerID, data.Name, data.Description, data.Currency, data.Amount, data.Personal, data.CreatedAt, data.UpdatedAt, data.DeletedAt, data.Version,
 	}
 }
 
@@ -49,13 +64,20 @@ func getReturnError(err error) error {
 	if errors.Is(err, repository.ErrWalletConflict) {
 		return &pgconn.PgError{Code: pgerrcode.IntegrityConstraintViolation}
 	}
+	if errors.Is(err, model.ErrDecimalOverflow) {
+		return &pgconn.PgError{Code: pgerrcode.CheckViolation, ConstraintName: "wallets_amount_range"}
+	}
 	return err
 }
 
 var connErr = errors.New("connection error")
 
+const owner uint64 = 1
+
+var userCtx = model.WithUserID(context.Background(), owner)
+
 var rowsAll = []string{
-	"id", "name", "description", "currency", "amount", "personal", "created_at", "updated_at", "deleted_at",
+	"id", "owner_id", "name", "description", "currency", "amount", "personal", "created_at", "updated_at", "deleted_at", "version",
 }
 
 func TestWallet_CountAll(t *testing.T) {
@@ -83,17 +105,56 @@ func TestWallet_CountAll(t *testing.T) {
 			repo := NewWallet(pool)
 
 			pool.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM wallets")).
-				WithArgs(subtest.args...).
+				WithArgs(append([]any{owner, owner}, subtest.args...)...).
 				WillReturnRows(pgxmock.NewRows([]string{"count"}).
 					AddRow(subtest.expect))
 
-			count, err := repo.CountAll(context.Background(), subtest.filter)
+			count, err := repo.CountAll(userCtx, subtest.filter)
 			require.NoError(t, err)
 			require.Equal(t, subtest.expect, count)
 		})
 	}
 }
 
+func TestWallet_CountAllFilter(t *testing.T) {
+	date := time.Date(1999, 2, 23, 4, 36, 0, 0, time.UTC)
+	min, max := stored(10000), stored(999900)
+
+	subtests := [...]struct {
+		name   string
+		filter *model.WalletFilter
+		query  string
+		args   []any
+	}{
+		{"Currencies", &model.WalletFilter{Currencies: []string{"KZT", "USD"}}, "AND currency IN \\(\\$3, \\$4\\) AND deleted_at IS NULL$", []any{"KZT", "USD"}},
+		{"Amount", &model.WalletFilter{AmountMin: &min, AmountMax: &max}, "AND amount >= \\$3 AND amount <= \\$4 AND deleted_at IS NULL$", []any{min, max}},
+		{"Created", &model.WalletFilter{CreatedFrom: &date, CreatedTo: &date}, "AND created_at >= \\$3 AND created_at < \\$4 AND deleted_at IS NULL$", []any{date, date}},
+		{"Updated", &model.WalletFilter{UpdatedFrom: &date, UpdatedTo: &date}, "AND updated_at >= \\$3 AND updated_at < \\$4 AND deleted_at IS NULL$", []any{date, date}},
+		{"Deleted default", &model.WalletFilter{}, "AND deleted_at IS NULL$", nil},
+		{"Deleted excluded", &model.WalletFilter{Deleted: model.DeletedExclude}, "AND deleted_at IS NULL$", nil},
+		{"Deleted only", &model.WalletFilter{Deleted: model.DeletedOnly}, "AND deleted_at IS NOT NULL$", nil},
+		{"Deleted included", &model.WalletFilter{Deleted: model.DeletedInclude}, "FROM wallet_members WHERE user_id = \\$2\\)\\)\\)$", nil},
+	}
+
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			repo := NewWallet(pool)
+
+			pool.ExpectQuery(subtest.query).
+				WithArgs(append([]any{owner, owner}, subtest.args...)...).
+				WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(uint64(1)))
+
+			count, err := repo.CountAll(userCtx, subtest.filter)
+			require.NoError(t, err)
+			require.Equal(t, uint64(1), count)
+		})
+	}
+}
+
 func TestWallet_CountAllError(t *testing.T) {
 	subtests := [...]struct {
 		name   string
@@ -115,10 +176,10 @@ func TestWallet_CountAllError(t *testing.T) {
 			repo := NewWallet(pool)
 
 			pool.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM wallets")).
-				WithArgs(subtest.args...).
+				WithArgs(append([]any{owner, owner}, subtest.args...)...).
 				WillReturnError(getReturnError(subtest.err))
 
-			count, err := repo.CountAll(context.Background(), subtest.filter)
+			count, err := repo.CountAll(userCtx, subtest.filter)
 			require.Zero(t, count)
 			require.Equal(t, err, subtest.err)
 		})
@@ -141,39 +202,39 @@ func TestWallet_FindAll(t *testing.T) {
 		{"None Personal", &model.WalletFilter{Personal: boolp(true)}, []any{boolp(true)}, []*model.Wallet{}},
 		{"None Currency Personal", &model.WalletFilter{Currency: "KZT", Personal: boolp(true)}, []any{"KZT", boolp(true)}, []*model.Wallet{}},
 		{"Some", &model.WalletFilter{}, nil, []*model.Wallet{
-			{1, "name 1", stringp("desc 1"), "KZT", 9999, true, date, date, nil},
-			{2, "name 1", stringp("desc 1"), "USD", 9999, false, date, date, nil},
-			{3, "name 1", stringp("desc 1"), "EUR", 9999, true, date, date, nil},
-			{4, "name 2", stringp("desc 2"), "KZT", 9999, false, date, date, nil},
-			{5, "name 2", stringp("desc 2"), "USD", 9999, true, date, date, nil},
-			{6, "name 2", stringp("desc 2"), "EUR", 9999, false, date, date, nil},
+			{1, 1, "name 1", stringp("desc 1"), "KZT", money(9999, kzt), true, date, date, nil, 1},
+			{2, 1, "name 1", stringp("desc 1"), "USD", money(9999, usd), false, date, date, nil, 1},
+			{3, 1, "name 1", stringp("desc 1"), "EUR", money(9999, eur), true, date, date, nil, 1},
+			{4, 1, "name 2", stringp("desc 2"), "KZT", money(9999, kzt), false, date, date, nil, 1},
+			{5, 1, "name 2", stringp("desc 2"), "USD", money(9999, usd), true, date, date, nil, 1},
+			{6, 1, "name 2", stringp("desc 2"), "EUR", money(9999, eur), false, date, date, nil, 1},
 		}},
 		{"Some NameLike", &model.WalletFilter{NameLike: "1"}, []any{"%1%"}, []*model.Wallet{
-			{1, "name 1", stringp("desc 1"), "KZT", 9999, true, date, date, nil},
-			{2, "name 1", stringp("desc 1"), "USD", 9999, true, date, date, nil},
-			{3, "name 1", stringp("desc 1"), "EUR", 9999, false, date, date, nil},
+			{1, 1, "name 1", stringp("desc 1"), "KZT", money(9999, kzt), true, date, date, nil, 1},
+			{2, 1, "name 1", stringp("desc 1"), "USD", money(9999, usd), true, date, date, nil, 1},
+			{3, 1, "name 1", stringp("desc 1"), "EUR", money(9999, eur), false, date, date, nil, 1},
 		}},
 		{"Some DescriptionLike", &model.WalletFilter{DescriptionLike: "1"}, []any{"%1%"}, []*model.Wallet{
-			{1, "name 1", stringp("desc 1"), "KZT", 9999, true, date, date, nil},
-			{2, "name 1", stringp("desc 1"), "USD", 9999, true, date, date, nil},
-			{3, "name 1", stringp("desc 1"), "EUR", 9999, false, date, date, nil},
+			{1, 1, "name 1", stringp("desc 1"), "KZT", money(9999, kzt), true, date, date, nil, 1},
+			{2, 1, "name 1", stringp("desc 1"), "USD", money(9999, usd), true, date, date, nil, 1},
+			{3, 1, "name 1", stringp("desc 1"), "EUR", money(9999, eur), false, date, date, nil, 1},
 		}},
 		{"Some Currency", &model.WalletFilter{Currency: "KZT"}, []any{"KZT"}, []*model.Wallet{
-			{1, "name 1", stringp("desc 1"), "KZT", 9999, true, date, date, nil},
-			{4, "name 2", stringp("desc 2"), "KZT", 9999, true, date, date, nil},
+			{1, 1, "name 1", stringp("desc 1"), "KZT", money(9999, kzt), true, date, date, nil, 1},
+			{4, 1, "name 2", stringp("desc 2"), "KZT", money(9999, kzt), true, date, date, nil, 1},
 		}},
 		{"Some Personal", &model.WalletFilter{Personal: boolp(true)}, []any{boolp(true)}, []*model.Wallet{
-			{1, "name 1", stringp("desc 1"), "KZT", 9999, true, date, date, nil},
-			{3, "name 1", stringp("desc 1"), "EUR", 9999, true, date, date, nil},
-			{5, "name 2", stringp("desc 2"), "USD", 9999, true, date, date, nil},
+			{1, 1, "name 1", stringp("desc 1"), "KZT", money(9999, kzt), true, date, date, nil, 1},
+			{3, 1, "name 1", stringp("desc 1"), "EUR", money(9999, eur), true, date, date, nil, 1},
+			{5, 1, "name 2", stringp("desc 2"), "USD", money(9999, usd), true, date, date, nil, 1},
 		}},
 		{"Some Currency Personal", &model.WalletFilter{Currency: "KZT", Personal: boolp(true)}, []any{"KZT", boolp(true)}, []*model.Wallet{
-			{1, "name 1", stringp("desc 1"), "KZT", 9999, true, date, date, nil},
+			{1, 1, "name 1", stringp("desc 1"), "KZT", money(9999, kzt), true, date, date, nil, 1},
 		}},
-		{"Some Limit Offset", &model.WalletFilter{Filter: model.Filter{3, 2}}, nil, []*model.Wallet{
-			{3, "name 1", stringp("desc 1"), "EUR", 9999, true, date, date, nil},
-			{4, "name 2", stringp("desc 2"), "KZT", 9999, false, date, date, nil},
-			{5, "name 2", stringp("desc 2"), "USD", 9999, true, date, date, nil},
+		{"Some Limit Offset", &model.WalletFilter{Filter: model.Filter{Limit: 3, Offset: 2}}, nil, []*model.Wallet{
+			{3, 1, "name 1", stringp("desc 1"), "EUR", money(9999, eur), true, date, date, nil, 1},
+			{4, 1, "name 2", stringp("desc 2"), "KZT", money(9999, kzt), false, date, date, nil, 1},
+			{5, 1, "name 2", stringp("desc 2"), "USD", money(9999, usd), true, date, date, nil, 1},
 		}},
 	}
 
@@ -186,17 +247,104 @@ func TestWallet_FindAll(t *testing.T) {
 			repo := NewWallet(pool)
 
 			pool.ExpectQuery("SELECT (.+) FROM wallets").
-				WithArgs(subtest.args...).
+				WithArgs(append([]any{owner, owner}, subtest.args...)...).
 				WillReturnRows(pgxmock.NewRows(rowsAll).
 					AddRows(dataToRows(subtest.expect)...))
 
-			data, err := repo.FindAll(context.Background(), subtest.filter)
+			data, err := repo.FindAll(userCtx, subtest.filter)
 			require.NoError(t, err)
 			require.Equal(t, subtest.expect, data)
 		})
 	}
 }
 
+func TestWallet_FindAllSort(t *testing.T) {
+	subtests := [...]struct {
+		name   string
+		filter *model.WalletFilter
+		order  string
+	}{
+		{"Default", &model.WalletFilter{}, "ORDER BY id DESC$"},
+		{"ID", &model.WalletFilter{Sort: model.WalletSortID}, "ORDER BY id DESC$"},
+		{"ID ascending", &model.WalletFilter{Sort: model.WalletSortID, Order: model.Asc}, "ORDER BY id ASC$"},
+		{"Name", &model.WalletFilter{Sort: model.WalletSortName}, "ORDER BY name ASC, id ASC$"},
+		{"Amount descending", &model.WalletFilter{Sort: model.WalletSortAmount, Order: model.Desc}, "ORDER BY amount DESC, id DESC$"},
+		{"Updated limit", &model.WalletFilter{Filter: model.Filter{Limit: 3}, Sort: model.WalletSortUpdatedAt}, "ORDER BY updated_at ASC, id ASC LIMIT 3$"},
+		{"Unknown", &model.WalletFilter{Sort: "owner_id; DROP TABLE wallets"}, "ORDER BY id DESC$"},
+	}
+
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			repo := NewWallet(pool)
+
+			pool.ExpectQuery(subtest.order).
+				WithArgs(owner, owner).
+				WillReturnRows(pgxmock.NewRows(rowsAll))
+
+			data, err := repo.FindAll(userCtx, subtest.filter)
+			require.NoError(t, err)
+			require.Empty(t, data)
+		})
+	}
+}
+
+func TestWallet_FindAllCursor(t *testing.T) {
+	date := time.Date(1999, 2, 23, 4, 36, 0, 0, time.UTC)
+	last := &model.Wallet{ID: 3, Name: "name", Amount: money(9999, kzt), CreatedAt: date}
+
+	subtests := [...]struct {
+		name   string
+		filter *model.WalletFilter
+		query  string
+		args   []any
+	}{
+		{"ID", &model.WalletFilter{Filter: model.Filter{Limit: 2}}, "AND id < \\$3 ORDER BY id DESC LIMIT 2$", []any{uint64(3)}},
+		{"Name", &model.WalletFilter{Sort: model.WalletSortName}, "AND \\(name, id\\) > \\(\\$3, \\$4\\) ORDER BY name ASC, id ASC$", []any{stringp("name"), uint64(3)}},
+		{"Amount descending", &model.WalletFilter{Sort: model.WalletSortAmount, Order: model.Desc}, "AND \\(amount, id\\) < \\(\\$3, \\$4\\) ORDER BY amount DESC, id DESC$", []any{moneyp(stored(999900)), uint64(3)}},
+		{"Created offset", &model.WalletFilter{Filter: model.Filter{Offset: 1}, Sort: model.WalletSortCreatedAt}, "AND \\(created_at, id\\) > \\(\\$3, \\$4\\) ORDER BY created_at ASC, id ASC OFFSET 1$", []any{timep(date), uint64(3)}},
+	}
+
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			repo := NewWallet(pool)
+			subtest.filter.Cursor = subtest.filter.Next(last)
+
+			pool.ExpectQuery(subtest.query).
+				WithArgs(append([]any{owner, owner}, subtest.args...)...).
+				WillReturnRows(pgxmock.NewRows(rowsAll))
+
+			data, err := repo.FindAll(userCtx, subtest.filter)
+			require.NoError(t, err)
+			require.Empty(t, data)
+		})
+	}
+}
+
+func TestWallet_FindAllInvalidCursor(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	repo := NewWallet(pool)
+
+	// the cursor was made for the default order
+	filter := &model.WalletFilter{Sort: model.WalletSortName}
+	filter.Cursor = (&model.WalletFilter{}).Next(&model.Wallet{ID: 3})
+
+	data, err := repo.FindAll(userCtx, filter)
+	require.Nil(t, data)
+	require.ErrorIs(t, err, model.ErrInvalidCursor)
+	require.NoError(t, pool.ExpectationsWereMet())
+}
+
 func TestWallet_FindAllError(t *testing.T) {
 	subtests := [...]struct {
 		name   string
@@ -221,10 +369,10 @@ func TestWallet_FindAllError(t *testing.T) {
 			repo := NewWallet(pool)
 
 			pool.ExpectQuery("SELECT (.+) FROM wallets").
-				WithArgs(subtest.args...).
+				WithArgs(append([]any{owner, owner}, subtest.args...)...).
 				WillReturnError(getReturnError(subtest.err))
 
-			data, err := repo.FindAll(context.Background(), subtest.filter)
+			data, err := repo.FindAll(userCtx, subtest.filter)
 			require.Zero(t, data)
 			require.Equal(t, subtest.err, err)
 		})
@@ -250,11 +398,11 @@ func TestWallet_FindAllScanError(t *testing.T) {
 			repo := NewWallet(pool)
 
 			pool.ExpectQuery("SELECT (.+) FROM wallets").
-				WithArgs(subtest.args...).
+				WithArgs(append([]any{owner, owner}, subtest.args...)...).
 				WillReturnRows(pgxmock.NewRows(rowsAll).
-					AddRow(1, 2, 3, 4, 5, 6, 7, 8, 9))
+					AddRow(1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11))
 
-			data, err := repo.FindAll(context.Background(), subtest.filter)
+			data, err := repo.FindAll(userCtx, subtest.filter)
 			require.Zero(t, data)
 			require.Error(t, err)
 		})
@@ -267,7 +415,7 @@ func TestWallet_FindByID(t *testing.T) {
 		input  uint64
 		expect *model.Wallet
 	}{
-		{"ID", 1, &model.Wallet{1, "name", stringp("desc"), "KZT", 9999, true, time.Now(), time.Now(), nil}},
+		{"ID", 1, &model.Wallet{1, 1, "name", stringp("desc"), "KZT", money(9999, kzt), true, time.Now(), time.Now(), nil, 1}},
 	}
 
 	pool, err := pgxmock.NewPool()
@@ -279,11 +427,11 @@ func TestWallet_FindByID(t *testing.T) {
 			repo := NewWallet(pool)
 
 			pool.ExpectQuery("SELECT (.+) FROM wallets (.+) LIMIT 1").
-				WithArgs(subtest.input).
+				WithArgs(subtest.input, owner, owner).
 				WillReturnRows(pgxmock.NewRows(rowsAll).
 					AddRow(dataToRow(subtest.expect)...))
 
-			data, err := repo.FindByID(context.Background(), subtest.input)
+			data, err := repo.FindByID(userCtx, subtest.input)
 			require.NoError(t, err)
 			require.Equal(t, subtest.expect, data)
 		})
@@ -309,10 +457,10 @@ func TestWallet_FindByIDError(t *testing.T) {
 			repo := NewWallet(pool)
 
 			pool.ExpectQuery("SELECT (.+) FROM wallets (.+) LIMIT 1").
-				WithArgs(subtest.input).
+				WithArgs(subtest.input, owner, owner).
 				WillReturnError(getReturnError(subtest.err))
 
-			data, err := repo.FindByID(context.Background(), subtest.input)
+			data, err := repo.FindByID(userCtx, subtest.input)
 			require.Zero(t, data)
 			require.Equal(t, subtest.err, err)
 		})
@@ -328,7 +476,7 @@ func TestWallet_Create(t *testing.T) {
 			Name:        "name",
 			Description: stringp("desc"),
 			Currency:    "KZT",
-			Amount:      9999,
+			Amount:      money(9999, kzt),
 			Personal:    true,
 		}},
 	}
@@ -345,11 +493,11 @@ func TestWallet_Create(t *testing.T) {
 			now := time.Now()
 
 			pool.ExpectQuery("INSERT INTO wallets (.+)").
-				WithArgs(data.Name, data.Description, data.Currency, data.Amount, data.Personal).
+				WithArgs(owner, data.Name, data.Description, data.Currency, data.Amount, data.Personal).
 				WillReturnRows(pgxmock.NewRows(rowsAll).
-					AddRow(uint64(1), data.Name, data.Description, data.Currency, data.Amount, data.Personal, now, now, nil))
+					AddRow(uint64(1), owner, data.Name, data.Description, data.Currency, data.Amount, data.Personal, now, now, nil, uint64(1)))
 
-			err := repo.Create(context.Background(), data)
+			err := repo.Create(userCtx, data)
 			require.NoError(t, err)
 
 			assert.NotZero(t, data.ID)
@@ -369,14 +517,19 @@ func TestWallet_CreateError(t *testing.T) {
 			Name:        "name",
 			Description: stringp("desc"),
 			Currency:    "KZT",
-			Amount:      9999,
+			Amount:      money(9999, kzt),
 			Personal:    true,
 		}, repository.ErrWalletConflict},
+		{"Amount out of range", &model.Wallet{
+			Name:     "name",
+			Currency: "KZT",
+			Amount:   money(model.MaxStored/100+1, kzt),
+		}, model.ErrDecimalOverflow},
 		{"Connection error", &model.Wallet{
 			Name:        "name",
 			Description: stringp("desc"),
 			Currency:    "KZT",
-			Amount:      9999,
+			Amount:      money(9999, kzt),
 			Personal:    true,
 		}, connErr},
 	}
@@ -392,10 +545,10 @@ func TestWallet_CreateError(t *testing.T) {
 			data := subtest.input
 
 			pool.ExpectQuery("INSERT INTO wallets (.+)").
-				WithArgs(data.Name, data.Description, data.Currency, data.Amount, data.Personal).
+				WithArgs(owner, data.Name, data.Description, data.Currency, data.Amount, data.Personal).
 				WillReturnError(getReturnError(subtest.err))
 
-			err := repo.Create(context.Background(), data)
+			err := repo.Create(userCtx, data)
 			require.Zero(t, data.ID)
 			require.Equal(t, subtest.err, err)
 		})
@@ -412,17 +565,19 @@ func TestWallet_Update(t *testing.T) {
 			Name:        "name",
 			Description: nil,
 			Currency:    "KZT",
-			Amount:      9999,
+			Amount:      money(9999, kzt),
 			Personal:    true,
+			Version:     1,
 		}},
 		{"Delete", &model.Wallet{
 			ID:          1,
 			Name:        "name",
 			Description: nil,
 			Currency:    "KZT",
-			Amount:      9999,
+			Amount:      money(9999, kzt),
 			Personal:    true,
 			DeletedAt:   timep(time.Now()),
+			Version:     4,
 		}},
 	}
 	pool, err := pgxmock.NewPool()
@@ -434,18 +589,20 @@ func TestWallet_Update(t *testing.T) {
 			repo := NewWallet(pool)
 
 			data := subtest.input
+			version := data.Version
 
 			now := time.Now()
 
 			pool.ExpectQuery("UPDATE wallets").
-				WithArgs(data.Name, data.Description, data.Currency, data.Amount, data.Personal, data.DeletedAt, data.ID).
+				WithArgs(data.Name, data.Description, data.Currency, data.Personal, data.DeletedAt, data.ID, data.Version, owner, owner).
 				WillReturnRows(pgxmock.NewRows(rowsAll).
-					AddRow(data.ID, data.Name, data.Description, data.Currency, data.Amount, data.Personal, now.Add(-24*time.Hour), now, data.DeletedAt))
+					AddRow(data.ID, owner, data.Name, data.Description, data.Currency, data.Amount, data.Personal, now.Add(-24*time.Hour), now, data.DeletedAt, data.Version+1))
 
-			err := repo.Update(context.Background(), data)
+			err := repo.Update(userCtx, data)
 			require.NoError(t, err)
 
 			assert.Equal(t, now, data.UpdatedAt)
+			assert.Equal(t, version+1, data.Version)
 		})
 	}
 }
@@ -460,21 +617,21 @@ func TestWallet_UpdateError(t *testing.T) {
 			Name:        "name",
 			Description: stringp("desc"),
 			Currency:    "KZT",
-			Amount:      9999,
+			Amount:      money(9999, kzt),
 			Personal:    true,
 		}, repository.ErrWalletNotFound},
 		{"Conflict", &model.Wallet{
 			Name:        "name",
 			Description: stringp("desc"),
 			Currency:    "KZT",
-			Amount:      9999,
+			Amount:      money(9999, kzt),
 			Personal:    true,
 		}, repository.ErrWalletConflict},
 		{"Connection error", &model.Wallet{
 			Name:        "name",
 			Description: stringp("desc"),
 			Currency:    "KZT",
-			Amount:      9999,
+			Amount:      money(9999, kzt),
 			Personal:    true,
 		}, connErr},
 	}
@@ -490,23 +647,50 @@ func TestWallet_UpdateError(t *testing.T) {
 			data := subtest.input
 
 			pool.ExpectQuery("UPDATE wallets").
-				WithArgs(data.Name, data.Description, data.Currency, data.Amount, data.Personal, data.DeletedAt, data.ID).
+				WithArgs(data.Name, data.Description, data.Currency, data.Personal, data.DeletedAt, data.ID, data.Version, owner, owner).
 				WillReturnError(getReturnError(subtest.err))
+			if errors.Is(subtest.err, repository.ErrWalletNotFound) {
+				pool.ExpectQuery("SELECT (.+) FROM wallets WHERE id = \\$1").
+					WithArgs(data.ID, owner, owner).
+					WillReturnError(pgx.ErrNoRows)
+			}
 
-			err := repo.Update(context.Background(), data)
+			err := repo.Update(userCtx, data)
 			require.Zero(t, data.ID)
 			require.Equal(t, subtest.err, err)
 		})
 	}
 }
 
+func TestWallet_UpdateStale(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	repo := NewWallet(pool)
+
+	current := &model.Wallet{ID: 1, OwnerID: owner, Name: "name", Currency: "KZT", Version: 3}
+	data := &model.Wallet{ID: 1, Name: "new name", Currency: "KZT", Version: 2}
+
+	pool.ExpectQuery("UPDATE wallets SET (.+) WHERE id = (.+) AND version = (.+)").
+		WithArgs(data.Name, data.Description, data.Currency, data.Personal, data.DeletedAt, data.ID, data.Version, owner, owner).
+		WillReturnError(pgx.ErrNoRows)
+	pool.ExpectQuery("SELECT (.+) FROM wallets WHERE id = \\$1").
+		WithArgs(data.ID, owner, owner).
+		WillReturnRows(dataToReturnRows(current))
+
+	err = repo.Update(userCtx, data)
+	require.ErrorIs(t, err, repository.ErrWalletStale)
+	require.Equal(t, "new name", data.Name)
+}
+
 func TestWallet_DeleteByID(t *testing.T) {
 	subtests := [...]struct {
 		name   string
 		input  uint64
 		expect *model.Wallet
 	}{
-		{"ID", 1, &model.Wallet{1, "name", nil, "KZT", 9999, true, time.Now().Add(-24 * time.Hour), time.Now().Add(-10 * time.Minute), timep(time.Now())}},
+		{"ID", 1, &model.Wallet{1, 1, "name", nil, "KZT", money(9999, kzt), true, time.Now().Add(-24 * time.Hour), time.Now().Add(-10 * time.Minute), timep(time.Now()), 1}},
 	}
 
 	pool, err := pgxmock.NewPool()
@@ -517,18 +701,97 @@ func TestWallet_DeleteByID(t *testing.T) {
 		t.Run(subtest.name, func(t *testing.T) {
 			repo := NewWallet(pool)
 
+			pool.ExpectBegin()
+			expectTransfers(pool, subtest.input)
 			pool.ExpectQuery("DELETE FROM wallets").
-				WithArgs(subtest.input).
+				WithArgs(subtest.input, owner, owner).
 				WillReturnRows(pgxmock.NewRows(rowsAll).
 					AddRow(dataToRow(subtest.expect)...))
+			pool.ExpectCommit()
 
-			data, err := repo.DeleteByID(context.Background(), subtest.input)
+			data, err := repo.DeleteByID(userCtx, subtest.input)
 			require.NoError(t, err)
 			require.Equal(t, subtest.expect, data)
 		})
 	}
 }
 
+// expectTransfers expects the transfers of the wallets to be looked up, returning rows of
+// from_wallet_id, to_wallet_id, amount, received_amount and commission
+func expectTransfers(pool pgxmock.PgxPoolIface, id uint64, rows ...[]any) {
+	result := pgxmock.NewRows([]string{"from_wallet_id", "to_wallet_id", "amount", "received_amount", "commission"})
+	for _, row := range rows {
+		result.AddRow(row...)
+	}
+	pool.ExpectQuery("SELECT (.+) FROM transfers t LEFT JOIN transactions c ON c.transfer_id = t.id WHERE \\(t.from_wallet_id IN \\(\\$1\\) OR t.to_wallet_id IN \\(\\$2\\)\\)").
+		WithArgs(id, id).
+		WillReturnRows(result)
+}
+
+func TestWallet_DeleteByIDTransfers(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	repo := NewWallet(pool)
+	deleted := &model.Wallet{1, 1, "name", nil, "KZT", money(9999, kzt), true, time.Now().Add(-24 * time.Hour), time.Now().Add(-10 * time.Minute), timep(time.Now()), 1}
+
+	// the wallet sent 100 with a commission of 5 to wallet 2 and received 30 from wallet 3
+	pool.ExpectBegin()
+	expectTransfers(pool, 1,
+		[]any{uint64(1), uint64(2), stored(10000), stored(10000), stored(500)},
+		[]any{uint64(3), uint64(1), stored(3000), stored(3000), stored(0)},
+	)
+	pool.ExpectExec("UPDATE wallets SET amount = amount \\+ \\$1, updated_at = default, version = version \\+ 1 WHERE id = \\$2$").
+		WithArgs(stored(-10000), uint64(2)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	pool.ExpectExec("UPDATE wallets SET amount = amount \\+ \\$1, updated_at = default, version = version \\+ 1 WHERE id = \\$2$").
+		WithArgs(stored(3000), uint64(3)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	pool.ExpectQuery("DELETE FROM wallets").
+		WithArgs(uint64(1), owner, owner).
+		WillReturnRows(pgxmock.NewRows(rowsAll).AddRow(dataToRow(deleted)...))
+	pool.ExpectCommit()
+
+	data, err := repo.DeleteByID(userCtx, 1)
+	require.NoError(t, err)
+	require.Equal(t, deleted, data)
+	require.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestWallet_Purge(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	repo := NewWallet(pool)
+	before := time.Date(1999, 2, 23, 4, 36, 0, 0, time.UTC)
+
+	// purge runs in the background with no acting user
+	pool.ExpectBegin()
+	pool.ExpectQuery("^SELECT id FROM wallets WHERE deleted_at IS NOT NULL AND deleted_at < \\$1 ORDER BY id FOR UPDATE$").
+		WithArgs(before).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint64(1)).AddRow(uint64(2)))
+	// a transfer between the purged wallets leaves the others alone, the one to wallet 5 is taken back
+	pool.ExpectQuery("SELECT (.+) FROM transfers t").
+		WithArgs(uint64(1), uint64(2), uint64(1), uint64(2)).
+		WillReturnRows(pgxmock.NewRows([]string{"from_wallet_id", "to_wallet_id", "amount", "received_amount", "commission"}).
+			AddRow(uint64(1), uint64(2), stored(10000), stored(10000), stored(0)).
+			AddRow(uint64(2), uint64(5), stored(10000), stored(5000), stored(100)))
+	pool.ExpectExec("UPDATE wallets SET amount = amount \\+ \\$1, updated_at = default, version = version \\+ 1 WHERE id = \\$2$").
+		WithArgs(stored(-5000), uint64(5)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	pool.ExpectExec("^DELETE FROM wallets WHERE id IN \\(\\$1, \\$2\\)$").
+		WithArgs(uint64(1), uint64(2)).
+		WillReturnResult(pgxmock.NewResult("DELETE", 2))
+	pool.ExpectCommit()
+
+	count, err := repo.Purge(context.Background(), before)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), count)
+	require.NoError(t, pool.ExpectationsWereMet())
+}
+
 func TestWallet_DeleteByIDError(t *testing.T) {
 	subtests := [...]struct {
 		name  string
@@ -547,13 +810,29 @@ func TestWallet_DeleteByIDError(t *testing.T) {
 		t.Run(subtest.name, func(t *testing.T) {
 			repo := NewWallet(pool)
 
+			pool.ExpectBegin()
+			expectTransfers(pool, subtest.input)
 			pool.ExpectQuery("DELETE FROM wallets").
-				WithArgs(subtest.input).
+				WithArgs(subtest.input, owner, owner).
 				WillReturnError(getReturnError(subtest.err))
+			pool.ExpectRollback()
 
-			data, err := repo.DeleteByID(context.Background(), subtest.input)
+			data, err := repo.DeleteByID(userCtx, subtest.input)
 			require.Zero(t, data)
 			require.Equal(t, subtest.err, err)
 		})
 	}
 }
+
+func TestWallet_NoUser(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	repo := NewWallet(pool)
+
+	data, err := repo.FindAll(context.Background(), &model.WalletFilter{})
+	require.Zero(t, data)
+	require.Equal(t, model.ErrNoUser, err)
+	require.NoError(t, pool.ExpectationsWereMet())
+}
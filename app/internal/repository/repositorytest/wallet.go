@@ -0,0 +1,558 @@
+// Package repositorytest holds conformance suites for the repository interfaces.
+// They check behavior rather than queries, so every storage backend is held to the same contract
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/repository"
+)
+
+// users the suites act as, factories make sure both exist when the storage refers to users
+const (
+	Owner    uint64 = 1
+	Stranger uint64 = 2
+)
+
+var (
+	ownerCtx    = model.WithUserID(context.Background(), Owner)
+	strangerCtx = model.WithUserID(context.Background(), Stranger)
+)
+
+// currencies of the suites, the storage returns the amounts bound to them
+var (
+	kzt = model.Currency{Code: "KZT", Exponent: 2}
+	usd = model.Currency{Code: "USD", Exponent: 2}
+	eur = model.Currency{Code: "EUR", Exponent: 2}
+	kwd = model.Currency{Code: "KWD", Exponent: 3}
+)
+
+func money(amount int64, currency model.Currency) model.Money {
+	return model.Money{Amount: amount, Currency: currency}
+}
+
+// missingID is the id of no wallet, ids of the suites stay far below it
+const missingID uint64 = 1 << 40
+
+// tick is long enough for the storage clock to move, SQLite keeps milliseconds
+const tick = 2 * time.Millisecond
+
+// TestWallet runs the wallet suite, newWallet returns an empty repository for every subtest
+func TestWallet(t *testing.T, newWallet func(t *testing.T) repository.Wallet) {
+	t.Run("FindAll", func(t *testing.T) { testWalletFindAll(t, newWallet(t)) })
+	t.Run("Ordering", func(t *testing.T) { testWalletOrdering(t, newWallet(t)) })
+	t.Run("Pagination", func(t *testing.T) { testWalletPagination(t, newWallet(t)) })
+	t.Run("FindByID", func(t *testing.T) { testWalletFindByID(t, newWallet(t)) })
+	t.Run("Create", func(t *testing.T) { testWalletCreate(t, newWallet(t)) })
+	t.Run("Update", func(t *testing.T) { testWalletUpdate(t, newWallet(t)) })
+	t.Run("DeleteByID", func(t *testing.T) { testWalletDeleteByID(t, newWallet(t)) })
+	t.Run("Purge", func(t *testing.T) { testWalletPurge(t, newWallet(t)) })
+	t.Run("Timestamps", func(t *testing.T) { testWalletTimestamps(t, newWallet(t)) })
+	t.Run("NoUser", func(t *testing.T) { testWalletNoUser(t, newWallet(t)) })
+}
+
+// seedWallets creates the wallets in order as the owner, apart enough for their timestamps to differ
+func seedWallets(t *testing.T, repo repository.Wallet, data ...*model.Wallet) []*model.Wallet {
+	for _, datum := range data {
+		time.Sleep(tick)
+		require.NoError(t, repo.Create(ownerCtx, datum))
+	}
+	return data
+}
+
+// deleteWallet marks the wallet deleted at the time
+func deleteWallet(t *testing.T, repo repository.Wallet, id uint64, at time.Time) *model.Wallet {
+	data, err := repo.FindByID(ownerCtx, id)
+	require.NoError(t, err)
+	data.DeletedAt = &at
+	require.NoError(t, repo.Update(ownerCtx, data))
+	return data
+}
+
+func walletIDs(data []*model.Wallet) []uint64 {
+	ids := []uint64{}
+	for _, datum := range data {
+		ids = append(ids, datum.ID)
+	}
+	return ids
+}
+
+func testWalletFindAll(t *testing.T, repo repository.Wallet) {
+	created := seedWallets(t, repo,
+		&model.Wallet{Name: "cash", Currency: "KZT", Amount: money(500, kzt)},
+		&model.Wallet{Name: "card", Description: stringp("salary card"), Currency: "USD", Amount: money(1500, usd)},
+		&model.Wallet{Name: "savings", Currency: "EUR", Amount: money(100000, eur), Personal: true},
+		&model.Wallet{Name: "old", Currency: "KZT", Amount: money(0, kzt)},
+	)
+	cash, card, savings := created[0], created[1], created[2]
+	old := deleteWallet(t, repo, created[3].ID, time.Now())
+
+	// the bounds come unbound like query parameters and compare with the amounts of any currency
+	min, max := money(50000, model.Unbound), money(150000, model.Unbound)
+	personal, shared := true, false
+
+	subtests := []struct {
+		name   string
+		filter *model.WalletFilter
+		expect []*model.Wallet
+	}{
+		{"Default", &model.WalletFilter{}, []*model.Wallet{savings, card, cash}},
+		{"Name like", &model.WalletFilter{NameLike: "ca"}, []*model.Wallet{card, cash}},
+		{"Name like is case sensitive", &model.WalletFilter{NameLike: "CA"}, nil},
+		{"Name like any character", &model.WalletFilter{NameLike: "c_s"}, []*model.Wallet{cash}},
+		{"Name like any characters", &model.WalletFilter{NameLike: "s%s"}, []*model.Wallet{savings}},
+		{"Description like any characters", &model.WalletFilter{DescriptionLike: "sal%card"}, []*model.Wallet{card}},
+		{"Description like", &model.WalletFilter{DescriptionLike: "salary"}, []*model.Wallet{card}},
+		{"Currency", &model.WalletFilter{Currency: "KZT"}, []*model.Wallet{cash}},
+		{"Currencies", &model.WalletFilter{Currencies: []string{"USD", "EUR"}}, []*model.Wallet{savings, card}},
+		{"Personal", &model.WalletFilter{Personal: &personal}, []*model.Wallet{savings}},
+		{"Shared", &model.WalletFilter{Personal: &shared}, []*model.Wallet{card, cash}},
+		{"Amount min", &model.WalletFilter{AmountMin: &max}, []*model.Wallet{savings, card}},
+		{"Amount range", &model.WalletFilter{AmountMin: &min, AmountMax: &max}, []*model.Wallet{card, cash}},
+		{"Created from", &model.WalletFilter{CreatedFrom: &card.CreatedAt}, []*model.Wallet{savings, card}},
+		{"Created to", &model.WalletFilter{CreatedTo: &card.CreatedAt}, []*model.Wallet{cash}},
+		{"Updated range", &model.WalletFilter{UpdatedFrom: &cash.UpdatedAt, UpdatedTo: &savings.UpdatedAt}, []*model.Wallet{card, cash}},
+		{"Deleted only", &model.WalletFilter{Deleted: model.DeletedOnly}, []*model.Wallet{old}},
+		{"Deleted included", &model.WalletFilter{Deleted: model.DeletedInclude}, []*model.Wallet{old, savings, card, cash}},
+		{"Combined", &model.WalletFilter{NameLike: "a", Currency: "KZT", Deleted: model.DeletedInclude}, []*model.Wallet{cash}},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			data, err := repo.FindAll(ownerCtx, subtest.filter)
+			require.NoError(t, err)
+			assert.Equal(t, walletIDs(subtest.expect), walletIDs(data))
+
+			count, err := repo.CountAll(ownerCtx, subtest.filter)
+			require.NoError(t, err)
+			assert.Equal(t, uint64(len(subtest.expect)), count)
+		})
+	}
+
+	t.Run("Listed as found", func(t *testing.T) {
+		data, err := repo.FindAll(ownerCtx, &model.WalletFilter{Deleted: model.DeletedInclude})
+		require.NoError(t, err)
+		for _, datum := range data {
+			found, err := repo.FindByID(ownerCtx, datum.ID)
+			require.NoError(t, err)
+			assert.Equal(t, found, datum)
+		}
+	})
+
+	t.Run("Not accessible", func(t *testing.T) {
+		data, err := repo.FindAll(strangerCtx, &model.WalletFilter{Deleted: model.DeletedInclude})
+		require.NoError(t, err)
+		assert.NotNil(t, data)
+		assert.Empty(t, data)
+
+		count, err := repo.CountAll(strangerCtx, &model.WalletFilter{Deleted: model.DeletedInclude})
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+}
+
+func testWalletOrdering(t *testing.T, repo repository.Wallet) {
+	created := seedWallets(t, repo,
+		&model.Wallet{Name: "b", Currency: "KZT", Amount: money(200, kzt)},
+		&model.Wallet{Name: "a", Currency: "USD", Amount: money(100, usd)},
+		&model.Wallet{Name: "b", Currency: "EUR", Amount: money(-300, eur)},
+		&model.Wallet{Name: "c", Currency: "KZT", Amount: money(100, kzt)},
+	)
+	w1, w2, w3, w4 := created[0], created[1], created[2], created[3]
+
+	// ties are broken by id in the direction of the order
+	subtests := []struct {
+		name   string
+		filter *model.WalletFilter
+		expect []*model.Wallet
+	}{
+		{"Default", &model.WalletFilter{}, []*model.Wallet{w4, w3, w2, w1}},
+		{"Id asc", &model.WalletFilter{Order: model.Asc}, []*model.Wallet{w1, w2, w3, w4}},
+		{"Name", &model.WalletFilter{Sort: model.WalletSortName}, []*model.Wallet{w2, w1, w3, w4}},
+		{"Name desc", &model.WalletFilter{Sort: model.WalletSortName, Order: model.Desc}, []*model.Wallet{w4, w3, w1, w2}},
+		{"Currency", &model.WalletFilter{Sort: model.WalletSortCurrency}, []*model.Wallet{w3, w1, w4, w2}},
+		{"Amount", &model.WalletFilter{Sort: model.WalletSortAmount}, []*model.Wallet{w3, w2, w4, w1}},
+		{"Amount desc", &model.WalletFilter{Sort: model.WalletSortAmount, Order: model.Desc}, []*model.Wallet{w1, w4, w2, w3}},
+		{"Created at", &model.WalletFilter{Sort: model.WalletSortCreatedAt}, []*model.Wallet{w1, w2, w3, w4}},
+		{"Updated at desc", &model.WalletFilter{Sort: model.WalletSortUpdatedAt, Order: model.Desc}, []*model.Wallet{w4, w3, w2, w1}},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			data, err := repo.FindAll(ownerCtx, subtest.filter)
+			require.NoError(t, err)
+			assert.Equal(t, walletIDs(subtest.expect), walletIDs(data))
+		})
+	}
+
+	t.Run("Updated at follows updates", func(t *testing.T) {
+		time.Sleep(tick)
+		data, err := repo.FindByID(ownerCtx, w2.ID)
+		require.NoError(t, err)
+		require.NoError(t, repo.Update(ownerCtx, data))
+
+		listed, err := repo.FindAll(ownerCtx, &model.WalletFilter{Sort: model.WalletSortUpdatedAt, Order: model.Desc})
+		require.NoError(t, err)
+		assert.Equal(t, walletIDs([]*model.Wallet{w2, w4, w3, w1}), walletIDs(listed))
+	})
+}
+
+func testWalletPagination(t *testing.T, repo repository.Wallet) {
+	created := seedWallets(t, repo,
+		&model.Wallet{Name: "b", Currency: "KZT", Amount: money(200, kzt)},
+		&model.Wallet{Name: "a", Currency: "USD", Amount: money(100, usd)},
+		&model.Wallet{Name: "b", Currency: "EUR", Amount: money(300, eur)},
+		&model.Wallet{Name: "c", Currency: "KZT", Amount: money(100, kzt)},
+		&model.Wallet{Name: "a", Currency: "KZT", Amount: money(200, kzt)},
+	)
+	deleteWallet(t, repo, created[4].ID, time.Now())
+
+	sorts := []model.WalletSort{
+		model.WalletSortID, model.WalletSortName, model.WalletSortCurrency,
+		model.WalletSortAmount, model.WalletSortCreatedAt, model.WalletSortUpdatedAt,
+	}
+
+	for _, sort := range sorts {
+		for _, order := range []model.SortOrder{model.Asc, model.Desc} {
+			for _, deleted := range []model.DeletedState{"", model.DeletedInclude} {
+				filter := model.WalletFilter{Sort: sort, Order: order, Deleted: deleted}
+				name := string(sort) + " " + string(order)
+				if deleted != "" {
+					name += " " + string(deleted)
+				}
+				t.Run(name, func(t *testing.T) {
+					all, err := repo.FindAll(ownerCtx, &filter)
+					require.NoError(t, err)
+					expect := walletIDs(all)
+
+					for _, limit := range []uint64{1, 2, 3} {
+						// pages by cursor walk the same order as the unpaged list
+						paged := filter
+						paged.Limit = limit
+						got := []uint64{}
+						for {
+							data, err := repo.FindAll(ownerCtx, &paged)
+							require.NoError(t, err)
+							require.LessOrEqual(t, uint64(len(data)), limit)
+							if len(data) == 0 {
+								break
+							}
+							got = append(got, walletIDs(data)...)
+							paged.Cursor = paged.Next(data[len(data)-1])
+						}
+						assert.Equal(t, expect, got, "cursor, limit %d", limit)
+
+						// and so do pages by offset
+						got = []uint64{}
+						for offset := uint64(0); offset <= uint64(len(expect)); offset += limit {
+							paged := filter
+							paged.Limit, paged.Offset = limit, offset
+							data, err := repo.FindAll(ownerCtx, &paged)
+							require.NoError(t, err)
+							got = append(got, walletIDs(data)...)
+						}
+						assert.Equal(t, expect, got, "offset, limit %d", limit)
+					}
+				})
+			}
+		}
+	}
+
+	t.Run("Offset without limit", func(t *testing.T) {
+		data, err := repo.FindAll(ownerCtx, &model.WalletFilter{Filter: model.Filter{Offset: 2}})
+		require.NoError(t, err)
+		assert.Equal(t, walletIDs([]*model.Wallet{created[1], created[0]}), walletIDs(data))
+	})
+
+	t.Run("Offset past the end", func(t *testing.T) {
+		data, err := repo.FindAll(ownerCtx, &model.WalletFilter{Filter: model.Filter{Limit: 2, Offset: 10}})
+		require.NoError(t, err)
+		assert.NotNil(t, data)
+		assert.Empty(t, data)
+	})
+
+	t.Run("Count ignores pagination", func(t *testing.T) {
+		filter := &model.WalletFilter{Filter: model.Filter{Limit: 1, Offset: 1}}
+		filter.Cursor = filter.Next(created[3])
+
+		count, err := repo.CountAll(ownerCtx, filter)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(4), count)
+	})
+
+	t.Run("Cursor combined with offset", func(t *testing.T) {
+		filter := &model.WalletFilter{Filter: model.Filter{Limit: 1, Offset: 1}}
+		filter.Cursor = filter.Next(created[3])
+
+		data, err := repo.FindAll(ownerCtx, filter)
+		require.NoError(t, err)
+		assert.Equal(t, walletIDs(created[1:2]), walletIDs(data))
+	})
+
+	t.Run("Cursor of another sort", func(t *testing.T) {
+		filter := &model.WalletFilter{Sort: model.WalletSortName}
+		filter.Cursor = (&model.WalletFilter{}).Next(created[0])
+
+		_, err := repo.FindAll(ownerCtx, filter)
+		assert.ErrorIs(t, err, model.ErrInvalidCursor)
+	})
+
+	t.Run("Cursor of another order", func(t *testing.T) {
+		filter := &model.WalletFilter{Order: model.Asc}
+		filter.Cursor = (&model.WalletFilter{}).Next(created[0])
+
+		_, err := repo.FindAll(ownerCtx, filter)
+		assert.ErrorIs(t, err, model.ErrInvalidCursor)
+	})
+}
+
+func testWalletFindByID(t *testing.T, repo repository.Wallet) {
+	created := seedWallets(t, repo,
+		&model.Wallet{Name: "cash", Description: stringp("pocket"), Currency: "KZT", Amount: money(-150, kzt)},
+		&model.Wallet{Name: "savings", Currency: "KZT", Personal: true},
+	)
+
+	for _, datum := range created {
+		found, err := repo.FindByID(ownerCtx, datum.ID)
+		require.NoError(t, err)
+		assert.Equal(t, datum, found)
+	}
+
+	// deleted wallets are still found by id, so they can be restored
+	deleted := deleteWallet(t, repo, created[0].ID, time.Now())
+	found, err := repo.FindByID(ownerCtx, deleted.ID)
+	require.NoError(t, err)
+	assert.Equal(t, deleted, found)
+
+	subtests := []struct {
+		name string
+		ctx  context.Context
+		id   uint64
+	}{
+		{"Not found", ownerCtx, missingID},
+		{"Zero id", ownerCtx, 0},
+		{"Not accessible", strangerCtx, created[0].ID},
+		{"Personal", strangerCtx, created[1].ID},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			_, err := repo.FindByID(subtest.ctx, subtest.id)
+			assert.ErrorIs(t, err, repository.ErrWalletNotFound)
+		})
+	}
+}
+
+func testWalletCreate(t *testing.T, repo repository.Wallet) {
+	data := &model.Wallet{Name: "cash", Description: stringp("pocket"), Currency: "KZT", Amount: money(100, kzt), Personal: true}
+	require.NoError(t, repo.Create(ownerCtx, data))
+	assert.NotZero(t, data.ID)
+	assert.Equal(t, Owner, data.OwnerID)
+	assert.Equal(t, "cash", data.Name)
+	assert.Equal(t, stringp("pocket"), data.Description)
+	assert.Equal(t, "KZT", data.Currency)
+	assert.Equal(t, money(100, kzt), data.Amount)
+	assert.True(t, data.Personal)
+	assert.Nil(t, data.DeletedAt)
+	assert.Equal(t, uint64(1), data.Version)
+
+	// the id and the owner are assigned whatever the wallet says
+	other := &model.Wallet{ID: data.ID, OwnerID: Stranger, Name: "card", Currency: "USD"}
+	require.NoError(t, repo.Create(ownerCtx, other))
+	assert.Equal(t, Owner, other.OwnerID)
+	assert.Greater(t, other.ID, data.ID)
+	found, err := repo.FindByID(ownerCtx, data.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "cash", found.Name)
+
+	subtests := []struct {
+		name   string
+		amount model.Money
+		err    error
+	}{
+		{"Fils", money(1234, kwd), nil},
+		{"Max amount", money(model.MaxStored/100, kzt), nil},
+		{"Min amount", money(-model.MaxStored/100, kzt), nil},
+		{"Amount above range", money(model.MaxStored/100+1, kzt), model.ErrDecimalOverflow},
+		{"Amount below range", money(-model.MaxStored/100-1, kzt), model.ErrDecimalOverflow},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			data := &model.Wallet{Name: "cash", Currency: subtest.amount.Currency.Code, Amount: subtest.amount}
+			err := repo.Create(ownerCtx, data)
+			assert.ErrorIs(t, err, subtest.err)
+			if err != nil {
+				return
+			}
+
+			found, err := repo.FindByID(ownerCtx, data.ID)
+			require.NoError(t, err)
+			assert.Equal(t, subtest.amount, found.Amount)
+		})
+	}
+
+	count, err := repo.CountAll(ownerCtx, &model.WalletFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), count)
+}
+
+func testWalletUpdate(t *testing.T, repo repository.Wallet) {
+	created := seedWallets(t, repo, &model.Wallet{Name: "cash", Currency: "KZT", Amount: money(100, kzt)})[0]
+
+	data := *created
+	data.Name, data.Description, data.Currency, data.Amount, data.Personal = "wallet", stringp("pocket"), "USD", money(-100, usd), true
+	require.NoError(t, repo.Update(ownerCtx, &data))
+	assert.Equal(t, created.ID, data.ID)
+	assert.Equal(t, Owner, data.OwnerID)
+	assert.Equal(t, uint64(2), data.Version)
+	// the amount only changes through transactions and transfers, it keeps its value in the new currency
+	assert.Equal(t, money(100, usd), data.Amount)
+
+	found, err := repo.FindByID(ownerCtx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, &data, found)
+
+	// clearing the optional fields is an update too
+	data.Description = nil
+	require.NoError(t, repo.Update(ownerCtx, &data))
+	found, err = repo.FindByID(ownerCtx, created.ID)
+	require.NoError(t, err)
+	assert.Nil(t, found.Description)
+	assert.Equal(t, uint64(3), found.Version)
+
+	subtests := []struct {
+		name string
+		ctx  context.Context
+		data *model.Wallet
+		err  error
+	}{
+		{"Stale", ownerCtx, &model.Wallet{ID: created.ID, Name: "cash", Currency: "KZT", Version: 2}, repository.ErrWalletStale},
+		{"Ahead", ownerCtx, &model.Wallet{ID: created.ID, Name: "cash", Currency: "KZT", Version: 4}, repository.ErrWalletStale},
+		{"Not found", ownerCtx, &model.Wallet{ID: missingID, Name: "cash", Currency: "KZT", Version: 1}, repository.ErrWalletNotFound},
+		{"Not accessible", strangerCtx, &model.Wallet{ID: created.ID, Name: "cash", Currency: "KZT", Version: 3}, repository.ErrWalletNotFound},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			assert.ErrorIs(t, repo.Update(subtest.ctx, subtest.data), subtest.err)
+
+			// failed updates change nothing
+			unchanged, err := repo.FindByID(ownerCtx, created.ID)
+			require.NoError(t, err)
+			assert.Equal(t, found, unchanged)
+		})
+	}
+}
+
+func testWalletDeleteByID(t *testing.T, repo repository.Wallet) {
+	created := seedWallets(t, repo,
+		&model.Wallet{Name: "cash", Currency: "KZT"},
+		&model.Wallet{Name: "card", Currency: "KZT"},
+	)
+
+	_, err := repo.DeleteByID(strangerCtx, created[0].ID)
+	assert.ErrorIs(t, err, repository.ErrWalletNotFound)
+
+	deleted, err := repo.DeleteByID(ownerCtx, created[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, created[0], deleted)
+
+	_, err = repo.FindByID(ownerCtx, created[0].ID)
+	assert.ErrorIs(t, err, repository.ErrWalletNotFound)
+
+	_, err = repo.DeleteByID(ownerCtx, created[0].ID)
+	assert.ErrorIs(t, err, repository.ErrWalletNotFound)
+
+	// deleting by id removes the wallet for good, not only marks it deleted
+	data, err := repo.FindAll(ownerCtx, &model.WalletFilter{Deleted: model.DeletedInclude})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{created[1].ID}, walletIDs(data))
+}
+
+func testWalletPurge(t *testing.T, repo repository.Wallet) {
+	created := seedWallets(t, repo,
+		&model.Wallet{Name: "kept", Currency: "KZT"},
+		&model.Wallet{Name: "recently deleted", Currency: "KZT"},
+		&model.Wallet{Name: "purged", Currency: "KZT"},
+		&model.Wallet{Name: "purged too", Currency: "KZT"},
+	)
+
+	deletedAt := time.Now()
+	deleteWallet(t, repo, created[1].ID, deletedAt)
+	deleteWallet(t, repo, created[2].ID, deletedAt.Add(-time.Hour))
+	deleteWallet(t, repo, created[3].ID, deletedAt.Add(-48*time.Hour))
+
+	count, err := repo.Purge(context.Background(), deletedAt.Add(-time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), count)
+
+	data, err := repo.FindAll(ownerCtx, &model.WalletFilter{Deleted: model.DeletedInclude})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{created[1].ID, created[0].ID}, walletIDs(data))
+
+	count, err = repo.Purge(context.Background(), deletedAt.Add(-time.Minute))
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func testWalletTimestamps(t *testing.T, repo repository.Wallet) {
+	before := time.Now()
+	data := seedWallets(t, repo, &model.Wallet{Name: "cash", Currency: "KZT"})[0]
+
+	assert.WithinDuration(t, before, data.CreatedAt, time.Minute)
+	assert.True(t, data.UpdatedAt.Equal(data.CreatedAt), "updated at %s, created at %s", data.UpdatedAt, data.CreatedAt)
+
+	// times the caller sets are kept to the microsecond
+	deletedAt := time.Date(2023, 6, 1, 12, 30, 15, 123456000, time.UTC)
+	created := *data
+	time.Sleep(tick)
+	updated := deleteWallet(t, repo, data.ID, deletedAt)
+	require.NotNil(t, updated.DeletedAt)
+	assert.True(t, deletedAt.Equal(*updated.DeletedAt), "deleted at %s", updated.DeletedAt)
+
+	// the creation time stays, the update time moves
+	assert.True(t, created.CreatedAt.Equal(updated.CreatedAt))
+	assert.True(t, updated.UpdatedAt.After(created.UpdatedAt), "updated at %s, was %s", updated.UpdatedAt, created.UpdatedAt)
+
+	found, err := repo.FindByID(ownerCtx, data.ID)
+	require.NoError(t, err)
+	assert.True(t, deletedAt.Equal(*found.DeletedAt))
+	assert.True(t, updated.UpdatedAt.Equal(found.UpdatedAt))
+
+	// caller set creation and update times are ignored
+	found.CreatedAt, found.UpdatedAt, found.DeletedAt = deletedAt, deletedAt, nil
+	time.Sleep(tick)
+	require.NoError(t, repo.Update(ownerCtx, found))
+	assert.True(t, created.CreatedAt.Equal(found.CreatedAt))
+	assert.True(t, found.UpdatedAt.After(updated.UpdatedAt))
+	assert.Nil(t, found.DeletedAt)
+}
+
+func testWalletNoUser(t *testing.T, repo repository.Wallet) {
+	ctx := context.Background()
+
+	_, err := repo.CountAll(ctx, &model.WalletFilter{})
+	assert.ErrorIs(t, err, model.ErrNoUser)
+
+	_, err = repo.FindAll(ctx, &model.WalletFilter{})
+	assert.ErrorIs(t, err, model.ErrNoUser)
+
+	_, err = repo.FindByID(ctx, 1)
+	assert.ErrorIs(t, err, model.ErrNoUser)
+
+	assert.ErrorIs(t, repo.Create(ctx, &model.Wallet{Name: "cash", Currency: "KZT"}), model.ErrNoUser)
+
+	assert.ErrorIs(t, repo.Update(ctx, &model.Wallet{ID: 1, Name: "cash", Currency: "KZT", Version: 1}), model.ErrNoUser)
+
+	_, err = repo.DeleteByID(ctx, 1)
+	assert.ErrorIs(t, err, model.ErrNoUser)
+}
+
+func stringp(s string) *string { return &s }
@@ -0,0 +1,206 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/repository"
+)
+
+func NewCategory(db DB) repository.Category { return &category{db} }
+
+type category struct{ db DB }
+
+func scanCategory(row row, data *model.Category) error {
+	return row.Scan(
+		&data.ID, &data.OwnerID, &data.ParentID, &data.Name, &data.Type, &data.CreatedAt, &data.UpdatedAt, &data.ArchivedAt,
+	)
+}
+
+func (c *category) CountAll(ctx context.Context, filter *model.CategoryFilter) (count uint64, err error) {
+	owner, err := model.UserID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	query, args := dialect.CountCategories(owner, filter)
+
+	err = conn(ctx, c.db).QueryRowContext(ctx, query, args...).Scan(&count)
+
+	return
+}
+
+func (c *category) FindAll(ctx context.Context, filter *model.CategoryFilter) (data []*model.Category, err error) {
+	owner, err := model.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := dialect.FindCategories(owner, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn(ctx, c.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data = []*model.Category{}
+	for rows.Next() {
+		elem := &model.Category{}
+
+		if err = scanCategory(rows, elem); err != nil {
+			return nil, err
+		}
+
+		data = append(data, elem)
+	}
+
+	return data, rows.Err()
+}
+
+func (c *category) FindByID(ctx context.Context, id uint64) (data *model.Category, err error) {
+	owner, err := model.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args := dialect.FindCategory(owner, id)
+
+	data = &model.Category{}
+	if err = categoryError(scanCategory(conn(ctx, c.db).QueryRowContext(ctx, query, args...), data)); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (c *category) Create(ctx context.Context, data *model.Category) error {
+	owner, err := model.UserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query, args := dialect.CreateCategory(owner, data)
+
+	return categoryError(scanCategory(conn(ctx, c.db).QueryRowContext(ctx, query, args...), data))
+}
+
+func (c *category) Update(ctx context.Context, data *model.Category) error {
+	owner, err := model.UserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query, args := dialect.UpdateCategory(owner, data)
+
+	return categoryError(scanCategory(conn(ctx, c.db).QueryRowContext(ctx, query, args...), data))
+}
+
+func (c *category) Archive(ctx context.Context, id uint64, at time.Time) (archived *model.Category, err error) {
+	owner, err := model.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args := dialect.ArchiveCategory(owner, id, at)
+
+	archived = &model.Category{}
+	if err = categoryError(scanCategory(conn(ctx, c.db).QueryRowContext(ctx, query, args...), archived)); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (c *category) Used(ctx context.Context, id uint64) (used bool, err error) {
+	query, args := dialect.CategoryUsed(id)
+
+	err = conn(ctx, c.db).QueryRowContext(ctx, query, args...).Scan(&used)
+
+	return
+}
+
+func (c *category) DeleteByID(ctx context.Context, id uint64, reassignTo *uint64) (deleted *model.Category, err error) {
+	owner, err := model.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = inTx(ctx, c.db, func(tx *sql.Tx) error {
+		if err := checkCategory(ctx, tx, reassignTo); err != nil {
+			return err
+		}
+
+		if reassignTo != nil {
+			query, args := dialect.ReassignTransactions(id, *reassignTo)
+
+			if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+				return categoryError(err)
+			}
+		}
+
+		// children are moved to the parent of the category being deleted
+		query, args := dialect.MoveChildren(owner, id)
+
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return categoryError(err)
+		}
+
+		query, args = dialect.DeleteCategory(owner, id)
+
+		deleted = &model.Category{}
+		err := scanCategory(tx.QueryRowContext(ctx, query, args...), deleted)
+		// children are moved already, so only transactions may still refer to the category
+		if foreignKeyFailed(err) {
+			return repository.ErrCategoryInUse
+		}
+		return categoryError(err)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// checkCategory makes sure the category, if any, is owned by the acting user
+func checkCategory(ctx context.Context, q querier, id *uint64) error {
+	if id == nil {
+		return nil
+	}
+
+	owner, err := model.UserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query, args := dialect.CategoryExists(owner, *id)
+
+	var found int
+	return categoryError(q.QueryRowContext(ctx, query, args...).Scan(&found))
+}
+
+func categoryError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return repository.ErrCategoryNotFound
+	}
+
+	if foreignKeyFailed(err) {
+		return repository.ErrCategoryNotFound
+	}
+	if violates(err) {
+		return repository.ErrCategoryConflict
+	}
+
+	return err
+}
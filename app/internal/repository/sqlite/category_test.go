@@ -0,0 +1,168 @@
+package sqlite_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/mustan989/wallet/app/internal/repository/sqlite"
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/repository"
+)
+
+func TestCategory_FindAll(t *testing.T) {
+	repo := NewCategory(open(t))
+	for _, data := range []*model.Category{
+		{Name: "food", Type: model.Expense},
+		{Name: "groceries", Type: model.Expense, ParentID: uint64p(1)},
+		{Name: "salary", Type: model.Income},
+		{Name: "cafe", Type: model.Expense, ParentID: uint64p(1)},
+	} {
+		require.NoError(t, repo.Create(userCtx, data))
+	}
+	require.NoError(t, repo.Create(strangerCtx, &model.Category{Name: "food", Type: model.Expense}))
+
+	archived := true
+
+	subtests := []struct {
+		name   string
+		filter *model.CategoryFilter
+		expect []uint64
+	}{
+		{"Default", &model.CategoryFilter{}, []uint64{4, 1, 2, 3}},
+		{"Root", &model.CategoryFilter{ParentID: uint64p(0)}, []uint64{1, 3}},
+		{"Parent", &model.CategoryFilter{ParentID: uint64p(1)}, []uint64{4, 2}},
+		{"Name like", &model.CategoryFilter{NameLike: "ar"}, []uint64{3}},
+		{"Type", &model.CategoryFilter{Type: model.Income}, []uint64{3}},
+		{"Archived", &model.CategoryFilter{Archived: &archived}, nil},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			data, err := repo.FindAll(userCtx, subtest.filter)
+			require.NoError(t, err)
+			var got []uint64
+			for _, datum := range data {
+				got = append(got, datum.ID)
+			}
+			assert.Equal(t, subtest.expect, got)
+
+			count, err := repo.CountAll(userCtx, subtest.filter)
+			require.NoError(t, err)
+			assert.Equal(t, uint64(len(subtest.expect)), count)
+		})
+	}
+
+	t.Run("Cursor", func(t *testing.T) {
+		filter := &model.CategoryFilter{Filter: model.Filter{Limit: 3}}
+		data, err := repo.FindAll(userCtx, filter)
+		require.NoError(t, err)
+		require.Len(t, data, 3)
+
+		filter.Cursor = filter.Next(data[2])
+		data, err = repo.FindAll(userCtx, filter)
+		require.NoError(t, err)
+		require.Len(t, data, 1)
+		assert.Equal(t, uint64(3), data[0].ID)
+	})
+}
+
+func TestCategory_Create(t *testing.T) {
+	repo := NewCategory(open(t))
+
+	archivedAt := time.Date(2023, 6, 1, 12, 30, 0, 123456000, time.UTC)
+	data := &model.Category{Name: "food", Type: model.Expense, ArchivedAt: &archivedAt}
+	require.NoError(t, repo.Create(userCtx, data))
+	assert.Equal(t, owner, data.OwnerID)
+	assert.True(t, archivedAt.Equal(*data.ArchivedAt))
+
+	found, err := repo.FindByID(userCtx, data.ID)
+	require.NoError(t, err)
+	assert.Equal(t, data, found)
+
+	_, err = repo.FindByID(strangerCtx, data.ID)
+	assert.ErrorIs(t, err, repository.ErrCategoryNotFound)
+
+	err = repo.Create(userCtx, &model.Category{Name: "food", Type: model.Expense, ParentID: uint64p(10)})
+	assert.ErrorIs(t, err, repository.ErrCategoryNotFound)
+}
+
+func TestCategory_Archive(t *testing.T) {
+	repo := NewCategory(open(t))
+
+	data := &model.Category{Name: "food", Type: model.Expense}
+	require.NoError(t, repo.Create(userCtx, data))
+
+	archivedAt := time.Date(2023, 6, 1, 12, 30, 0, 123456000, time.UTC)
+	archived, err := repo.Archive(userCtx, data.ID, archivedAt)
+	require.NoError(t, err)
+	assert.True(t, archivedAt.Equal(*archived.ArchivedAt))
+
+	// updates keep the archive time
+	archived.Name, archived.ArchivedAt = "meals", nil
+	require.NoError(t, repo.Update(userCtx, archived))
+	assert.Equal(t, "meals", archived.Name)
+	assert.True(t, archivedAt.Equal(*archived.ArchivedAt))
+
+	_, err = repo.Archive(strangerCtx, data.ID, archivedAt)
+	assert.ErrorIs(t, err, repository.ErrCategoryNotFound)
+}
+
+func TestCategory_Used(t *testing.T) {
+	db := open(t)
+	repo, transactions := NewCategory(db), NewTransaction(db)
+	wallet := seed(t, NewWallet(db), &model.Wallet{Name: "cash", Currency: "KZT"})[0]
+	for _, data := range []*model.Category{
+		{Name: "food", Type: model.Expense},
+		{Name: "groceries", Type: model.Expense, ParentID: uint64p(1)},
+		{Name: "other", Type: model.Expense},
+	} {
+		require.NoError(t, repo.Create(userCtx, data))
+	}
+	require.NoError(t, transactions.Create(userCtx, &model.Transaction{WalletID: wallet.ID, CategoryID: uint64p(2), Type: model.Expense, Amount: money(100, kzt), Date: time.Now()}))
+
+	for id, expect := range map[uint64]bool{1: true, 2: true, 3: false} {
+		used, err := repo.Used(userCtx, id)
+		require.NoError(t, err)
+		assert.Equal(t, expect, used, id)
+	}
+}
+
+func TestCategory_DeleteByID(t *testing.T) {
+	db := open(t)
+	repo, transactions := NewCategory(db), NewTransaction(db)
+	wallet := seed(t, NewWallet(db), &model.Wallet{Name: "cash", Currency: "KZT"})[0]
+	for _, data := range []*model.Category{
+		{Name: "food", Type: model.Expense},
+		{Name: "groceries", Type: model.Expense, ParentID: uint64p(1)},
+		{Name: "other", Type: model.Expense},
+	} {
+		require.NoError(t, repo.Create(userCtx, data))
+	}
+	transaction := &model.Transaction{WalletID: wallet.ID, CategoryID: uint64p(1), Type: model.Expense, Amount: money(100, kzt), Date: time.Now()}
+	require.NoError(t, transactions.Create(userCtx, transaction))
+
+	_, err := repo.DeleteByID(userCtx, 1, nil)
+	assert.ErrorIs(t, err, repository.ErrCategoryInUse)
+
+	_, err = repo.DeleteByID(userCtx, 1, uint64p(10))
+	assert.ErrorIs(t, err, repository.ErrCategoryNotFound)
+
+	deleted, err := repo.DeleteByID(userCtx, 1, uint64p(3))
+	require.NoError(t, err)
+	assert.Equal(t, "food", deleted.Name)
+
+	// the transaction is reassigned and the child moved to the root
+	transaction, err = transactions.FindByID(userCtx, transaction.ID)
+	require.NoError(t, err)
+	assert.Equal(t, uint64p(3), transaction.CategoryID)
+
+	child, err := repo.FindByID(userCtx, 2)
+	require.NoError(t, err)
+	assert.Nil(t, child.ParentID)
+
+	_, err = repo.DeleteByID(userCtx, 1, nil)
+	assert.ErrorIs(t, err, repository.ErrCategoryNotFound)
+}
@@ -0,0 +1,73 @@
+package sqlite_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mustan989/wallet/app/config"
+	. "github.com/mustan989/wallet/app/internal/repository/sqlite"
+	migrations "github.com/mustan989/wallet/migrations/sqlite"
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/pkg/migrate"
+	"github.com/mustan989/wallet/pkg/sqlite"
+	"github.com/mustan989/wallet/repository"
+)
+
+func stringp(s string) *string { return &s }
+
+func uint64p(u uint64) *uint64 { return &u }
+
+var (
+	kzt = model.Currency{Code: "KZT", Exponent: 2}
+	usd = model.Currency{Code: "USD", Exponent: 2}
+)
+
+func money(amount int64, currency model.Currency) model.Money {
+	return model.Money{Amount: amount, Currency: currency}
+}
+
+const (
+	owner    uint64 = 1
+	stranger uint64 = 2
+)
+
+var (
+	userCtx     = model.WithUserID(context.Background(), owner)
+	strangerCtx = model.WithUserID(context.Background(), stranger)
+)
+
+// open returns a migrated in-memory database with the owner and the stranger registered
+func open(t *testing.T) *sql.DB {
+	db, err := sqlite.Connect(&config.Database{Name: ":memory:"})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	loaded, err := migrate.Load(migrations.FS)
+	require.NoError(t, err)
+	require.NoError(t, migrate.NewSQLite(db, loaded).Up(context.Background()))
+
+	users := NewUser(db)
+	for _, email := range []string{"owner@example.com", "stranger@example.com"} {
+		require.NoError(t, users.Create(context.Background(), &model.User{Name: email, Email: email, PasswordHash: []byte("hash")}))
+	}
+
+	return db
+}
+
+// seed creates the wallets in order and returns them as stored
+func seed(t *testing.T, repo repository.Wallet, data ...*model.Wallet) []*model.Wallet {
+	for _, datum := range data {
+		require.NoError(t, repo.Create(userCtx, datum))
+	}
+	return data
+}
+
+func ids(data []*model.Wallet) (ids []uint64) {
+	for _, datum := range data {
+		ids = append(ids, datum.ID)
+	}
+	return
+}
@@ -0,0 +1,26 @@
+package sqlite
+
+import (
+	"errors"
+	"strings"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// violates reports whether err violates any constraint
+func violates(err error) bool {
+	var sqliteErr *sqlite.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.Code()&0xff == sqlite3.SQLITE_CONSTRAINT
+}
+
+// foreignKeyFailed reports whether err violates a foreign key. The extended code is not reliable,
+// SQLite reports foreign keys checked at the end of a RETURNING statement with a different one
+func foreignKeyFailed(err error) bool {
+	return violates(err) && strings.Contains(err.Error(), "FOREIGN KEY constraint failed")
+}
+
+// checkFailed reports whether err violates the named check, SQLite reports the name in the message only
+func checkFailed(err error, name string) bool {
+	return violates(err) && strings.Contains(err.Error(), "CHECK constraint failed: "+name)
+}
@@ -0,0 +1,118 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/repository"
+)
+
+func NewMember(db DB) repository.Member { return &member{db} }
+
+type member struct{ db DB }
+
+func scanMember(row row, data *model.Member) error {
+	return row.Scan(&data.WalletID, &data.UserID, &data.Role, &data.CreatedAt, &data.UpdatedAt)
+}
+
+func (m *member) FindAll(ctx context.Context, walletID uint64) (data []*model.Member, err error) {
+	userID, err := model.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args := dialect.FindMembers(userID, walletID)
+
+	rows, err := conn(ctx, m.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data = []*model.Member{}
+	for rows.Next() {
+		elem := &model.Member{}
+
+		if err = scanMember(rows, elem); err != nil {
+			return nil, err
+		}
+
+		data = append(data, elem)
+	}
+
+	return data, rows.Err()
+}
+
+func (m *member) FindRole(ctx context.Context, walletID uint64) (role model.Role, err error) {
+	userID, err := model.UserID(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	query, args := dialect.FindRole(userID, walletID)
+
+	err = conn(ctx, m.db).QueryRowContext(ctx, query, args...).Scan(&role)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", repository.ErrWalletNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return
+}
+
+func (m *member) Save(ctx context.Context, data *model.Member) error {
+	return inTx(ctx, m.db, func(tx *sql.Tx) error {
+		// SQLite does not name the failed foreign key, so the wallet is looked up first
+		query, args := dialect.WalletExists(data.WalletID)
+
+		var found int
+		err := tx.QueryRowContext(ctx, query, args...).Scan(&found)
+		if errors.Is(err, sql.ErrNoRows) {
+			return repository.ErrWalletNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		query, args = dialect.SaveMember(data)
+
+		return memberError(scanMember(tx.QueryRowContext(ctx, query, args...), data))
+	})
+}
+
+func (m *member) DeleteByID(ctx context.Context, walletID, userID uint64) (deleted *model.Member, err error) {
+	actingID, err := model.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args := dialect.DeleteMember(actingID, walletID, userID)
+
+	deleted = &model.Member{}
+	if err = memberError(scanMember(conn(ctx, m.db).QueryRowContext(ctx, query, args...), deleted)); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func memberError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return repository.ErrMemberNotFound
+	}
+
+	// the wallet is checked before saving, so only the user may be missing
+	if foreignKeyFailed(err) {
+		return repository.ErrUserNotFound
+	}
+
+	return err
+}
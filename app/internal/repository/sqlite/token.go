@@ -0,0 +1,48 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/mustan989/wallet/repository"
+)
+
+func NewToken(db DB) repository.Token { return &token{db} }
+
+type token struct{ db DB }
+
+func (t *token) Revoke(ctx context.Context, id string, expiresAt time.Time) (revoked bool, err error) {
+	// expired tokens are rejected anyway, so there is no need to keep them
+	query, args := dialect.DeleteExpiredTokens(time.Now())
+
+	if _, err = conn(ctx, t.db).ExecContext(ctx, query, args...); err != nil {
+		return false, err
+	}
+
+	query, args = dialect.RevokeToken(id, expiresAt)
+
+	result, err := conn(ctx, t.db).ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	return affected == 1, err
+}
+
+func (t *token) IsRevoked(ctx context.Context, id string) (revoked bool, err error) {
+	query, args := dialect.TokenRevoked(id)
+
+	var found int
+	err = conn(ctx, t.db).QueryRowContext(ctx, query, args...).Scan(&found)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
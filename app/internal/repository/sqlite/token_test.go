@@ -0,0 +1,34 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/mustan989/wallet/app/internal/repository/sqlite"
+)
+
+func TestToken(t *testing.T) {
+	repo := NewToken(open(t))
+	ctx := context.Background()
+
+	revoke := func(id string, expiresAt time.Time) bool {
+		revoked, err := repo.Revoke(ctx, id, expiresAt)
+		require.NoError(t, err)
+		return revoked
+	}
+
+	assert.True(t, revoke("expired", time.Now().Add(-time.Minute)))
+	assert.True(t, revoke("active", time.Now().Add(time.Hour)))
+	// revoking twice reports the token as already revoked and drops the expired token
+	assert.False(t, revoke("active", time.Now().Add(time.Hour)))
+
+	for id, expect := range map[string]bool{"active": true, "expired": false, "unknown": false} {
+		revoked, err := repo.IsRevoked(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, expect, revoked, id)
+	}
+}
@@ -0,0 +1,181 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/repository"
+)
+
+func NewTransaction(db DB) repository.Transaction { return &transaction{db} }
+
+type transaction struct{ db DB }
+
+// scanTransaction scans a transaction, binding its amount to the currency of its wallet
+func scanTransaction(row row, data *model.Transaction) error {
+	var currency string
+	if err := row.Scan(
+		&data.ID, &data.WalletID, &data.CategoryID, &data.Type, (*money)(&data.Amount), &data.Description, &data.Date, &data.TransferID, &data.CreatedAt, &data.UpdatedAt, &currency,
+	); err != nil {
+		return err
+	}
+	return model.Bind(currency, &data.Amount)
+}
+
+func (t *transaction) CountAll(ctx context.Context, filter *model.TransactionFilter) (count uint64, err error) {
+	userID, err := model.UserID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	query, args := dialect.CountTransactions(userID, filter)
+
+	err = conn(ctx, t.db).QueryRowContext(ctx, query, args...).Scan(&count)
+
+	return
+}
+
+func (t *transaction) FindAll(ctx context.Context, filter *model.TransactionFilter) (data []*model.Transaction, err error) {
+	userID, err := model.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := dialect.FindTransactions(userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn(ctx, t.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data = []*model.Transaction{}
+	for rows.Next() {
+		elem := &model.Transaction{}
+
+		if err = scanTransaction(rows, elem); err != nil {
+			return nil, err
+		}
+
+		data = append(data, elem)
+	}
+
+	return data, rows.Err()
+}
+
+func (t *transaction) FindByID(ctx context.Context, id uint64) (data *model.Transaction, err error) {
+	return t.findByID(ctx, conn(ctx, t.db), id)
+}
+
+// findByID needs no row lock, transactions of the single connection do not interleave
+func (t *transaction) findByID(ctx context.Context, q querier, id uint64) (data *model.Transaction, err error) {
+	userID, err := model.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args := dialect.FindTransaction(userID, id, false)
+
+	data = &model.Transaction{}
+	err = scanTransaction(q.QueryRowContext(ctx, query, args...), data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrTransactionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (t *transaction) Create(ctx context.Context, data *model.Transaction) error {
+	return inTx(ctx, t.db, func(tx *sql.Tx) error {
+		if err := addWalletAmount(ctx, tx, data.WalletID, data.Delta()); err != nil {
+			return err
+		}
+		if err := checkCategory(ctx, tx, data.CategoryID); err != nil {
+			return err
+		}
+
+		query, args := dialect.CreateTransaction(data)
+
+		return transactionError(scanTransaction(tx.QueryRowContext(ctx, query, args...), data))
+	})
+}
+
+func (t *transaction) Update(ctx context.Context, data *model.Transaction) error {
+	return inTx(ctx, t.db, func(tx *sql.Tx) error {
+		old, err := t.findByID(ctx, tx, data.ID)
+		if err != nil {
+			return err
+		}
+		if old.TransferID != nil {
+			return repository.ErrTransactionLinked
+		}
+
+		if err = addWalletAmount(ctx, tx, old.WalletID, old.Delta().Neg()); err != nil {
+			return err
+		}
+		if err = addWalletAmount(ctx, tx, data.WalletID, data.Delta()); err != nil {
+			return err
+		}
+		if err = checkCategory(ctx, tx, data.CategoryID); err != nil {
+			return err
+		}
+
+		query, args := dialect.UpdateTransaction(data)
+
+		return transactionError(scanTransaction(tx.QueryRowContext(ctx, query, args...), data))
+	})
+}
+
+func (t *transaction) DeleteByID(ctx context.Context, id uint64) (deleted *model.Transaction, err error) {
+	err = inTx(ctx, t.db, func(tx *sql.Tx) error {
+		old, err := t.findByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if old.TransferID != nil {
+			return repository.ErrTransactionLinked
+		}
+
+		query, args := dialect.DeleteTransaction(id)
+
+		deleted = &model.Transaction{}
+		if err = transactionError(scanTransaction(tx.QueryRowContext(ctx, query, args...), deleted)); err != nil {
+			return err
+		}
+
+		return addWalletAmount(ctx, tx, deleted.WalletID, deleted.Delta().Neg())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func transactionError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return repository.ErrTransactionNotFound
+	}
+
+	// the wallet is checked by addWalletAmount, so only the category may be missing
+	if foreignKeyFailed(err) {
+		return repository.ErrCategoryNotFound
+	}
+	if violates(err) {
+		return repository.ErrTransactionConflict
+	}
+
+	return err
+}
@@ -0,0 +1,154 @@
+package sqlite_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/mustan989/wallet/app/internal/repository/sqlite"
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/repository"
+)
+
+// amount returns the amount of the wallet as stored
+func amount(t *testing.T, repo repository.Wallet, id uint64) model.Money {
+	data, err := repo.FindByID(userCtx, id)
+	require.NoError(t, err)
+	return data.Amount
+}
+
+func TestTransaction_FindAll(t *testing.T) {
+	db := open(t)
+	repo := NewTransaction(db)
+	wallets := seed(t, NewWallet(db),
+		&model.Wallet{Name: "cash", Currency: "KZT"},
+		&model.Wallet{Name: "card", Currency: "KZT"},
+	)
+
+	day := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	for _, data := range []*model.Transaction{
+		{WalletID: wallets[0].ID, Type: model.Income, Amount: money(1000, kzt), Description: stringp("salary"), Date: day},
+		{WalletID: wallets[0].ID, Type: model.Expense, Amount: money(100, kzt), Date: day.Add(48 * time.Hour)},
+		{WalletID: wallets[1].ID, Type: model.Expense, Amount: money(200, kzt), Date: day.Add(24 * time.Hour)},
+		{WalletID: wallets[1].ID, Type: model.Expense, Amount: money(300, kzt), Date: day.Add(24 * time.Hour)},
+	} {
+		require.NoError(t, repo.Create(userCtx, data))
+	}
+
+	from, to := day.Add(24*time.Hour), day.Add(48*time.Hour)
+
+	subtests := []struct {
+		name   string
+		filter *model.TransactionFilter
+		expect []uint64
+	}{
+		{"Default", &model.TransactionFilter{}, []uint64{2, 4, 3, 1}},
+		{"Wallet", &model.TransactionFilter{WalletID: &wallets[1].ID}, []uint64{4, 3}},
+		{"Type", &model.TransactionFilter{Type: model.Income}, []uint64{1}},
+		{"Description like", &model.TransactionFilter{DescriptionLike: "sal"}, []uint64{1}},
+		{"Date range", &model.TransactionFilter{DateFrom: &from, DateTo: &to}, []uint64{4, 3}},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			data, err := repo.FindAll(userCtx, subtest.filter)
+			require.NoError(t, err)
+			var got []uint64
+			for _, datum := range data {
+				got = append(got, datum.ID)
+			}
+			assert.Equal(t, subtest.expect, got)
+
+			count, err := repo.CountAll(userCtx, subtest.filter)
+			require.NoError(t, err)
+			assert.Equal(t, uint64(len(subtest.expect)), count)
+		})
+	}
+
+	t.Run("Cursor", func(t *testing.T) {
+		filter := &model.TransactionFilter{Filter: model.Filter{Limit: 1}}
+
+		var got []uint64
+		for {
+			data, err := repo.FindAll(userCtx, filter)
+			require.NoError(t, err)
+			if len(data) == 0 {
+				break
+			}
+			got = append(got, data[0].ID)
+			filter.Cursor = filter.Next(data[0])
+		}
+		assert.Equal(t, []uint64{2, 4, 3, 1}, got)
+	})
+
+	t.Run("Not accessible", func(t *testing.T) {
+		data, err := repo.FindAll(strangerCtx, &model.TransactionFilter{})
+		require.NoError(t, err)
+		assert.Empty(t, data)
+	})
+}
+
+func TestTransaction_Create(t *testing.T) {
+	db := open(t)
+	repo, wallets := NewTransaction(db), NewWallet(db)
+	wallet := seed(t, wallets, &model.Wallet{Name: "cash", Currency: "KZT", Amount: money(1000, kzt)})[0]
+
+	date := time.Date(2023, 6, 1, 12, 30, 0, 123456000, time.UTC)
+	data := &model.Transaction{WalletID: wallet.ID, Type: model.Expense, Amount: money(250, kzt), Description: stringp("lunch"), Date: date}
+	require.NoError(t, repo.Create(userCtx, data))
+	assert.True(t, date.Equal(data.Date))
+	assert.Equal(t, money(750, kzt), amount(t, wallets, wallet.ID))
+
+	found, err := repo.FindByID(userCtx, data.ID)
+	require.NoError(t, err)
+	assert.Equal(t, data, found)
+
+	subtests := []struct {
+		name string
+		data *model.Transaction
+		err  error
+	}{
+		{"Wallet not found", &model.Transaction{WalletID: 10, Type: model.Income, Amount: money(1, kzt), Date: date}, repository.ErrWalletNotFound},
+		{"Category not found", &model.Transaction{WalletID: wallet.ID, CategoryID: uint64p(10), Type: model.Income, Amount: money(1, kzt), Date: date}, repository.ErrCategoryNotFound},
+		{"Amount out of range", &model.Transaction{WalletID: wallet.ID, Type: model.Income, Amount: money(model.MaxStored/100, kzt), Date: date}, model.ErrDecimalOverflow},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			assert.ErrorIs(t, repo.Create(userCtx, subtest.data), subtest.err)
+			// failed transactions leave the wallet as it was
+			assert.Equal(t, money(750, kzt), amount(t, wallets, wallet.ID))
+		})
+	}
+}
+
+func TestTransaction_UpdateAndDelete(t *testing.T) {
+	db := open(t)
+	repo, wallets := NewTransaction(db), NewWallet(db)
+	created := seed(t, wallets,
+		&model.Wallet{Name: "cash", Currency: "KZT", Amount: money(1000, kzt)},
+		&model.Wallet{Name: "card", Currency: "KZT", Amount: money(1000, kzt)},
+	)
+	cash, card := created[0].ID, created[1].ID
+
+	data := &model.Transaction{WalletID: cash, Type: model.Expense, Amount: money(100, kzt), Date: time.Now()}
+	require.NoError(t, repo.Create(userCtx, data))
+
+	data.WalletID, data.Type, data.Amount = card, model.Income, money(300, kzt)
+	require.NoError(t, repo.Update(userCtx, data))
+	assert.Equal(t, money(1000, kzt), amount(t, wallets, cash))
+	assert.Equal(t, money(1300, kzt), amount(t, wallets, card))
+
+	err := repo.Update(userCtx, &model.Transaction{ID: 10, WalletID: cash, Type: model.Income, Amount: money(1, kzt), Date: time.Now()})
+	assert.ErrorIs(t, err, repository.ErrTransactionNotFound)
+
+	deleted, err := repo.DeleteByID(userCtx, data.ID)
+	require.NoError(t, err)
+	assert.Equal(t, data.ID, deleted.ID)
+	assert.Equal(t, money(1000, kzt), amount(t, wallets, card))
+
+	_, err = repo.DeleteByID(userCtx, data.ID)
+	assert.ErrorIs(t, err, repository.ErrTransactionNotFound)
+}
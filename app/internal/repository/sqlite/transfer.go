@@ -0,0 +1,246 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/repository"
+)
+
+func NewTransfer(db DB) repository.Transfer { return &transfer{db} }
+
+type transfer struct{ db DB }
+
+// scanTransfer scans a transfer joined with its commission transaction
+func scanTransfer(row row, data *model.Transfer) error {
+	var from, to string
+	if err := row.Scan(
+		&data.ID, &data.FromWalletID, &data.ToWalletID, (*money)(&data.Amount), (*money)(&data.ReceivedAmount), (*rate)(&data.Rate), (*money)(&data.Commission), &data.CommissionID, &data.Description, &data.Date, &data.CreatedAt, &data.UpdatedAt, &from, &to,
+	); err != nil {
+		return err
+	}
+	return bindTransfer(data, from, to)
+}
+
+// scanTransferReturning scans a transfer returned without its commission
+func scanTransferReturning(row row, data *model.Transfer) error {
+	var from, to string
+	if err := row.Scan(
+		&data.ID, &data.FromWalletID, &data.ToWalletID, (*money)(&data.Amount), (*money)(&data.ReceivedAmount), (*rate)(&data.Rate), &data.Description, &data.Date, &data.CreatedAt, &data.UpdatedAt, &from, &to,
+	); err != nil {
+		return err
+	}
+	return bindTransfer(data, from, to)
+}
+
+// bindTransfer binds the amount and the commission to the currency of the source wallet
+// and the received amount to the currency of the destination
+func bindTransfer(data *model.Transfer, from, to string) error {
+	if err := model.Bind(from, &data.Amount, &data.Commission); err != nil {
+		return err
+	}
+	return model.Bind(to, &data.ReceivedAmount)
+}
+
+func (t *transfer) CountAll(ctx context.Context, filter *model.TransferFilter) (count uint64, err error) {
+	userID, err := model.UserID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	query, args := dialect.CountTransfers(userID, filter)
+
+	err = conn(ctx, t.db).QueryRowContext(ctx, query, args...).Scan(&count)
+
+	return
+}
+
+func (t *transfer) FindAll(ctx context.Context, filter *model.TransferFilter) (data []*model.Transfer, err error) {
+	userID, err := model.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := dialect.FindTransfers(userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn(ctx, t.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data = []*model.Transfer{}
+	for rows.Next() {
+		elem := &model.Transfer{}
+
+		if err = scanTransfer(rows, elem); err != nil {
+			return nil, err
+		}
+
+		data = append(data, elem)
+	}
+
+	return data, rows.Err()
+}
+
+func (t *transfer) FindByID(ctx context.Context, id uint64) (data *model.Transfer, err error) {
+	return t.findByID(ctx, conn(ctx, t.db), id)
+}
+
+// findByID needs no row lock, transactions of the single connection do not interleave
+func (t *transfer) findByID(ctx context.Context, q querier, id uint64) (data *model.Transfer, err error) {
+	userID, err := model.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args := dialect.FindTransfer(userID, id, false)
+
+	data = &model.Transfer{}
+	err = scanTransfer(q.QueryRowContext(ctx, query, args...), data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrTransferNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (t *transfer) Create(ctx context.Context, data *model.Transfer) error {
+	return inTx(ctx, t.db, func(tx *sql.Tx) error {
+		if err := t.move(ctx, tx, data, false); err != nil {
+			return err
+		}
+
+		query, args := dialect.CreateTransfer(data)
+
+		if err := transferError(scanTransferReturning(tx.QueryRowContext(ctx, query, args...), data)); err != nil {
+			return err
+		}
+
+		return t.createCommission(ctx, tx, data)
+	})
+}
+
+func (t *transfer) Update(ctx context.Context, data *model.Transfer) error {
+	return inTx(ctx, t.db, func(tx *sql.Tx) error {
+		old, err := t.findByID(ctx, tx, data.ID)
+		if err != nil {
+			return err
+		}
+
+		if err = t.move(ctx, tx, old, true); err != nil {
+			return err
+		}
+		if err = t.deleteCommission(ctx, tx, old); err != nil {
+			return err
+		}
+		if err = t.move(ctx, tx, data, false); err != nil {
+			return err
+		}
+
+		query, args := dialect.UpdateTransfer(data)
+
+		if err = transferError(scanTransferReturning(tx.QueryRowContext(ctx, query, args...), data)); err != nil {
+			return err
+		}
+
+		return t.createCommission(ctx, tx, data)
+	})
+}
+
+func (t *transfer) DeleteByID(ctx context.Context, id uint64) (deleted *model.Transfer, err error) {
+	err = inTx(ctx, t.db, func(tx *sql.Tx) error {
+		deleted, err = t.findByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		if err = t.move(ctx, tx, deleted, true); err != nil {
+			return err
+		}
+		if err = t.deleteCommission(ctx, tx, deleted); err != nil {
+			return err
+		}
+
+		query, args := dialect.DeleteTransfer(id)
+
+		_, err = tx.ExecContext(ctx, query, args...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// move applies the balance changes of the transfer to its wallets, or reverts them
+func (t *transfer) move(ctx context.Context, q querier, data *model.Transfer, revert bool) error {
+	spent, err := data.Amount.Add(data.Commission)
+	if err != nil {
+		return err
+	}
+	debit, credit := spent.Neg(), data.ReceivedAmount
+	if revert {
+		debit, credit = spent, credit.Neg()
+	}
+
+	if err = addWalletAmount(ctx, q, data.FromWalletID, debit); err != nil {
+		return err
+	}
+	return addWalletAmount(ctx, q, data.ToWalletID, credit)
+}
+
+// createCommission records the commission of the transfer as an expense of the source wallet.
+// Wallet amounts are expected to be already changed by move
+func (t *transfer) createCommission(ctx context.Context, q querier, data *model.Transfer) error {
+	data.CommissionID = nil
+	if data.Commission.Sign() == 0 {
+		return nil
+	}
+
+	query, args := dialect.CreateCommission(data)
+
+	var id uint64
+	if err := q.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		return transferError(err)
+	}
+	data.CommissionID = &id
+
+	return nil
+}
+
+func (t *transfer) deleteCommission(ctx context.Context, q querier, data *model.Transfer) error {
+	if data.CommissionID == nil {
+		return nil
+	}
+
+	query, args := dialect.DeleteCommission(*data.CommissionID)
+
+	_, err := q.ExecContext(ctx, query, args...)
+	return err
+}
+
+func transferError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return repository.ErrTransferNotFound
+	}
+
+	if violates(err) {
+		return repository.ErrTransferConflict
+	}
+
+	return err
+}
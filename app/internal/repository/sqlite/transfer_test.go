@@ -0,0 +1,110 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/mustan989/wallet/app/internal/repository/sqlite"
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/repository"
+)
+
+func TestTransfer(t *testing.T) {
+	db := open(t)
+	repo, wallets, transactions := NewTransfer(db), NewWallet(db), NewTransaction(db)
+	created := seed(t, wallets,
+		&model.Wallet{Name: "cash", Currency: "KZT", Amount: money(100000, kzt)},
+		&model.Wallet{Name: "card", Currency: "USD", Amount: money(1, usd)},
+	)
+	cash, card := created[0].ID, created[1].ID
+
+	data := &model.Transfer{
+		FromWalletID: cash, ToWalletID: card, Amount: money(45000, kzt), ReceivedAmount: money(100, usd), Rate: model.Rate(222222),
+		Commission: money(500, kzt), Description: stringp("exchange"), Date: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, repo.Create(userCtx, data))
+	require.NotNil(t, data.CommissionID)
+	assert.Equal(t, money(54500, kzt), amount(t, wallets, cash))
+	assert.Equal(t, money(101, usd), amount(t, wallets, card))
+
+	found, err := repo.FindByID(userCtx, data.ID)
+	require.NoError(t, err)
+	assert.Equal(t, data, found)
+
+	// the commission is managed by the transfer
+	_, err = transactions.DeleteByID(userCtx, *data.CommissionID)
+	assert.ErrorIs(t, err, repository.ErrTransactionLinked)
+
+	data.Amount, data.ReceivedAmount, data.Commission = money(90000, kzt), money(200, usd), money(0, kzt)
+	require.NoError(t, repo.Update(userCtx, data))
+	assert.Nil(t, data.CommissionID)
+	assert.Equal(t, money(10000, kzt), amount(t, wallets, cash))
+	assert.Equal(t, money(201, usd), amount(t, wallets, card))
+
+	list, err := repo.FindAll(userCtx, &model.TransferFilter{WalletID: &card})
+	require.NoError(t, err)
+	assert.Equal(t, []*model.Transfer{data}, list)
+
+	count, err := transactions.CountAll(userCtx, &model.TransactionFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), count)
+
+	// the failed update leaves both wallets as they were
+	overflow := *data
+	overflow.ReceivedAmount = money(model.MaxStored/100, usd)
+	assert.ErrorIs(t, repo.Update(userCtx, &overflow), model.ErrDecimalOverflow)
+	assert.Equal(t, money(10000, kzt), amount(t, wallets, cash))
+	assert.Equal(t, money(201, usd), amount(t, wallets, card))
+
+	_, err = repo.FindByID(strangerCtx, data.ID)
+	assert.ErrorIs(t, err, repository.ErrTransferNotFound)
+
+	deleted, err := repo.DeleteByID(userCtx, data.ID)
+	require.NoError(t, err)
+	assert.Equal(t, data, deleted)
+	assert.Equal(t, money(100000, kzt), amount(t, wallets, cash))
+	assert.Equal(t, money(1, usd), amount(t, wallets, card))
+
+	_, err = repo.DeleteByID(userCtx, data.ID)
+	assert.ErrorIs(t, err, repository.ErrTransferNotFound)
+}
+
+func TestTransfer_DeleteWallet(t *testing.T) {
+	db := open(t)
+	repo, wallets := NewTransfer(db), NewWallet(db)
+	created := seed(t, wallets,
+		&model.Wallet{Name: "cash", Currency: "KZT", Amount: money(100000, kzt)},
+		&model.Wallet{Name: "card", Currency: "KZT"},
+		&model.Wallet{Name: "savings", Currency: "KZT", Amount: money(50000, kzt)},
+	)
+	cash, card, savings := created[0].ID, created[1].ID, created[2].ID
+	date := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	require.NoError(t, repo.Create(userCtx, &model.Transfer{FromWalletID: cash, ToWalletID: card, Amount: money(20000, kzt), ReceivedAmount: money(20000, kzt), Rate: model.OneRate, Commission: money(500, kzt), Date: date}))
+	require.NoError(t, repo.Create(userCtx, &model.Transfer{FromWalletID: savings, ToWalletID: cash, Amount: money(10000, kzt), ReceivedAmount: money(10000, kzt), Rate: model.OneRate, Commission: money(0, kzt), Date: date}))
+	assert.Equal(t, money(89500, kzt), amount(t, wallets, cash))
+
+	// purging the card takes back what cash sent it, commission included
+	deleted, err := wallets.FindByID(userCtx, card)
+	require.NoError(t, err)
+	deletedAt := time.Now().Add(-time.Hour)
+	deleted.DeletedAt = &deletedAt
+	require.NoError(t, wallets.Update(userCtx, deleted))
+	count, err := wallets.Purge(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), count)
+	assert.Equal(t, money(110000, kzt), amount(t, wallets, cash))
+
+	// deleting the savings takes back what cash received from it
+	_, err = wallets.DeleteByID(userCtx, savings)
+	require.NoError(t, err)
+	assert.Equal(t, money(100000, kzt), amount(t, wallets, cash))
+
+	list, err := repo.FindAll(userCtx, &model.TransferFilter{})
+	require.NoError(t, err)
+	assert.Empty(t, list)
+}
@@ -0,0 +1,176 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/huandu/go-sqlbuilder"
+
+	"github.com/mustan989/wallet/app/internal/repository/sqlquery"
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/repository"
+)
+
+// dialect builds the queries for SQLite, whose columns keep the model values as arg converts them
+var dialect = sqlquery.Dialect{Flavor: sqlbuilder.SQLite, Arg: arg, Now: now}
+
+// querier is implemented by both DB and *sql.Tx
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+func NewTxManager(db DB) repository.TxManager { return &txManager{db} }
+
+type txManager struct{ db DB }
+
+func (m *txManager) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return inTx(ctx, m.db, func(tx *sql.Tx) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+type txKey struct{}
+
+// conn returns the transaction of the context, the database when there is none.
+// The database has a single connection, so a transaction must not wait for the database
+func conn(ctx context.Context, db DB) querier {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+// savepoint names the savepoints of nested transactions, SQLite releases and rolls back the latest one of a name
+const savepoint = "nested"
+
+// inTx runs fn inside a database transaction, committing on success and rolling back on error or panic.
+// Within the transaction of the context it runs in a savepoint, so its rollback leaves the outer transaction going
+func inTx(ctx context.Context, db DB, fn func(tx *sql.Tx) error) (err error) {
+	if outer, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return inSavepoint(ctx, outer, fn)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err = fn(tx); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("%w (rollback: %s)", err, rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func inSavepoint(ctx context.Context, tx *sql.Tx, fn func(tx *sql.Tx) error) (err error) {
+	if _, err = tx.ExecContext(ctx, "SAVEPOINT "+savepoint); err != nil {
+		return err
+	}
+
+	rollback := func() error {
+		_, err := tx.ExecContext(ctx, "ROLLBACK TO "+savepoint+"; RELEASE "+savepoint)
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = rollback()
+			panic(p)
+		}
+	}()
+
+	if err = fn(tx); err != nil {
+		if rbErr := rollback(); rbErr != nil {
+			return fmt.Errorf("%w (rollback: %s)", err, rbErr)
+		}
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "RELEASE "+savepoint)
+	return err
+}
+
+// walletsAmountRange is the name of the check keeping wallet amounts within ±model.MaxStored
+const walletsAmountRange = "wallets_amount_range"
+
+// addWalletAmount adds delta to the amount of the wallet accessible to the acting user
+func addWalletAmount(ctx context.Context, q querier, id uint64, delta model.Money) error {
+	userID, err := model.UserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query, args := dialect.AddWalletAmount(userID, id, delta)
+
+	return execWalletAmount(ctx, q, query, args, id)
+}
+
+// revertTransfers takes the transfers between the wallets and the other ones off the amounts of the other wallets,
+// as the transfers are deleted along with the wallets. It acts for no user, so purge can run it in the background
+func revertTransfers(ctx context.Context, q querier, ids []uint64) error {
+	query, args := dialect.WalletsTransfers(ids)
+
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	reverts := sqlquery.NewReverts(ids)
+	for rows.Next() {
+		var fromID, toID uint64
+		var amount, received, commission model.Money
+		if err = rows.Scan(&fromID, &toID, (*money)(&amount), (*money)(&received), (*money)(&commission)); err != nil {
+			return err
+		}
+		reverts.Add(fromID, toID, amount, received, commission)
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+	if err = rows.Close(); err != nil {
+		return err
+	}
+
+	for _, id := range reverts.Wallets() {
+		query, args := dialect.RevertWalletAmount(id, reverts.Delta(id))
+
+		if err = execWalletAmount(ctx, q, query, args, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func execWalletAmount(ctx context.Context, q querier, query string, args []any, id uint64) error {
+	result, err := q.ExecContext(ctx, query, args...)
+	if err != nil {
+		if checkFailed(err, walletsAmountRange) {
+			return fmt.Errorf("%w: amount of wallet %d", model.ErrDecimalOverflow, id)
+		}
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repository.ErrWalletNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package sqlite_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/mustan989/wallet/app/internal/repository/sqlite"
+	"github.com/mustan989/wallet/model"
+)
+
+func TestTxManager_InTx(t *testing.T) {
+	db := open(t)
+	manager, wallets := NewTxManager(db), NewWallet(db)
+
+	require.NoError(t, manager.InTx(userCtx, func(ctx context.Context) error {
+		return wallets.Create(ctx, &model.Wallet{Name: "committed", Currency: "KZT"})
+	}))
+
+	errRollback := errors.New("rollback")
+	err := manager.InTx(userCtx, func(ctx context.Context) error {
+		require.NoError(t, wallets.Create(ctx, &model.Wallet{Name: "rolled back", Currency: "KZT"}))
+		return errRollback
+	})
+	assert.ErrorIs(t, err, errRollback)
+
+	data, err := wallets.FindAll(userCtx, &model.WalletFilter{})
+	require.NoError(t, err)
+	require.Len(t, data, 1)
+	assert.Equal(t, "committed", data[0].Name)
+}
+
+func TestTxManager_InTxNested(t *testing.T) {
+	db := open(t)
+	manager, wallets := NewTxManager(db), NewWallet(db)
+
+	require.NoError(t, manager.InTx(userCtx, func(ctx context.Context) error {
+		require.NoError(t, wallets.Create(ctx, &model.Wallet{Name: "outer", Currency: "KZT"}))
+
+		// the failed nested transaction leaves the outer one going
+		err := manager.InTx(ctx, func(ctx context.Context) error {
+			require.NoError(t, wallets.Create(ctx, &model.Wallet{Name: "inner", Currency: "KZT"}))
+			return errors.New("rollback")
+		})
+		require.Error(t, err)
+
+		return manager.InTx(ctx, func(ctx context.Context) error {
+			return wallets.Create(ctx, &model.Wallet{Name: "released", Currency: "KZT"})
+		})
+	}))
+
+	data, err := wallets.FindAll(userCtx, &model.WalletFilter{Sort: model.WalletSortName})
+	require.NoError(t, err)
+	require.Len(t, data, 2)
+	assert.Equal(t, "outer", data[0].Name)
+	assert.Equal(t, "released", data[1].Name)
+}
+
+func TestTxManager_InTxPanic(t *testing.T) {
+	db := open(t)
+	manager, wallets := NewTxManager(db), NewWallet(db)
+
+	assert.Panics(t, func() {
+		_ = manager.InTx(userCtx, func(ctx context.Context) error {
+			require.NoError(t, wallets.Create(ctx, &model.Wallet{Name: "rolled back", Currency: "KZT"}))
+			panic("boom")
+		})
+	})
+
+	// the connection is released, so the database is usable again
+	count, err := wallets.CountAll(userCtx, &model.WalletFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), count)
+}
@@ -0,0 +1,68 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/repository"
+)
+
+func NewUser(db DB) repository.User { return &user{db} }
+
+type user struct{ db DB }
+
+func scanUser(row row, data *model.User) error {
+	return row.Scan(&data.ID, &data.Name, &data.Email, &data.PasswordHash, &data.CreatedAt, &data.UpdatedAt)
+}
+
+func (u *user) FindByID(ctx context.Context, id uint64) (data *model.User, err error) {
+	query, args := dialect.FindUser(id)
+
+	data = &model.User{}
+	if err = userError(scanUser(conn(ctx, u.db).QueryRowContext(ctx, query, args...), data)); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (u *user) FindByEmail(ctx context.Context, email string) (data *model.User, err error) {
+	query, args := dialect.FindUserByEmail(email)
+
+	data = &model.User{}
+	if err = userError(scanUser(conn(ctx, u.db).QueryRowContext(ctx, query, args...), data)); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (u *user) Create(ctx context.Context, data *model.User) error {
+	query, args := dialect.CreateUser(data)
+
+	return userError(scanUser(conn(ctx, u.db).QueryRowContext(ctx, query, args...), data))
+}
+
+func (u *user) Update(ctx context.Context, data *model.User) error {
+	query, args := dialect.UpdateUser(data)
+
+	return userError(scanUser(conn(ctx, u.db).QueryRowContext(ctx, query, args...), data))
+}
+
+func userError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return repository.ErrUserNotFound
+	}
+
+	if violates(err) {
+		return repository.ErrUserConflict
+	}
+
+	return err
+}
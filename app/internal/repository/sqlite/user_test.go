@@ -0,0 +1,46 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/mustan989/wallet/app/internal/repository/sqlite"
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/repository"
+)
+
+func TestUser(t *testing.T) {
+	repo := NewUser(open(t))
+	ctx := context.Background()
+
+	data := &model.User{Name: "Alice", Email: "Alice@Example.com", PasswordHash: []byte("hash")}
+	require.NoError(t, repo.Create(ctx, data))
+	assert.Equal(t, uint64(3), data.ID)
+
+	// emails are matched case insensitively
+	found, err := repo.FindByEmail(ctx, "alice@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, data, found)
+
+	err = repo.Create(ctx, &model.User{Name: "Alice", Email: "ALICE@example.com", PasswordHash: []byte("hash")})
+	assert.ErrorIs(t, err, repository.ErrUserConflict)
+
+	data.Name = "Alicia"
+	require.NoError(t, repo.Update(ctx, data))
+	assert.False(t, data.UpdatedAt.Before(data.CreatedAt))
+
+	found, err = repo.FindByID(ctx, data.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Alicia", found.Name)
+	assert.WithinDuration(t, time.Now(), found.UpdatedAt, time.Minute)
+
+	_, err = repo.FindByID(ctx, 10)
+	assert.ErrorIs(t, err, repository.ErrUserNotFound)
+
+	_, err = repo.FindByEmail(ctx, "bob@example.com")
+	assert.ErrorIs(t, err, repository.ErrUserNotFound)
+}
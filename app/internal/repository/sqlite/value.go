@@ -0,0 +1,73 @@
+package sqlite
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	"github.com/mustan989/wallet/model"
+)
+
+// timeLayout keeps times in utc with microseconds like postgres, so their text sorts in time order
+const timeLayout = "2006-01-02 15:04:05.000000-07:00"
+
+// now is the current time in timeLayout, the default of the time columns
+const now = "strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')"
+
+// arg converts the value to what the database stores: amounts as integer minor units of model.Unbound,
+// rates as integer hundred-millionths and times as utc text
+func arg(v any) any {
+	switch v := v.(type) {
+	case model.Money:
+		return money(v)
+	case *model.Money:
+		if v == nil {
+			return nil
+		}
+		return money(*v)
+	case model.Rate:
+		return int64(v)
+	case time.Time:
+		return v.UTC().Format(timeLayout)
+	case *time.Time:
+		if v == nil {
+			return nil
+		}
+		return v.UTC().Format(timeLayout)
+	}
+	return v
+}
+
+// money keeps model.Money as integer minor units of model.Unbound, e.g. 1.5 as 15000
+type money model.Money
+
+// Value fails with model.ErrDecimalOverflow if the amount does not fit the integer
+func (m money) Value() (driver.Value, error) {
+	stored, err := model.Money(m).In(model.Unbound)
+	if err != nil {
+		return nil, err
+	}
+	return stored.Amount, nil
+}
+
+// Scan reads the amount in model.Unbound, it is bound to its currency afterwards
+func (m *money) Scan(src any) error {
+	v, ok := src.(int64)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into Money", src)
+	}
+	*m = money{Amount: v, Currency: model.Unbound}
+	return nil
+}
+
+// rate scans integer hundred-millionths into model.Rate, whose own Scan takes integers as whole units
+type rate model.Rate
+
+func (r *rate) Scan(src any) error {
+	v, ok := src.(int64)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into Rate", src)
+	}
+	*r = rate(v)
+	return nil
+}
@@ -0,0 +1,212 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/repository"
+)
+
+func NewWallet(db DB) repository.Wallet { return &wallet{db} }
+
+type wallet struct{ db DB }
+
+// scanWallet scans a wallet, binding its amount to its currency
+func scanWallet(row row, data *model.Wallet) error {
+	if err := row.Scan(
+		&data.ID, &data.OwnerID, &data.Name, &data.Description, &data.Currency, (*money)(&data.Amount), &data.Personal, &data.CreatedAt, &data.UpdatedAt, &data.DeletedAt, &data.Version,
+	); err != nil {
+		return err
+	}
+	return model.Bind(data.Currency, &data.Amount)
+}
+
+func (w *wallet) CountAll(ctx context.Context, filter *model.WalletFilter) (count uint64, err error) {
+	userID, err := model.UserID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	query, args := dialect.CountWallets(userID, filter)
+
+	err = conn(ctx, w.db).QueryRowContext(ctx, query, args...).Scan(&count)
+
+	return
+}
+
+func (w *wallet) FindAll(ctx context.Context, filter *model.WalletFilter) (data []*model.Wallet, err error) {
+	userID, err := model.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := dialect.FindWallets(userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn(ctx, w.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data = []*model.Wallet{}
+	for rows.Next() {
+		elem := &model.Wallet{}
+
+		if err = scanWallet(rows, elem); err != nil {
+			return nil, err
+		}
+
+		data = append(data, elem)
+	}
+
+	return data, rows.Err()
+}
+
+func (w *wallet) FindByID(ctx context.Context, id uint64) (data *model.Wallet, err error) {
+	userID, err := model.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args := dialect.FindWallet(userID, id)
+
+	data = &model.Wallet{}
+	err = scanWallet(conn(ctx, w.db).QueryRowContext(ctx, query, args...), data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrWalletNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (w *wallet) Create(ctx context.Context, data *model.Wallet) error {
+	owner, err := model.UserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query, args := dialect.CreateWallet(owner, data)
+
+	if err = scanWallet(conn(ctx, w.db).QueryRowContext(ctx, query, args...), data); err != nil {
+		return walletError(err)
+	}
+
+	return nil
+}
+
+func (w *wallet) Update(ctx context.Context, data *model.Wallet) error {
+	userID, err := model.UserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query, args := dialect.UpdateWallet(userID, data)
+
+	if err = scanWallet(conn(ctx, w.db).QueryRowContext(ctx, query, args...), data); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// the wallet is either gone or at another version
+			if _, err = w.FindByID(ctx, data.ID); err != nil {
+				return err
+			}
+			return repository.ErrWalletStale
+		}
+
+		return walletError(err)
+	}
+
+	return nil
+}
+
+func (w *wallet) DeleteByID(ctx context.Context, id uint64) (deleted *model.Wallet, err error) {
+	userID, err := model.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args := dialect.DeleteWallet(userID, id)
+
+	deleted = &model.Wallet{}
+	err = inTx(ctx, w.db, func(tx *sql.Tx) error {
+		// before the transfers go with the wallet; the rollback undoes it if the wallet is not found
+		if err := revertTransfers(ctx, tx, []uint64{id}); err != nil {
+			return err
+		}
+		err := scanWallet(tx.QueryRowContext(ctx, query, args...), deleted)
+		if errors.Is(err, sql.ErrNoRows) {
+			return repository.ErrWalletNotFound
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (w *wallet) Purge(ctx context.Context, before time.Time) (count uint64, err error) {
+	query, args := dialect.PurgedWallets(before, false)
+
+	err = inTx(ctx, w.db, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		var ids []uint64
+		for rows.Next() {
+			var id uint64
+			if err = rows.Scan(&id); err != nil {
+				return err
+			}
+			ids = append(ids, id)
+		}
+		if err = rows.Err(); err != nil {
+			return err
+		}
+		if err = rows.Close(); err != nil || len(ids) == 0 {
+			return err
+		}
+
+		// before the transfers go with the wallets
+		if err = revertTransfers(ctx, tx, ids); err != nil {
+			return err
+		}
+
+		query, args := dialect.PurgeWallets(ids)
+
+		result, err := tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		affected, err := result.RowsAffected()
+		count = uint64(affected)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// walletError maps the amount out of ±model.MaxStored to model.ErrDecimalOverflow
+// and the other constraint violations to repository.ErrWalletConflict
+func walletError(err error) error {
+	if checkFailed(err, walletsAmountRange) {
+		return model.ErrDecimalOverflow
+	}
+	if violates(err) {
+		return repository.ErrWalletConflict
+	}
+	return err
+}
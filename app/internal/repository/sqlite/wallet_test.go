@@ -0,0 +1,13 @@
+package sqlite_test
+
+import (
+	"testing"
+
+	"github.com/mustan989/wallet/app/internal/repository/repositorytest"
+	. "github.com/mustan989/wallet/app/internal/repository/sqlite"
+	"github.com/mustan989/wallet/repository"
+)
+
+func TestWallet(t *testing.T) {
+	repositorytest.TestWallet(t, func(t *testing.T) repository.Wallet { return NewWallet(open(t)) })
+}
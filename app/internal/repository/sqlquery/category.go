@@ -0,0 +1,172 @@
+package sqlquery
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+
+	"github.com/mustan989/wallet/model"
+)
+
+const categoriesTable = "categories"
+
+// categoriesColumns are the columns of a category in the order the repositories scan them
+var categoriesColumns = []string{
+	"id", "owner_id", "parent_id", "name", "type", "created_at", "updated_at", "archived_at",
+}
+
+const categoriesReturning = `$? RETURNING "id", "owner_id", "parent_id", "name", "type", "created_at", "updated_at", "archived_at"`
+
+func (d Dialect) categoriesWhere(sb *sqlbuilder.SelectBuilder, owner uint64, filter *model.CategoryFilter) {
+	sb.Where(sb.E("owner_id", owner))
+	if filter.ParentID != nil && *filter.ParentID == 0 {
+		sb.Where(sb.IsNull("parent_id"))
+	}
+	if filter.ParentID != nil && *filter.ParentID != 0 {
+		sb.Where(sb.Equal("parent_id", *filter.ParentID))
+	}
+	if filter.NameLike != "" {
+		sb.Where(sb.Like("name", fmt.Sprint("%", filter.NameLike, "%")))
+	}
+	if filter.Type != "" {
+		sb.Where(sb.Equal("type", filter.Type))
+	}
+	if filter.Archived != nil && *filter.Archived {
+		sb.Where(sb.IsNotNull("archived_at"))
+	}
+	if filter.Archived != nil && !*filter.Archived {
+		sb.Where(sb.IsNull("archived_at"))
+	}
+}
+
+// CountCategories counts the categories of the filter owned by the user
+func (d Dialect) CountCategories(owner uint64, filter *model.CategoryFilter) (string, []any) {
+	sb := d.Flavor.NewSelectBuilder().
+		Select("COUNT(*)").
+		From(categoriesTable)
+	d.categoriesWhere(sb, owner, filter)
+
+	return sb.Build()
+}
+
+// FindCategories selects the page of the categories of the filter owned by the user
+func (d Dialect) FindCategories(owner uint64, filter *model.CategoryFilter) (string, []any, error) {
+	sb := d.Flavor.NewSelectBuilder().
+		Select(categoriesColumns...).
+		From(categoriesTable)
+	d.categoriesWhere(sb, owner, filter)
+
+	if filter.Cursor != nil {
+		name, err := filter.After()
+		if err != nil {
+			return "", nil, err
+		}
+		sb.Where(d.after(sb, "name", "id", model.Asc, name, filter.Cursor.ID))
+	}
+	d.page(sb, &filter.Filter)
+
+	query, args := sb.OrderBy("name", "id").Build()
+	return query, args, nil
+}
+
+// FindCategory selects the category if it is owned by the user
+func (d Dialect) FindCategory(owner, id uint64) (string, []any) {
+	sb := d.Flavor.NewSelectBuilder().
+		Select(categoriesColumns...).
+		From(categoriesTable)
+	sb.Where(sb.E("id", id), sb.E("owner_id", owner)).Limit(1)
+
+	return sb.Build()
+}
+
+// CategoryExists selects 1 if the category is owned by the user
+func (d Dialect) CategoryExists(owner, id uint64) (string, []any) {
+	sb := d.Flavor.NewSelectBuilder().
+		Select("1").
+		From(categoriesTable)
+	sb.Where(sb.E("id", id), sb.E("owner_id", owner))
+
+	return sb.Build()
+}
+
+// CreateCategory inserts the category of the owner
+func (d Dialect) CreateCategory(owner uint64, data *model.Category) (string, []any) {
+	ib := d.Flavor.NewInsertBuilder().
+		InsertInto(categoriesTable).
+		Cols("owner_id", "parent_id", "name", "type", "archived_at").
+		Values(owner, data.ParentID, data.Name, data.Type, d.arg(data.ArchivedAt))
+
+	return sqlbuilder.Build(categoriesReturning, ib).BuildWithFlavor(d.Flavor)
+}
+
+// UpdateCategory updates the category if it is owned by the user, keeping its archive time
+func (d Dialect) UpdateCategory(owner uint64, data *model.Category) (string, []any) {
+	ub := d.Flavor.NewUpdateBuilder().
+		Update(categoriesTable)
+	ub.Set(
+		ub.Assign("parent_id", data.ParentID),
+		ub.Assign("name", data.Name),
+		ub.Assign("type", data.Type),
+		d.updatedNow(),
+	).Where(ub.E("id", data.ID), ub.E("owner_id", owner))
+
+	return sqlbuilder.Build(categoriesReturning, ub).BuildWithFlavor(d.Flavor)
+}
+
+// ArchiveCategory sets the archive time of the category if it is owned by the user
+func (d Dialect) ArchiveCategory(owner, id uint64, at time.Time) (string, []any) {
+	ub := d.Flavor.NewUpdateBuilder().
+		Update(categoriesTable)
+	ub.Set(
+		d.updatedNow(),
+		ub.Assign("archived_at", d.arg(at)),
+	).Where(ub.E("id", id), ub.E("owner_id", owner))
+
+	return sqlbuilder.Build(categoriesReturning, ub).BuildWithFlavor(d.Flavor)
+}
+
+// CategoryUsed selects whether the category has children or transactions
+func (d Dialect) CategoryUsed(id uint64) (string, []any) {
+	children := d.Flavor.NewSelectBuilder().
+		Select("1").
+		From(categoriesTable)
+	children.Where(children.E("parent_id", id))
+
+	transactions := d.Flavor.NewSelectBuilder().
+		Select("1").
+		From(transactionsTable)
+	transactions.Where(transactions.E("category_id", id))
+
+	return sqlbuilder.Build("SELECT EXISTS ($?) OR EXISTS ($?)", children, transactions).BuildWithFlavor(d.Flavor)
+}
+
+// ReassignTransactions moves the transactions of the category to another one
+func (d Dialect) ReassignTransactions(id, to uint64) (string, []any) {
+	ub := d.Flavor.NewUpdateBuilder().
+		Update(transactionsTable)
+	ub.Set(ub.Assign("category_id", to)).Where(ub.E("category_id", id))
+
+	return ub.Build()
+}
+
+// MoveChildren moves the children of the category to its parent
+func (d Dialect) MoveChildren(owner, id uint64) (string, []any) {
+	ub := d.Flavor.NewUpdateBuilder().
+		Update(categoriesTable)
+	ub.Set(
+		fmt.Sprintf("parent_id = (SELECT parent_id FROM %s WHERE %s)", categoriesTable, ub.E("id", id)),
+		d.updatedNow(),
+	).Where(ub.E("parent_id", id), ub.E("owner_id", owner))
+
+	return ub.Build()
+}
+
+// DeleteCategory deletes the category if it is owned by the user
+func (d Dialect) DeleteCategory(owner, id uint64) (string, []any) {
+	db := d.Flavor.NewDeleteBuilder().
+		DeleteFrom(categoriesTable)
+	db.Where(db.E("id", id), db.E("owner_id", owner))
+
+	return sqlbuilder.Build(categoriesReturning, db).BuildWithFlavor(d.Flavor)
+}
@@ -0,0 +1,68 @@
+// Package sqlquery builds the queries of the SQL repositories. The Dialect sets the databases apart,
+// the repositories run the queries, scan the rows and map the errors of their drivers
+package sqlquery
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/huandu/go-sqlbuilder"
+
+	"github.com/mustan989/wallet/model"
+)
+
+// Dialect is what the queries of a database differ in
+type Dialect struct {
+	Flavor sqlbuilder.Flavor
+	// Arg converts a value to what the database stores, values are passed as they are when it is nil
+	Arg func(v any) any
+	// Now is the expression of the current time, the default of the time columns
+	Now string
+}
+
+func (d Dialect) arg(v any) any {
+	if d.Arg == nil {
+		return v
+	}
+	return d.Arg(v)
+}
+
+// updatedNow sets updated_at of the changed rows
+func (d Dialect) updatedNow() string {
+	return "updated_at = " + d.Now
+}
+
+// after is the keyset condition of the rows following the cursor in the order of column and then id,
+// column is empty when the rows are sorted by id only
+func (d Dialect) after(sb *sqlbuilder.SelectBuilder, column, id string, order model.SortOrder, key any, cursorID uint64) string {
+	op := ">"
+	if order == model.Desc {
+		op = "<"
+	}
+	if column == "" {
+		return fmt.Sprintf("%s %s %s", id, op, sb.Var(cursorID))
+	}
+	return fmt.Sprintf("(%s, %s) %s (%s, %s)", column, id, op, sb.Var(d.arg(key)), sb.Var(cursorID))
+}
+
+// page limits the rows to the page of the filter, SQLite takes an offset only after a limit
+func (d Dialect) page(sb *sqlbuilder.SelectBuilder, filter *model.Filter) {
+	if filter.Limit != 0 {
+		sb.Limit(int(filter.Limit))
+	}
+	if filter.Offset != 0 {
+		if filter.Limit == 0 && d.Flavor == sqlbuilder.SQLite {
+			sb.Limit(math.MaxInt)
+		}
+		sb.Offset(int(filter.Offset))
+	}
+}
+
+// ids converts the ids to the arguments of an IN condition
+func ids(in []uint64) []any {
+	args := make([]any, len(in))
+	for i, id := range in {
+		args[i] = id
+	}
+	return args
+}
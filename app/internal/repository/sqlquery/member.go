@@ -0,0 +1,64 @@
+package sqlquery
+
+import (
+	"fmt"
+
+	"github.com/huandu/go-sqlbuilder"
+
+	"github.com/mustan989/wallet/model"
+)
+
+const membersTable = "wallet_members"
+
+// membersColumns are the columns of a member in the order the repositories scan them
+var membersColumns = []string{
+	"wallet_id", "user_id", "role", "created_at", "updated_at",
+}
+
+const membersReturning = `$? RETURNING "wallet_id", "user_id", "role", "created_at", "updated_at"`
+
+// FindMembers selects the members of the wallet if it is accessible to the user
+func (d Dialect) FindMembers(userID, walletID uint64) (string, []any) {
+	sb := d.Flavor.NewSelectBuilder().
+		Select(membersColumns...).
+		From(membersTable)
+	sb.Where(sb.E("wallet_id", walletID), sb.In("wallet_id", d.accessibleWallets(userID)))
+
+	return sb.OrderBy("user_id").Build()
+}
+
+// FindRole selects the role of the user in the wallet, the owner is one too
+func (d Dialect) FindRole(userID, walletID uint64) (string, []any) {
+	sb := d.Flavor.NewSelectBuilder()
+	sb.Select(fmt.Sprintf("CASE WHEN w.owner_id = %s THEN %s ELSE m.role END", sb.Var(userID), sb.Var(model.Owner))).
+		From(walletsTable+" w").
+		JoinWithOption(sqlbuilder.LeftJoin, membersTable+" m", "m.wallet_id = w.id", sb.E("m.user_id", userID))
+	sb.Where(
+		sb.E("w.id", walletID),
+		sb.Or(sb.E("w.owner_id", userID), sb.And("NOT w.personal", sb.IsNotNull("m.role"))),
+	)
+
+	return sb.Build()
+}
+
+// SaveMember inserts the member or changes the role of the existing one
+func (d Dialect) SaveMember(data *model.Member) (string, []any) {
+	ib := d.Flavor.NewInsertBuilder().
+		InsertInto(membersTable).
+		Cols("wallet_id", "user_id", "role").
+		Values(data.WalletID, data.UserID, data.Role)
+
+	return sqlbuilder.Build(
+		`$? ON CONFLICT ("wallet_id", "user_id") DO UPDATE SET "role" = excluded."role", "updated_at" = `+d.Now+` `+
+			`RETURNING "wallet_id", "user_id", "role", "created_at", "updated_at"`, ib,
+	).BuildWithFlavor(d.Flavor)
+}
+
+// DeleteMember deletes the member of the wallet if it is accessible to the acting user
+func (d Dialect) DeleteMember(actingID, walletID, userID uint64) (string, []any) {
+	db := d.Flavor.NewDeleteBuilder().
+		DeleteFrom(membersTable)
+	db.Where(db.E("wallet_id", walletID), db.E("user_id", userID), db.In("wallet_id", d.accessibleWallets(actingID)))
+
+	return sqlbuilder.Build(membersReturning, db).BuildWithFlavor(d.Flavor)
+}
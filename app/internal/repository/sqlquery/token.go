@@ -0,0 +1,38 @@
+package sqlquery
+
+import (
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+)
+
+const revokedTokensTable = "revoked_tokens"
+
+// DeleteExpiredTokens deletes the revoked tokens expired by the time
+func (d Dialect) DeleteExpiredTokens(at time.Time) (string, []any) {
+	db := d.Flavor.NewDeleteBuilder().
+		DeleteFrom(revokedTokensTable)
+	db.Where(db.LessThan("expires_at", d.arg(at)))
+
+	return db.Build()
+}
+
+// RevokeToken inserts the revoked token unless it is there already
+func (d Dialect) RevokeToken(id string, expiresAt time.Time) (string, []any) {
+	ib := d.Flavor.NewInsertBuilder().
+		InsertInto(revokedTokensTable).
+		Cols("id", "expires_at").
+		Values(id, d.arg(expiresAt))
+
+	return sqlbuilder.Build("$? ON CONFLICT DO NOTHING", ib).BuildWithFlavor(d.Flavor)
+}
+
+// TokenRevoked selects 1 if the token is revoked
+func (d Dialect) TokenRevoked(id string) (string, []any) {
+	sb := d.Flavor.NewSelectBuilder().
+		Select("1").
+		From(revokedTokensTable)
+	sb.Where(sb.E("id", id))
+
+	return sb.Build()
+}
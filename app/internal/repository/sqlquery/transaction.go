@@ -0,0 +1,121 @@
+package sqlquery
+
+import (
+	"fmt"
+
+	"github.com/huandu/go-sqlbuilder"
+
+	"github.com/mustan989/wallet/model"
+)
+
+const transactionsTable = "transactions"
+
+// transactionsColumns are the columns of a transaction in the order the repositories scan them,
+// the currency of its wallet comes last to bind the amount to
+var transactionsColumns = []string{
+	"id", "wallet_id", "category_id", "type", "amount", "description", "date", "transfer_id", "created_at", "updated_at", walletCurrency("transactions.wallet_id"),
+}
+
+var transactionsReturning = `$? RETURNING "id", "wallet_id", "category_id", "type", "amount", "description", "date", "transfer_id", "created_at", "updated_at", ` +
+	walletCurrency("transactions.wallet_id")
+
+func (d Dialect) transactionsWhere(sb *sqlbuilder.SelectBuilder, userID uint64, filter *model.TransactionFilter) {
+	sb.Where(sb.In("wallet_id", d.accessibleWallets(userID)))
+	if filter.WalletID != nil {
+		sb.Where(sb.Equal("wallet_id", *filter.WalletID))
+	}
+	if filter.CategoryID != nil {
+		sb.Where(sb.Equal("category_id", *filter.CategoryID))
+	}
+	if filter.Type != "" {
+		sb.Where(sb.Equal("type", filter.Type))
+	}
+	if filter.DescriptionLike != "" {
+		sb.Where(sb.Like("description", fmt.Sprint("%", filter.DescriptionLike, "%")))
+	}
+	if filter.DateFrom != nil {
+		sb.Where(sb.GreaterEqualThan("date", d.arg(*filter.DateFrom)))
+	}
+	if filter.DateTo != nil {
+		sb.Where(sb.LessThan("date", d.arg(*filter.DateTo)))
+	}
+}
+
+// CountTransactions counts the transactions of the filter in the wallets accessible to the user
+func (d Dialect) CountTransactions(userID uint64, filter *model.TransactionFilter) (string, []any) {
+	sb := d.Flavor.NewSelectBuilder().
+		Select("COUNT(*)").
+		From(transactionsTable)
+	d.transactionsWhere(sb, userID, filter)
+
+	return sb.Build()
+}
+
+// FindTransactions selects the page of the transactions of the filter in the wallets accessible to the user
+func (d Dialect) FindTransactions(userID uint64, filter *model.TransactionFilter) (string, []any, error) {
+	sb := d.Flavor.NewSelectBuilder().
+		Select(transactionsColumns...).
+		From(transactionsTable)
+	d.transactionsWhere(sb, userID, filter)
+
+	if filter.Cursor != nil {
+		date, err := filter.After()
+		if err != nil {
+			return "", nil, err
+		}
+		sb.Where(d.after(sb, "date", "id", model.Desc, date, filter.Cursor.ID))
+	}
+	d.page(sb, &filter.Filter)
+
+	query, args := sb.OrderBy("date DESC", "id DESC").Build()
+	return query, args, nil
+}
+
+// FindTransaction selects the transaction if its wallet is accessible to the user, locking it when lock is set
+func (d Dialect) FindTransaction(userID, id uint64, lock bool) (string, []any) {
+	sb := d.Flavor.NewSelectBuilder().
+		Select(transactionsColumns...).
+		From(transactionsTable)
+	sb.Where(sb.E("id", id), sb.In("wallet_id", d.accessibleWallets(userID))).Limit(1)
+	if lock {
+		sb.ForUpdate()
+	}
+
+	return sb.Build()
+}
+
+// CreateTransaction inserts the transaction
+func (d Dialect) CreateTransaction(data *model.Transaction) (string, []any) {
+	ib := d.Flavor.NewInsertBuilder().
+		InsertInto(transactionsTable).
+		Cols("wallet_id", "category_id", "type", "amount", "description", "date").
+		Values(data.WalletID, data.CategoryID, data.Type, d.arg(data.Amount), data.Description, d.arg(data.Date))
+
+	return sqlbuilder.Build(transactionsReturning, ib).BuildWithFlavor(d.Flavor)
+}
+
+// UpdateTransaction updates the transaction
+func (d Dialect) UpdateTransaction(data *model.Transaction) (string, []any) {
+	ub := d.Flavor.NewUpdateBuilder().
+		Update(transactionsTable)
+	ub.Set(
+		ub.Assign("wallet_id", data.WalletID),
+		ub.Assign("category_id", data.CategoryID),
+		ub.Assign("type", data.Type),
+		ub.Assign("amount", d.arg(data.Amount)),
+		ub.Assign("description", data.Description),
+		ub.Assign("date", d.arg(data.Date)),
+		d.updatedNow(),
+	).Where(ub.E("id", data.ID))
+
+	return sqlbuilder.Build(transactionsReturning, ub).BuildWithFlavor(d.Flavor)
+}
+
+// DeleteTransaction deletes the transaction
+func (d Dialect) DeleteTransaction(id uint64) (string, []any) {
+	db := d.Flavor.NewDeleteBuilder().
+		DeleteFrom(transactionsTable)
+	db.Where(db.E("id", id))
+
+	return sqlbuilder.Build(transactionsReturning, db).BuildWithFlavor(d.Flavor)
+}
@@ -0,0 +1,146 @@
+package sqlquery
+
+import (
+	"fmt"
+
+	"github.com/huandu/go-sqlbuilder"
+
+	"github.com/mustan989/wallet/model"
+)
+
+const transfersTable = "transfers"
+
+// transfersColumns select a transfer joined with its commission transaction, in the order the repositories scan them,
+// the currencies of its wallets come last to bind the amounts to
+var transfersColumns = []string{
+	"t.id", "t.from_wallet_id", "t.to_wallet_id", "t.amount", "t.received_amount", "t.rate", "COALESCE(c.amount, 0)", "c.id", "t.description", "t.date", "t.created_at", "t.updated_at",
+	walletCurrency("t.from_wallet_id"), walletCurrency("t.to_wallet_id"),
+}
+
+// transfersReturning returns the transfer without its commission
+var transfersReturning = `$? RETURNING "id", "from_wallet_id", "to_wallet_id", "amount", "received_amount", "rate", "description", "date", "created_at", "updated_at", ` +
+	walletCurrency("transfers.from_wallet_id") + ", " + walletCurrency("transfers.to_wallet_id")
+
+func (d Dialect) transfersSelect(columns ...string) *sqlbuilder.SelectBuilder {
+	return d.Flavor.NewSelectBuilder().
+		Select(columns...).
+		From(transfersTable+" t").
+		JoinWithOption(sqlbuilder.LeftJoin, transactionsTable+" c", "c.transfer_id = t.id")
+}
+
+// transfersAccessible is the condition of the transfers from or to the wallets accessible to the user
+func (d Dialect) transfersAccessible(sb *sqlbuilder.SelectBuilder, userID uint64) string {
+	return sb.Or(sb.In("t.from_wallet_id", d.accessibleWallets(userID)), sb.In("t.to_wallet_id", d.accessibleWallets(userID)))
+}
+
+func (d Dialect) transfersWhere(sb *sqlbuilder.SelectBuilder, userID uint64, filter *model.TransferFilter) {
+	sb.Where(d.transfersAccessible(sb, userID))
+	if filter.WalletID != nil {
+		sb.Where(sb.Or(sb.Equal("t.from_wallet_id", *filter.WalletID), sb.Equal("t.to_wallet_id", *filter.WalletID)))
+	}
+	if filter.DescriptionLike != "" {
+		sb.Where(sb.Like("t.description", fmt.Sprint("%", filter.DescriptionLike, "%")))
+	}
+	if filter.DateFrom != nil {
+		sb.Where(sb.GreaterEqualThan("t.date", d.arg(*filter.DateFrom)))
+	}
+	if filter.DateTo != nil {
+		sb.Where(sb.LessThan("t.date", d.arg(*filter.DateTo)))
+	}
+}
+
+// CountTransfers counts the transfers of the filter from or to the wallets accessible to the user
+func (d Dialect) CountTransfers(userID uint64, filter *model.TransferFilter) (string, []any) {
+	sb := d.transfersSelect("COUNT(*)")
+	d.transfersWhere(sb, userID, filter)
+
+	return sb.Build()
+}
+
+// FindTransfers selects the page of the transfers of the filter from or to the wallets accessible to the user
+func (d Dialect) FindTransfers(userID uint64, filter *model.TransferFilter) (string, []any, error) {
+	sb := d.transfersSelect(transfersColumns...)
+	d.transfersWhere(sb, userID, filter)
+
+	if filter.Cursor != nil {
+		date, err := filter.After()
+		if err != nil {
+			return "", nil, err
+		}
+		sb.Where(d.after(sb, "t.date", "t.id", model.Desc, date, filter.Cursor.ID))
+	}
+	d.page(sb, &filter.Filter)
+
+	query, args := sb.OrderBy("t.date DESC", "t.id DESC").Build()
+	return query, args, nil
+}
+
+// FindTransfer selects the transfer if one of its wallets is accessible to the user, locking it when lock is set
+func (d Dialect) FindTransfer(userID, id uint64, lock bool) (string, []any) {
+	sb := d.transfersSelect(transfersColumns...)
+	sb.Where(sb.E("t.id", id), d.transfersAccessible(sb, userID)).Limit(1)
+
+	query, args := sb.Build()
+	if lock {
+		// the commission is on the nullable side of the join, so only the transfer row can be locked
+		query += " FOR UPDATE OF t"
+	}
+
+	return query, args
+}
+
+// CreateTransfer inserts the transfer without its commission
+func (d Dialect) CreateTransfer(data *model.Transfer) (string, []any) {
+	ib := d.Flavor.NewInsertBuilder().
+		InsertInto(transfersTable).
+		Cols("from_wallet_id", "to_wallet_id", "amount", "received_amount", "rate", "description", "date").
+		Values(data.FromWalletID, data.ToWalletID, d.arg(data.Amount), d.arg(data.ReceivedAmount), d.arg(data.Rate), data.Description, d.arg(data.Date))
+
+	return sqlbuilder.Build(transfersReturning, ib).BuildWithFlavor(d.Flavor)
+}
+
+// UpdateTransfer updates the transfer without its commission
+func (d Dialect) UpdateTransfer(data *model.Transfer) (string, []any) {
+	ub := d.Flavor.NewUpdateBuilder().
+		Update(transfersTable)
+	ub.Set(
+		ub.Assign("from_wallet_id", data.FromWalletID),
+		ub.Assign("to_wallet_id", data.ToWalletID),
+		ub.Assign("amount", d.arg(data.Amount)),
+		ub.Assign("received_amount", d.arg(data.ReceivedAmount)),
+		ub.Assign("rate", d.arg(data.Rate)),
+		ub.Assign("description", data.Description),
+		ub.Assign("date", d.arg(data.Date)),
+		d.updatedNow(),
+	).Where(ub.E("id", data.ID))
+
+	return sqlbuilder.Build(transfersReturning, ub).BuildWithFlavor(d.Flavor)
+}
+
+// DeleteTransfer deletes the transfer, its commission is deleted apart
+func (d Dialect) DeleteTransfer(id uint64) (string, []any) {
+	db := d.Flavor.NewDeleteBuilder().
+		DeleteFrom(transfersTable)
+	db.Where(db.E("id", id))
+
+	return db.Build()
+}
+
+// CreateCommission inserts the commission of the transfer as an expense of the source wallet, returning its id
+func (d Dialect) CreateCommission(data *model.Transfer) (string, []any) {
+	ib := d.Flavor.NewInsertBuilder().
+		InsertInto(transactionsTable).
+		Cols("wallet_id", "type", "amount", "description", "date", "transfer_id").
+		Values(data.FromWalletID, model.Expense, d.arg(data.Commission), data.Description, d.arg(data.Date), data.ID)
+
+	return sqlbuilder.Build(`$? RETURNING "id"`, ib).BuildWithFlavor(d.Flavor)
+}
+
+// DeleteCommission deletes the commission transaction
+func (d Dialect) DeleteCommission(id uint64) (string, []any) {
+	db := d.Flavor.NewDeleteBuilder().
+		DeleteFrom(transactionsTable)
+	db.Where(db.E("id", id))
+
+	return db.Build()
+}
@@ -0,0 +1,67 @@
+package sqlquery
+
+import (
+	"strings"
+
+	"github.com/huandu/go-sqlbuilder"
+
+	"github.com/mustan989/wallet/model"
+)
+
+const usersTable = "users"
+
+// usersColumns are the columns of a user in the order the repositories scan them
+var usersColumns = []string{
+	"id", "name", "email", "password_hash", "created_at", "updated_at",
+}
+
+const usersReturning = `$? RETURNING "id", "name", "email", "password_hash", "created_at", "updated_at"`
+
+// FindUser selects the user
+func (d Dialect) FindUser(id uint64) (string, []any) {
+	sb := d.Flavor.NewSelectBuilder().
+		Select(usersColumns...).
+		From(usersTable)
+	sb.Where(sb.E("id", id)).Limit(1)
+
+	return sb.Build()
+}
+
+// FindUserByEmail selects the user of the email in any case
+func (d Dialect) FindUserByEmail(email string) (string, []any) {
+	sb := d.Flavor.NewSelectBuilder().
+		Select(usersColumns...).
+		From(usersTable)
+	if d.Flavor == sqlbuilder.SQLite {
+		// lower of SQLite folds ascii letters only, the unique index folds the same way
+		sb.Where("lower(email) = lower(" + sb.Var(email) + ")")
+	} else {
+		sb.Where(sb.E("lower(email)", strings.ToLower(email)))
+	}
+	sb.Limit(1)
+
+	return sb.Build()
+}
+
+// CreateUser inserts the user
+func (d Dialect) CreateUser(data *model.User) (string, []any) {
+	ib := d.Flavor.NewInsertBuilder().
+		InsertInto(usersTable).
+		Cols("name", "email", "password_hash").
+		Values(data.Name, data.Email, data.PasswordHash)
+
+	return sqlbuilder.Build(usersReturning, ib).BuildWithFlavor(d.Flavor)
+}
+
+// UpdateUser updates the name and the email of the user
+func (d Dialect) UpdateUser(data *model.User) (string, []any) {
+	ub := d.Flavor.NewUpdateBuilder().
+		Update(usersTable)
+	ub.Set(
+		ub.Assign("name", data.Name),
+		ub.Assign("email", data.Email),
+		d.updatedNow(),
+	).Where(ub.E("id", data.ID))
+
+	return sqlbuilder.Build(usersReturning, ub).BuildWithFlavor(d.Flavor)
+}
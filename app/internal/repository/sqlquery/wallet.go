@@ -0,0 +1,312 @@
+package sqlquery
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+
+	"github.com/mustan989/wallet/model"
+)
+
+const walletsTable = "wallets"
+
+// walletsColumns are the columns of a wallet in the order the repositories scan them
+var walletsColumns = []string{
+	"id", "owner_id", "name", "description", "currency", "amount", "personal", "created_at", "updated_at", "deleted_at", "version",
+}
+
+const walletsReturning = `$? RETURNING "id", "owner_id", "name", "description", "currency", "amount", "personal", "created_at", "updated_at", "deleted_at", "version"`
+
+// walletsSorts maps the sortable fields to their columns
+var walletsSorts = map[model.WalletSort]string{
+	model.WalletSortID:        "id",
+	model.WalletSortName:      "name",
+	model.WalletSortCurrency:  "currency",
+	model.WalletSortAmount:    "amount",
+	model.WalletSortCreatedAt: "created_at",
+	model.WalletSortUpdatedAt: "updated_at",
+}
+
+// walletCurrency selects the currency of the wallet of the id column, amounts are stored unbound to it
+func walletCurrency(column string) string {
+	return fmt.Sprintf("(SELECT currency FROM %s WHERE %s.id = %s)", walletsTable, walletsTable, column)
+}
+
+// accessible is the condition of the wallets owned by the user or shared with them, personal wallets are never shared
+func (d Dialect) accessible(cond *sqlbuilder.Cond, userID uint64) string {
+	members := d.Flavor.NewSelectBuilder().
+		Select("wallet_id").
+		From(membersTable)
+	members.Where(members.E("user_id", userID))
+
+	return cond.Or(cond.E("owner_id", userID), cond.And("NOT personal", cond.In("id", members)))
+}
+
+// accessibleWallets selects ids of the wallets owned by the user or shared with them
+func (d Dialect) accessibleWallets(userID uint64) *sqlbuilder.SelectBuilder {
+	sb := d.Flavor.NewSelectBuilder().
+		Select("id").
+		From(walletsTable)
+	sb.Where(d.accessible(&sb.Cond, userID))
+	return sb
+}
+
+func (d Dialect) walletsWhere(sb *sqlbuilder.SelectBuilder, userID uint64, filter *model.WalletFilter) {
+	sb.Where(d.accessible(&sb.Cond, userID))
+	if filter.NameLike != "" {
+		sb.Where(sb.Like("name", fmt.Sprint("%", filter.NameLike, "%")))
+	}
+	if filter.DescriptionLike != "" {
+		sb.Where(sb.Like("description", fmt.Sprint("%", filter.DescriptionLike, "%")))
+	}
+	if filter.Currency != "" {
+		sb.Where(sb.Equal("currency", filter.Currency))
+	}
+	if len(filter.Currencies) != 0 {
+		currencies := make([]any, len(filter.Currencies))
+		for i, currency := range filter.Currencies {
+			currencies[i] = currency
+		}
+		sb.Where(sb.In("currency", currencies...))
+	}
+	if filter.Personal != nil {
+		sb.Where(sb.Equal("personal", filter.Personal))
+	}
+	if filter.AmountMin != nil {
+		sb.Where(sb.GreaterEqualThan("amount", d.arg(*filter.AmountMin)))
+	}
+	if filter.AmountMax != nil {
+		sb.Where(sb.LessEqualThan("amount", d.arg(*filter.AmountMax)))
+	}
+	if filter.CreatedFrom != nil {
+		sb.Where(sb.GreaterEqualThan("created_at", d.arg(*filter.CreatedFrom)))
+	}
+	if filter.CreatedTo != nil {
+		sb.Where(sb.LessThan("created_at", d.arg(*filter.CreatedTo)))
+	}
+	if filter.UpdatedFrom != nil {
+		sb.Where(sb.GreaterEqualThan("updated_at", d.arg(*filter.UpdatedFrom)))
+	}
+	if filter.UpdatedTo != nil {
+		sb.Where(sb.LessThan("updated_at", d.arg(*filter.UpdatedTo)))
+	}
+	switch filter.Deleted {
+	case model.DeletedInclude:
+	case model.DeletedOnly:
+		sb.Where(sb.IsNotNull("deleted_at"))
+	default:
+		sb.Where(sb.IsNull("deleted_at"))
+	}
+}
+
+// walletsOrder sorts by the filter field, ties are broken by id so the pages are stable
+func (d Dialect) walletsOrder(sb *sqlbuilder.SelectBuilder, filter *model.WalletFilter) *sqlbuilder.SelectBuilder {
+	sort, order := filter.Ordering()
+
+	direction := "ASC"
+	if order == model.Desc {
+		direction = "DESC"
+	}
+
+	if column := walletsSorts[sort]; column != "id" {
+		return sb.OrderBy(column+" "+direction, "id "+direction)
+	}
+	return sb.OrderBy("id " + direction)
+}
+
+// CountWallets counts the wallets of the filter accessible to the user
+func (d Dialect) CountWallets(userID uint64, filter *model.WalletFilter) (string, []any) {
+	sb := d.Flavor.NewSelectBuilder().
+		Select("COUNT(*)").
+		From(walletsTable)
+	d.walletsWhere(sb, userID, filter)
+
+	return sb.Build()
+}
+
+// FindWallets selects the page of the wallets of the filter accessible to the user
+func (d Dialect) FindWallets(userID uint64, filter *model.WalletFilter) (string, []any, error) {
+	sb := d.Flavor.NewSelectBuilder().
+		Select(walletsColumns...).
+		From(walletsTable)
+	d.walletsWhere(sb, userID, filter)
+
+	if filter.Cursor != nil {
+		key, err := filter.After()
+		if err != nil {
+			return "", nil, err
+		}
+		sort, order := filter.Ordering()
+		column := ""
+		if key != nil {
+			column = walletsSorts[sort]
+		}
+		sb.Where(d.after(sb, column, "id", order, key, filter.Cursor.ID))
+	}
+	d.page(sb, &filter.Filter)
+
+	query, args := d.walletsOrder(sb, filter).Build()
+	return query, args, nil
+}
+
+// FindWallet selects the wallet if it is accessible to the user
+func (d Dialect) FindWallet(userID, id uint64) (string, []any) {
+	sb := d.Flavor.NewSelectBuilder().
+		Select(walletsColumns...).
+		From(walletsTable)
+	sb.Where(sb.E("id", id), d.accessible(&sb.Cond, userID)).Limit(1)
+
+	return sb.Build()
+}
+
+// WalletExists selects 1 if the wallet exists, whoever it belongs to
+func (d Dialect) WalletExists(id uint64) (string, []any) {
+	sb := d.Flavor.NewSelectBuilder().
+		Select("1").
+		From(walletsTable)
+	sb.Where(sb.E("id", id))
+
+	return sb.Build()
+}
+
+// CreateWallet inserts the wallet of the owner
+func (d Dialect) CreateWallet(owner uint64, data *model.Wallet) (string, []any) {
+	ib := d.Flavor.NewInsertBuilder().
+		InsertInto(walletsTable).
+		Cols("owner_id", "name", "description", "currency", "amount", "personal").
+		Values(owner, data.Name, data.Description, data.Currency, d.arg(data.Amount), data.Personal)
+
+	return sqlbuilder.Build(walletsReturning, ib).BuildWithFlavor(d.Flavor)
+}
+
+// UpdateWallet updates the wallet at its version if it is accessible to the user, the amount changes only with the history
+func (d Dialect) UpdateWallet(userID uint64, data *model.Wallet) (string, []any) {
+	ub := d.Flavor.NewUpdateBuilder().
+		Update(walletsTable)
+	ub.Set(
+		ub.Assign("name", data.Name),
+		ub.Assign("description", data.Description),
+		ub.Assign("currency", data.Currency),
+		ub.Assign("personal", data.Personal),
+		d.updatedNow(),
+		ub.Assign("deleted_at", d.arg(data.DeletedAt)),
+		"version = version + 1",
+	).Where(ub.E("id", data.ID), ub.E("version", data.Version), d.accessible(&ub.Cond, userID))
+
+	return sqlbuilder.Build(walletsReturning, ub).BuildWithFlavor(d.Flavor)
+}
+
+// DeleteWallet deletes the wallet if it is accessible to the user
+func (d Dialect) DeleteWallet(userID, id uint64) (string, []any) {
+	db := d.Flavor.NewDeleteBuilder().
+		DeleteFrom(walletsTable)
+	db.Where(db.E("id", id), d.accessible(&db.Cond, userID))
+
+	return sqlbuilder.Build(walletsReturning, db).BuildWithFlavor(d.Flavor)
+}
+
+// PurgedWallets selects ids of the wallets deleted before the time, locking them when lock is set
+func (d Dialect) PurgedWallets(before time.Time, lock bool) (string, []any) {
+	sb := d.Flavor.NewSelectBuilder().
+		Select("id").
+		From(walletsTable)
+	sb.Where(sb.IsNotNull("deleted_at"), sb.LessThan("deleted_at", d.arg(before))).
+		OrderBy("id")
+	if lock {
+		sb.ForUpdate()
+	}
+
+	return sb.Build()
+}
+
+// PurgeWallets deletes the wallets for good
+func (d Dialect) PurgeWallets(in []uint64) (string, []any) {
+	db := d.Flavor.NewDeleteBuilder().
+		DeleteFrom(walletsTable)
+	db.Where(db.In("id", ids(in)...))
+
+	return db.Build()
+}
+
+// AddWalletAmount adds delta to the amount of the wallet accessible to the user
+func (d Dialect) AddWalletAmount(userID, id uint64, delta model.Money) (string, []any) {
+	ub := d.Flavor.NewUpdateBuilder().
+		Update(walletsTable)
+	ub.Set(
+		ub.Add("amount", d.arg(delta)),
+		d.updatedNow(),
+		"version = version + 1",
+	).Where(ub.E("id", id), d.accessible(&ub.Cond, userID))
+
+	return ub.Build()
+}
+
+// RevertWalletAmount adds delta to the amount of the wallet for no user
+func (d Dialect) RevertWalletAmount(id uint64, delta model.Money) (string, []any) {
+	ub := d.Flavor.NewUpdateBuilder().
+		Update(walletsTable)
+	ub.Set(
+		ub.Add("amount", d.arg(delta)),
+		d.updatedNow(),
+		"version = version + 1",
+	).Where(ub.E("id", id))
+
+	return ub.Build()
+}
+
+// WalletsTransfers selects the transfers of the wallets as the wallet ids, amount, received amount and commission,
+// the rows Reverts takes
+func (d Dialect) WalletsTransfers(in []uint64) (string, []any) {
+	sb := d.Flavor.NewSelectBuilder().
+		Select("t.from_wallet_id", "t.to_wallet_id", "t.amount", "t.received_amount", "COALESCE(c.amount, 0)").
+		From(transfersTable+" t").
+		JoinWithOption(sqlbuilder.LeftJoin, transactionsTable+" c", "c.transfer_id = t.id")
+	sb.Where(sb.Or(sb.In("t.from_wallet_id", ids(in)...), sb.In("t.to_wallet_id", ids(in)...)))
+
+	return sb.Build()
+}
+
+// Reverts sums up what the transfers of the deleted wallets take off the amounts of the other wallets.
+// The amounts are taken as they are stored, in the minor units of model.Unbound
+type Reverts struct {
+	deleted      map[uint64]bool
+	counterparts []uint64
+	deltas       map[uint64]int64
+}
+
+func NewReverts(deleted []uint64) *Reverts {
+	r := &Reverts{deleted: make(map[uint64]bool, len(deleted)), deltas: make(map[uint64]int64)}
+	for _, id := range deleted {
+		r.deleted[id] = true
+	}
+	return r
+}
+
+// Add reverts the transfer on the wallet that stays
+func (r *Reverts) Add(fromID, toID uint64, amount, received, commission model.Money) {
+	if !r.deleted[toID] {
+		if _, ok := r.deltas[toID]; !ok {
+			r.counterparts = append(r.counterparts, toID)
+		}
+		r.deltas[toID] -= received.Amount
+	}
+	if !r.deleted[fromID] {
+		if _, ok := r.deltas[fromID]; !ok {
+			r.counterparts = append(r.counterparts, fromID)
+		}
+		r.deltas[fromID] += amount.Amount + commission.Amount
+	}
+}
+
+// Wallets returns ids of the wallets that stay in the order of ids, so concurrent purges lock the wallets alike
+func (r *Reverts) Wallets() []uint64 {
+	sort.Slice(r.counterparts, func(i, j int) bool { return r.counterparts[i] < r.counterparts[j] })
+	return r.counterparts
+}
+
+// Delta returns what the transfers take off the amount of the wallet
+func (r *Reverts) Delta(id uint64) model.Money {
+	return model.Money{Amount: r.deltas[id], Currency: model.Unbound}
+}
@@ -0,0 +1,71 @@
+package sqlquery_test
+
+import (
+	"testing"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/mustan989/wallet/app/internal/repository/sqlquery"
+	"github.com/mustan989/wallet/model"
+)
+
+func TestReverts(t *testing.T) {
+	reverts := NewReverts([]uint64{1, 2})
+	reverts.Add(1, 5, stored(1000), stored(900), stored(10))
+	reverts.Add(3, 2, stored(500), stored(500), stored(0))
+	reverts.Add(1, 2, stored(700), stored(700), stored(5))
+	reverts.Add(4, 1, stored(200), stored(300), stored(1))
+	reverts.Add(3, 1, stored(100), stored(100), stored(0))
+
+	require.Equal(t, []uint64{3, 4, 5}, reverts.Wallets())
+	require.Equal(t, stored(600), reverts.Delta(3))
+	require.Equal(t, stored(201), reverts.Delta(4))
+	require.Equal(t, stored(-900), reverts.Delta(5))
+	require.Equal(t, stored(0), reverts.Delta(1))
+}
+
+func TestDialect_FindWallets(t *testing.T) {
+	postgres := Dialect{Flavor: sqlbuilder.PostgreSQL, Now: "default"}
+	sqlite := Dialect{Flavor: sqlbuilder.SQLite, Now: "CURRENT_TIMESTAMP", Arg: func(v any) any {
+		if v, ok := v.(model.Money); ok {
+			return v.Amount
+		}
+		return v
+	}}
+
+	tests := []struct {
+		name    string
+		dialect Dialect
+		filter  *model.WalletFilter
+		query   string
+		args    []any
+	}{
+		{"PostgreSQL offset", postgres, &model.WalletFilter{Filter: model.Filter{Offset: 10}},
+			"SELECT id, owner_id, name, description, currency, amount, personal, created_at, updated_at, deleted_at, version FROM wallets " +
+				"WHERE (owner_id = $1 OR (NOT personal AND id IN (SELECT wallet_id FROM wallet_members WHERE user_id = $2))) AND deleted_at IS NULL " +
+				"ORDER BY id DESC OFFSET 10",
+			[]any{uint64(1), uint64(1)}},
+		{"SQLite offset", sqlite, &model.WalletFilter{Filter: model.Filter{Offset: 10}},
+			"SELECT id, owner_id, name, description, currency, amount, personal, created_at, updated_at, deleted_at, version FROM wallets " +
+				"WHERE (owner_id = ? OR (NOT personal AND id IN (SELECT wallet_id FROM wallet_members WHERE user_id = ?))) AND deleted_at IS NULL " +
+				"ORDER BY id DESC LIMIT 9223372036854775807 OFFSET 10",
+			[]any{uint64(1), uint64(1)}},
+		{"SQLite amount", sqlite, &model.WalletFilter{AmountMin: &model.Money{Amount: 100, Currency: model.Unbound}},
+			"SELECT id, owner_id, name, description, currency, amount, personal, created_at, updated_at, deleted_at, version FROM wallets " +
+				"WHERE (owner_id = ? OR (NOT personal AND id IN (SELECT wallet_id FROM wallet_members WHERE user_id = ?))) AND amount >= ? AND deleted_at IS NULL " +
+				"ORDER BY id DESC",
+			[]any{uint64(1), uint64(1), int64(100)}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			query, args, err := test.dialect.FindWallets(1, test.filter)
+			require.NoError(t, err)
+			require.Equal(t, test.query, query)
+			require.Equal(t, test.args, args)
+		})
+	}
+}
+
+func stored(amount int64) model.Money { return model.Money{Amount: amount, Currency: model.Unbound} }
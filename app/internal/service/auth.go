@@ -0,0 +1,277 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/pkg/logger"
+	"github.com/mustan989/wallet/pkg/token"
+	"github.com/mustan989/wallet/repository"
+	"github.com/mustan989/wallet/service"
+)
+
+const (
+	accessTokenType  = "access"
+	refreshTokenType = "refresh"
+
+	minPasswordLength = 8
+	// maxPasswordLength is the limit of bcrypt, longer passwords are truncated by it silently
+	maxPasswordLength = 72
+)
+
+type AuthOption func(a *auth)
+
+func WithAuthLogger(log logger.Logger) AuthOption {
+	return func(a *auth) { a.log = log }
+}
+
+// WithAuthTTL sets lifetimes of the access and refresh tokens, zero keeps the default
+func WithAuthTTL(access, refresh time.Duration) AuthOption {
+	return func(a *auth) {
+		if access > 0 {
+			a.accessTTL = access
+		}
+		if refresh > 0 {
+			a.refreshTTL = refresh
+		}
+	}
+}
+
+// WithAuthCost sets the bcrypt cost of the password hashes
+func WithAuthCost(cost int) AuthOption {
+	return func(a *auth) { a.cost = cost }
+}
+
+func NewAuth(users repository.User, tokens repository.Token, signer *token.Signer, options ...AuthOption) service.Auth {
+	a := &auth{
+		log:        logger.Default(),
+		users:      users,
+		tokens:     tokens,
+		signer:     signer,
+		accessTTL:  15 * time.Minute,
+		refreshTTL: 30 * 24 * time.Hour,
+		cost:       bcrypt.DefaultCost,
+	}
+
+	for _, option := range options {
+		option(a)
+	}
+
+	// compared with on login of unknown users, so they take as long as the known ones
+	a.dummyHash, _ = bcrypt.GenerateFromPassword([]byte("dummy password"), a.cost)
+
+	return a
+}
+
+type auth struct {
+	log logger.Logger
+
+	users  repository.User
+	tokens repository.Token
+	signer *token.Signer
+
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+	cost       int
+	dummyHash  []byte
+}
+
+func (a *auth) Register(ctx context.Context, request *service.AuthRegisterRequest) (*service.AuthRegisterResponse, error) {
+	if err := validateUser(request.Data); err != nil {
+		return nil, err
+	}
+	if len(request.Password) < minPasswordLength || len(request.Password) > maxPasswordLength {
+		return nil, fmt.Errorf("%w: password must be %d to %d bytes long", service.ErrInvalidArgument, minPasswordLength, maxPasswordLength)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(request.Password), a.cost)
+	if err != nil {
+		a.log.Errorf("Error hashing password: %s", err)
+		return nil, err
+	}
+	request.Data.PasswordHash = hash
+
+	if err = a.users.Create(ctx, request.Data); err != nil {
+		a.log.Errorf("Error creating user: %s", err)
+		return nil, err
+	}
+	return &service.AuthRegisterResponse{Data: request.Data}, nil
+}
+
+func (a *auth) Login(ctx context.Context, request *service.AuthLoginRequest) (*service.AuthLoginResponse, error) {
+	user, err := a.users.FindByEmail(ctx, strings.TrimSpace(request.Email))
+	if errors.Is(err, repository.ErrUserNotFound) {
+		_ = bcrypt.CompareHashAndPassword(a.dummyHash, []byte(request.Password))
+		return nil, fmt.Errorf("%w: invalid email or password", service.ErrUnauthenticated)
+	}
+	if err != nil {
+		a.log.Errorf("Error getting user by email: %s", err)
+		return nil, err
+	}
+
+	if bcrypt.CompareHashAndPassword(user.PasswordHash, []byte(request.Password)) != nil {
+		return nil, fmt.Errorf("%w: invalid email or password", service.ErrUnauthenticated)
+	}
+
+	tokens, err := a.issue(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &service.AuthLoginResponse{Data: tokens}, nil
+}
+
+func (a *auth) Refresh(ctx context.Context, request *service.AuthRefreshRequest) (*service.AuthRefreshResponse, error) {
+	claims, userID, err := a.verify(ctx, request.RefreshToken, refreshTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	// refresh tokens are single use
+	if err = a.revoke(ctx, claims); err != nil {
+		return nil, err
+	}
+
+	tokens, err := a.issue(userID)
+	if err != nil {
+		return nil, err
+	}
+	return &service.AuthRefreshResponse{Data: tokens}, nil
+}
+
+func (a *auth) Logout(ctx context.Context, request *service.AuthLogoutRequest) (*service.AuthLogoutResponse, error) {
+	if request.AccessToken == "" && request.RefreshToken == "" {
+		return nil, fmt.Errorf("%w: access or refresh token is required", service.ErrUnauthenticated)
+	}
+
+	var (
+		revoked []*token.Claims
+		userID  uint64
+	)
+	if request.AccessToken != "" {
+		access, accessUserID, err := a.verify(ctx, request.AccessToken, accessTokenType)
+		// an expired access token grants nothing anymore, the refresh token alone is enough then
+		if err != nil && (request.RefreshToken == "" || !errors.Is(err, service.ErrUnauthenticated)) {
+			return nil, err
+		}
+		if err == nil {
+			revoked, userID = append(revoked, access), accessUserID
+		}
+	}
+	if request.RefreshToken != "" {
+		refresh, refreshUserID, err := a.verify(ctx, request.RefreshToken, refreshTokenType)
+		if err != nil {
+			return nil, err
+		}
+		if userID != 0 && refreshUserID != userID {
+			return nil, fmt.Errorf("%w: refresh token belongs to other user", service.ErrUnauthenticated)
+		}
+		revoked = append(revoked, refresh)
+	}
+
+	for _, claims := range revoked {
+		if err := a.revoke(ctx, claims); err != nil {
+			return nil, err
+		}
+	}
+
+	return &service.AuthLogoutResponse{}, nil
+}
+
+func (a *auth) Authenticate(ctx context.Context, request *service.AuthAuthenticateRequest) (*service.AuthAuthenticateResponse, error) {
+	_, userID, err := a.verify(ctx, request.AccessToken, accessTokenType)
+	if err != nil {
+		return nil, err
+	}
+	return &service.AuthAuthenticateResponse{UserID: userID}, nil
+}
+
+// verify parses the token of the type and makes sure it is not revoked
+func (a *auth) verify(ctx context.Context, raw, typ string) (*token.Claims, uint64, error) {
+	claims, err := a.signer.Parse(raw)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: %s", service.ErrUnauthenticated, err)
+	}
+	if claims.Type != typ {
+		return nil, 0, fmt.Errorf("%w: %s token expected", service.ErrUnauthenticated, typ)
+	}
+
+	userID, err := strconv.ParseUint(claims.Subject, 10, 64)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: %s", service.ErrUnauthenticated, token.ErrMalformed)
+	}
+
+	revoked, err := a.tokens.IsRevoked(ctx, claims.ID)
+	if err != nil {
+		a.log.Errorf("Error checking token revocation: %s", err)
+		return nil, 0, err
+	}
+	if revoked {
+		return nil, 0, fmt.Errorf("%w: token is revoked", service.ErrUnauthenticated)
+	}
+
+	return claims, userID, nil
+}
+
+// revoke fails if the token has been revoked since it was verified, e.g. by a concurrent refresh
+func (a *auth) revoke(ctx context.Context, claims *token.Claims) error {
+	revoked, err := a.tokens.Revoke(ctx, claims.ID, time.Unix(claims.ExpiresAt, 0))
+	if err != nil {
+		a.log.Errorf("Error revoking token: %s", err)
+		return err
+	}
+	if !revoked {
+		return fmt.Errorf("%w: token is revoked", service.ErrUnauthenticated)
+	}
+	return nil
+}
+
+// issue signs a pair of tokens for the user
+func (a *auth) issue(userID uint64) (*model.Tokens, error) {
+	now := time.Now()
+
+	access, err := a.sign(userID, accessTokenType, now, a.accessTTL)
+	if err != nil {
+		return nil, err
+	}
+	refresh, err := a.sign(userID, refreshTokenType, now, a.refreshTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Tokens{
+		AccessToken:  access,
+		RefreshToken: refresh,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(a.accessTTL / time.Second),
+	}, nil
+}
+
+func (a *auth) sign(userID uint64, typ string, now time.Time, ttl time.Duration) (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		a.log.Errorf("Error generating token id: %s", err)
+		return "", err
+	}
+
+	signed, err := a.signer.Sign(&token.Claims{
+		ID:        hex.EncodeToString(id),
+		Subject:   strconv.FormatUint(userID, 10),
+		Type:      typ,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(ttl).Unix(),
+	})
+	if err != nil {
+		a.log.Errorf("Error signing token: %s", err)
+		return "", err
+	}
+	return signed, nil
+}
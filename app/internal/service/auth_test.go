@@ -0,0 +1,286 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	mock_repository "github.com/mustan989/wallet/app/internal/repository/mock"
+	. "github.com/mustan989/wallet/app/internal/service"
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/pkg/token"
+	"github.com/mustan989/wallet/repository"
+	"github.com/mustan989/wallet/service"
+)
+
+var signer = token.NewSigner([]byte("secret"))
+
+func newAuth(ctl *gomock.Controller) (service.Auth, *mock_repository.MockUser, *mock_repository.MockToken) {
+	users := mock_repository.NewMockUser(ctl)
+	tokens := mock_repository.NewMockToken(ctl)
+	return NewAuth(users, tokens, signer, WithAuthLogger(log), WithAuthCost(bcrypt.MinCost)), users, tokens
+}
+
+func TestAuth_Register(t *testing.T) {
+	ctx := context.Background()
+	ctl := gomock.NewController(t)
+	svc, users, _ := newAuth(ctl)
+
+	users.EXPECT().
+		Create(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, data *model.User) error {
+			require.NoError(t, bcrypt.CompareHashAndPassword(data.PasswordHash, []byte("password")))
+			data.ID = 1
+			return nil
+		})
+
+	response, err := svc.Register(ctx, &service.AuthRegisterRequest{
+		Data:     &model.User{Name: " name ", Email: " Name@Example.com"},
+		Password: "password",
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), response.Data.ID)
+	require.Equal(t, "name", response.Data.Name)
+	require.Equal(t, "name@example.com", response.Data.Email)
+}
+
+func TestAuth_RegisterError(t *testing.T) {
+	subtests := [...]struct {
+		name     string
+		input    *model.User
+		password string
+	}{
+		{"No name", &model.User{Email: "name@example.com"}, "password"},
+		{"No email", &model.User{Name: "name"}, "password"},
+		{"Invalid email", &model.User{Name: "name", Email: "name.example.com"}, "password"},
+		{"Email with name", &model.User{Name: "name", Email: "Name <name@example.com>"}, "password"},
+		{"Short password", &model.User{Name: "name", Email: "name@example.com"}, "pass"},
+		{"Long password", &model.User{Name: "name", Email: "name@example.com"}, string(make([]byte, 73))},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			svc, _, _ := newAuth(gomock.NewController(t))
+
+			response, err := svc.Register(context.Background(), &service.AuthRegisterRequest{Data: subtest.input, Password: subtest.password})
+			require.Zero(t, response)
+			require.ErrorIs(t, err, service.ErrInvalidArgument)
+		})
+	}
+}
+
+func TestAuth_Login(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	require.NoError(t, err)
+	user := &model.User{ID: 1, Name: "name", Email: "name@example.com", PasswordHash: hash}
+
+	subtests := [...]struct {
+		name     string
+		user     *model.User
+		findErr  error
+		password string
+		err      error
+	}{
+		{"Valid", user, nil, "password", nil},
+		{"Wrong password", user, nil, "wrong password", service.ErrUnauthenticated},
+		{"Unknown user", nil, repository.ErrUserNotFound, "password", service.ErrUnauthenticated},
+		{"Repository error", nil, errors.New("error"), "password", errors.New("error")},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			ctx := context.Background()
+			svc, users, _ := newAuth(gomock.NewController(t))
+
+			users.EXPECT().FindByEmail(ctx, "name@example.com").Return(subtest.user, subtest.findErr)
+
+			response, err := svc.Login(ctx, &service.AuthLoginRequest{Email: " name@example.com ", Password: subtest.password})
+			if subtest.err != nil {
+				require.Zero(t, response)
+				if errors.Is(subtest.err, service.ErrUnauthenticated) {
+					require.ErrorIs(t, err, subtest.err)
+				} else {
+					require.Equal(t, subtest.err, err)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "Bearer", response.Data.TokenType)
+			require.Equal(t, int64(15*60), response.Data.ExpiresIn)
+
+			access, err := signer.Parse(response.Data.AccessToken)
+			require.NoError(t, err)
+			require.Equal(t, "1", access.Subject)
+			require.Equal(t, "access", access.Type)
+
+			refresh, err := signer.Parse(response.Data.RefreshToken)
+			require.NoError(t, err)
+			require.Equal(t, "1", refresh.Subject)
+			require.Equal(t, "refresh", refresh.Type)
+		})
+	}
+}
+
+func sign(t *testing.T, subject, typ string) (string, *token.Claims) {
+	now := time.Now()
+	claims := &token.Claims{ID: typ + "-id", Subject: subject, Type: typ, IssuedAt: now.Unix(), ExpiresAt: now.Add(time.Hour).Unix()}
+	signed, err := signer.Sign(claims)
+	require.NoError(t, err)
+	return signed, claims
+}
+
+func TestAuth_Refresh(t *testing.T) {
+	ctx := context.Background()
+	svc, _, tokens := newAuth(gomock.NewController(t))
+
+	refresh, claims := sign(t, "1", "refresh")
+
+	gomock.InOrder(
+		tokens.EXPECT().IsRevoked(ctx, claims.ID).Return(false, nil),
+		tokens.EXPECT().Revoke(ctx, claims.ID, time.Unix(claims.ExpiresAt, 0)).Return(true, nil),
+	)
+
+	response, err := svc.Refresh(ctx, &service.AuthRefreshRequest{RefreshToken: refresh})
+	require.NoError(t, err)
+
+	access, err := signer.Parse(response.Data.AccessToken)
+	require.NoError(t, err)
+	require.Equal(t, "1", access.Subject)
+}
+
+func TestAuth_RefreshError(t *testing.T) {
+	refresh, claims := sign(t, "1", "refresh")
+	access, _ := sign(t, "1", "access")
+	invalid, invalidClaims := sign(t, "user", "refresh")
+
+	subtests := [...]struct {
+		name    string
+		token   string
+		claims  *token.Claims
+		revoked bool
+	}{
+		{"Malformed", "token", nil, false},
+		{"Access token", access, nil, false},
+		{"Invalid subject", invalid, invalidClaims, false},
+		{"Revoked", refresh, claims, true},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			ctx := context.Background()
+			svc, _, tokens := newAuth(gomock.NewController(t))
+
+			if subtest.revoked {
+				tokens.EXPECT().IsRevoked(ctx, subtest.claims.ID).Return(true, nil)
+			}
+
+			response, err := svc.Refresh(ctx, &service.AuthRefreshRequest{RefreshToken: subtest.token})
+			require.Zero(t, response)
+			require.ErrorIs(t, err, service.ErrUnauthenticated)
+		})
+	}
+}
+
+func TestAuth_RefreshConcurrent(t *testing.T) {
+	ctx := context.Background()
+	svc, _, tokens := newAuth(gomock.NewController(t))
+
+	refresh, claims := sign(t, "1", "refresh")
+
+	// the other refresh revoked the token between the check and the revocation
+	gomock.InOrder(
+		tokens.EXPECT().IsRevoked(ctx, claims.ID).Return(false, nil),
+		tokens.EXPECT().Revoke(ctx, claims.ID, time.Unix(claims.ExpiresAt, 0)).Return(false, nil),
+	)
+
+	response, err := svc.Refresh(ctx, &service.AuthRefreshRequest{RefreshToken: refresh})
+	require.Zero(t, response)
+	require.ErrorIs(t, err, service.ErrUnauthenticated)
+}
+
+func TestAuth_Logout(t *testing.T) {
+	ctx := context.Background()
+	svc, _, tokens := newAuth(gomock.NewController(t))
+
+	access, accessClaims := sign(t, "1", "access")
+	refresh, refreshClaims := sign(t, "1", "refresh")
+
+	tokens.EXPECT().IsRevoked(ctx, accessClaims.ID).Return(false, nil)
+	tokens.EXPECT().IsRevoked(ctx, refreshClaims.ID).Return(false, nil)
+	tokens.EXPECT().Revoke(ctx, accessClaims.ID, time.Unix(accessClaims.ExpiresAt, 0)).Return(true, nil)
+	tokens.EXPECT().Revoke(ctx, refreshClaims.ID, time.Unix(refreshClaims.ExpiresAt, 0)).Return(true, nil)
+
+	_, err := svc.Logout(ctx, &service.AuthLogoutRequest{AccessToken: access, RefreshToken: refresh})
+	require.NoError(t, err)
+}
+
+func TestAuth_LogoutRefresh(t *testing.T) {
+	now := time.Now()
+	expired, err := signer.Sign(&token.Claims{ID: "expired-id", Subject: "1", Type: "access", IssuedAt: now.Add(-time.Hour).Unix(), ExpiresAt: now.Add(-time.Minute).Unix()})
+	require.NoError(t, err)
+
+	subtests := [...]struct {
+		name   string
+		access string
+	}{
+		{"Refresh alone", ""},
+		{"Expired access", expired},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			ctx := context.Background()
+			svc, _, tokens := newAuth(gomock.NewController(t))
+
+			refresh, claims := sign(t, "1", "refresh")
+
+			tokens.EXPECT().IsRevoked(ctx, claims.ID).Return(false, nil)
+			tokens.EXPECT().Revoke(ctx, claims.ID, time.Unix(claims.ExpiresAt, 0)).Return(true, nil)
+
+			_, err := svc.Logout(ctx, &service.AuthLogoutRequest{AccessToken: subtest.access, RefreshToken: refresh})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestAuth_LogoutNoToken(t *testing.T) {
+	svc, _, _ := newAuth(gomock.NewController(t))
+
+	response, err := svc.Logout(context.Background(), &service.AuthLogoutRequest{})
+	require.Zero(t, response)
+	require.ErrorIs(t, err, service.ErrUnauthenticated)
+}
+
+func TestAuth_LogoutOtherUser(t *testing.T) {
+	ctx := context.Background()
+	svc, _, tokens := newAuth(gomock.NewController(t))
+
+	access, accessClaims := sign(t, "1", "access")
+	refresh, refreshClaims := sign(t, "2", "refresh")
+
+	tokens.EXPECT().IsRevoked(ctx, accessClaims.ID).Return(false, nil)
+	tokens.EXPECT().IsRevoked(ctx, refreshClaims.ID).Return(false, nil)
+
+	response, err := svc.Logout(ctx, &service.AuthLogoutRequest{AccessToken: access, RefreshToken: refresh})
+	require.Zero(t, response)
+	require.ErrorIs(t, err, service.ErrUnauthenticated)
+}
+
+func TestAuth_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	svc, _, tokens := newAuth(gomock.NewController(t))
+
+	access, claims := sign(t, "1", "access")
+
+	tokens.EXPECT().IsRevoked(ctx, claims.ID).Return(false, nil)
+
+	response, err := svc.Authenticate(ctx, &service.AuthAuthenticateRequest{AccessToken: access})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), response.UserID)
+}
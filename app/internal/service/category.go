@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/pkg/logger"
+	"github.com/mustan989/wallet/repository"
+	"github.com/mustan989/wallet/service"
+)
+
+// maxCategoryDepth limits the nesting of categories
+const maxCategoryDepth = 8
+
+type CategoryOption func(c *category)
+
+func WithCategoryLogger(log logger.Logger) CategoryOption {
+	return func(c *category) { c.log = log }
+}
+
+func NewCategory(repo repository.Category, options ...CategoryOption) service.Category {
+	c := &category{
+		log:  logger.Default(),
+		repo: repo,
+	}
+
+	for _, option := range options {
+		option(c)
+	}
+
+	return c
+}
+
+type category struct {
+	log logger.Logger
+
+	repo repository.Category
+}
+
+func (c *category) Count(ctx context.Context, request *service.CategoryCountRequest) (*service.CategoryCountResponse, error) {
+	count, err := c.repo.CountAll(ctx, request.Filter)
+	if err != nil {
+		c.log.Errorf("Error getting category count: %s", err)
+		return nil, err
+	}
+	return &service.CategoryCountResponse{Count: count}, nil
+}
+
+func (c *category) GetAll(ctx context.Context, request *service.CategoryGetAllRequest) (*service.CategoryGetAllResponse, error) {
+	data, err := c.repo.FindAll(ctx, request.Filter)
+	if err != nil {
+		c.log.Errorf("Error getting categories: %s", err)
+		return nil, err
+	}
+
+	count, err := c.Count(ctx, &service.CategoryCountRequest{Filter: request.Filter})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &service.CategoryGetAllResponse{
+		Data:  data,
+		Total: count.Count,
+	}
+	if request.Filter.Full(len(data)) {
+		response.Next = request.Filter.Next(data[len(data)-1])
+	}
+	return response, nil
+}
+
+func (c *category) GetByID(ctx context.Context, request *service.CategoryGetByIDRequest) (*service.CategoryGetByIDResponse, error) {
+	data, err := c.repo.FindByID(ctx, request.ID)
+	if err != nil {
+		c.log.Errorf("Error getting category by id %d: %s", request.ID, err)
+		return nil, err
+	}
+	return &service.CategoryGetByIDResponse{Data: data}, nil
+}
+
+func (c *category) Create(ctx context.Context, request *service.CategoryCreateRequest) (*service.CategoryCreateResponse, error) {
+	if err := c.validate(ctx, request.Data); err != nil {
+		return nil, err
+	}
+
+	if err := c.repo.Create(ctx, request.Data); err != nil {
+		c.log.Errorf("Error creating category: %s", err)
+		return nil, err
+	}
+	return &service.CategoryCreateResponse{Data: request.Data}, nil
+}
+
+func (c *category) Update(ctx context.Context, request *service.CategoryUpdateRequest) (*service.CategoryUpdateResponse, error) {
+	if err := c.validate(ctx, request.Data); err != nil {
+		return nil, err
+	}
+
+	old, err := c.repo.FindByID(ctx, request.Data.ID)
+	if err != nil {
+		return nil, err
+	}
+	// the children and transactions of the category must stay of its type
+	if old.Type != request.Data.Type {
+		used, err := c.repo.Used(ctx, old.ID)
+		if err != nil {
+			c.log.Errorf("Error checking category usage: %s", err)
+			return nil, err
+		}
+		if used {
+			return nil, fmt.Errorf("%w: type of a category with children or transactions can not change", service.ErrInvalidArgument)
+		}
+	}
+
+	if err = c.repo.Update(ctx, request.Data); err != nil {
+		c.log.Errorf("Error updating category: %s", err)
+		return nil, err
+	}
+	return &service.CategoryUpdateResponse{Data: request.Data}, nil
+}
+
+func (c *category) DeleteByID(ctx context.Context, request *service.CategoryDeleteByIDRequest) (*service.CategoryDeleteByIDResponse, error) {
+	data, err := c.repo.FindByID(ctx, request.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Archive {
+		archived, err := c.repo.Archive(ctx, data.ID, time.Now())
+		if err != nil {
+			c.log.Errorf("Error archiving category: %s", err)
+			return nil, err
+		}
+		return &service.CategoryDeleteByIDResponse{Data: archived}, nil
+	}
+
+	if request.ReassignTo != nil {
+		if *request.ReassignTo == request.ID {
+			return nil, fmt.Errorf("%w: category can not be reassigned to itself", service.ErrInvalidArgument)
+		}
+		target, err := c.repo.FindByID(ctx, *request.ReassignTo)
+		if err != nil {
+			return nil, err
+		}
+		if target.Type != data.Type {
+			return nil, fmt.Errorf("%w: transactions can be reassigned to %s category only", service.ErrInvalidArgument, data.Type)
+		}
+		if target.ArchivedAt != nil {
+			return nil, fmt.Errorf("%w: transactions can not be reassigned to an archived category", service.ErrInvalidArgument)
+		}
+	}
+
+	deleted, err := c.repo.DeleteByID(ctx, request.ID, request.ReassignTo)
+	if err != nil {
+		c.log.Errorf("Error deleting category: %s", err)
+		return nil, err
+	}
+	return &service.CategoryDeleteByIDResponse{Data: deleted}, nil
+}
+
+// validate checks the fields of the category and that its parent is of the same type and is not its descendant
+func (c *category) validate(ctx context.Context, data *model.Category) error {
+	data.Name = strings.TrimSpace(data.Name)
+	if data.Name == "" {
+		return fmt.Errorf("%w: name is required", service.ErrInvalidArgument)
+	}
+	if !data.Type.Valid() {
+		return fmt.Errorf("%w: type must be %q or %q", service.ErrInvalidArgument, model.Income, model.Expense)
+	}
+
+	for depth, parentID := 1, data.ParentID; parentID != nil; depth++ {
+		if data.ID != 0 && *parentID == data.ID {
+			return fmt.Errorf("%w: category can not be nested in itself", service.ErrInvalidArgument)
+		}
+		if depth > maxCategoryDepth {
+			return fmt.Errorf("%w: categories can be nested %d levels deep", service.ErrInvalidArgument, maxCategoryDepth)
+		}
+
+		parent, err := c.repo.FindByID(ctx, *parentID)
+		if err != nil {
+			return err
+		}
+		if parent.Type != data.Type {
+			return fmt.Errorf("%w: parent category must be of %s type", service.ErrInvalidArgument, data.Type)
+		}
+
+		parentID = parent.ParentID
+	}
+
+	return nil
+}
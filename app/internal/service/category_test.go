@@ -0,0 +1,195 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	mock_repository "github.com/mustan989/wallet/app/internal/repository/mock"
+	. "github.com/mustan989/wallet/app/internal/service"
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/pkg/helper"
+	"github.com/mustan989/wallet/repository"
+	"github.com/mustan989/wallet/service"
+)
+
+func TestCategory_Create(t *testing.T) {
+	ctx := context.Background()
+	ctl := gomock.NewController(t)
+	repo := mock_repository.NewMockCategory(ctl)
+	svc := NewCategory(repo, WithCategoryLogger(log))
+
+	data := &model.Category{ParentID: helper.Uint64(1), Name: " Groceries ", Type: model.Expense}
+
+	repo.EXPECT().
+		FindByID(ctx, uint64(1)).
+		Return(&model.Category{ID: 1, Name: "Food", Type: model.Expense}, nil)
+	repo.EXPECT().
+		Create(ctx, data).
+		DoAndReturn(func(_ context.Context, data *model.Category) error {
+			data.ID = 2
+			return nil
+		})
+
+	response, err := svc.Create(ctx, &service.CategoryCreateRequest{Data: data})
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), response.Data.ID)
+	require.Equal(t, "Groceries", response.Data.Name)
+}
+
+func TestCategory_CreateError(t *testing.T) {
+	subtests := [...]struct {
+		name    string
+		input   *model.Category
+		parents []*model.Category
+		err     error
+	}{
+		{"No name", &model.Category{Name: " ", Type: model.Expense}, nil, service.ErrInvalidArgument},
+		{"Invalid type", &model.Category{Name: "Food", Type: "transfer"}, nil, service.ErrInvalidArgument},
+		{"Parent not found", &model.Category{ParentID: helper.Uint64(1), Name: "Groceries", Type: model.Expense}, nil, repository.ErrCategoryNotFound},
+		{"Parent of other type", &model.Category{ParentID: helper.Uint64(1), Name: "Groceries", Type: model.Expense}, []*model.Category{
+			{ID: 1, Name: "Salary", Type: model.Income},
+		}, service.ErrInvalidArgument},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			ctx := context.Background()
+			ctl := gomock.NewController(t)
+			repo := mock_repository.NewMockCategory(ctl)
+			svc := NewCategory(repo, WithCategoryLogger(log))
+
+			for _, parent := range subtest.parents {
+				repo.EXPECT().FindByID(ctx, parent.ID).Return(parent, nil)
+			}
+			if subtest.parents == nil && subtest.input.ParentID != nil {
+				repo.EXPECT().FindByID(ctx, *subtest.input.ParentID).Return(nil, repository.ErrCategoryNotFound)
+			}
+
+			response, err := svc.Create(ctx, &service.CategoryCreateRequest{Data: subtest.input})
+			require.Zero(t, response)
+			require.ErrorIs(t, err, subtest.err)
+		})
+	}
+}
+
+func TestCategory_UpdateCycle(t *testing.T) {
+	ctx := context.Background()
+	ctl := gomock.NewController(t)
+	repo := mock_repository.NewMockCategory(ctl)
+	svc := NewCategory(repo, WithCategoryLogger(log))
+
+	// 1 <- 2 <- 3, moving 1 under 3
+	repo.EXPECT().
+		FindByID(ctx, uint64(3)).
+		Return(&model.Category{ID: 3, ParentID: helper.Uint64(2), Name: "Bakery", Type: model.Expense}, nil)
+	repo.EXPECT().
+		FindByID(ctx, uint64(2)).
+		Return(&model.Category{ID: 2, ParentID: helper.Uint64(1), Name: "Groceries", Type: model.Expense}, nil)
+
+	data := &model.Category{ID: 1, ParentID: helper.Uint64(3), Name: "Food", Type: model.Expense}
+
+	response, err := svc.Update(ctx, &service.CategoryUpdateRequest{Data: data})
+	require.Zero(t, response)
+	require.ErrorIs(t, err, service.ErrInvalidArgument)
+}
+
+func TestCategory_UpdateType(t *testing.T) {
+	subtests := [...]struct {
+		name string
+		used bool
+		err  error
+	}{
+		{"Unused", false, nil},
+		{"Used", true, service.ErrInvalidArgument},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			ctx := context.Background()
+			ctl := gomock.NewController(t)
+			repo := mock_repository.NewMockCategory(ctl)
+			svc := NewCategory(repo, WithCategoryLogger(log))
+
+			data := &model.Category{ID: 1, Name: "Food", Type: model.Income}
+
+			repo.EXPECT().FindByID(ctx, uint64(1)).Return(&model.Category{ID: 1, Name: "Food", Type: model.Expense}, nil)
+			repo.EXPECT().Used(ctx, uint64(1)).Return(subtest.used, nil)
+			if subtest.err == nil {
+				repo.EXPECT().Update(ctx, data).Return(nil)
+			}
+
+			_, err := svc.Update(ctx, &service.CategoryUpdateRequest{Data: data})
+			require.ErrorIs(t, err, subtest.err)
+		})
+	}
+}
+
+func TestCategory_DeleteByID(t *testing.T) {
+	archivedAt := time.Now()
+
+	subtests := [...]struct {
+		name    string
+		request *service.CategoryDeleteByIDRequest
+		target  *model.Category
+		err     error
+	}{
+		{"Delete", &service.CategoryDeleteByIDRequest{ID: 1}, nil, nil},
+		{"Reassign", &service.CategoryDeleteByIDRequest{ID: 1, ReassignTo: helper.Uint64(2)}, &model.Category{ID: 2, Name: "Other", Type: model.Expense}, nil},
+		{"Reassign to itself", &service.CategoryDeleteByIDRequest{ID: 1, ReassignTo: helper.Uint64(1)}, nil, service.ErrInvalidArgument},
+		{"Reassign to other type", &service.CategoryDeleteByIDRequest{ID: 1, ReassignTo: helper.Uint64(2)}, &model.Category{ID: 2, Name: "Salary", Type: model.Income}, service.ErrInvalidArgument},
+		{"Reassign to archived", &service.CategoryDeleteByIDRequest{ID: 1, ReassignTo: helper.Uint64(2)}, &model.Category{ID: 2, Name: "Other", Type: model.Expense, ArchivedAt: &archivedAt}, service.ErrInvalidArgument},
+		{"In use", &service.CategoryDeleteByIDRequest{ID: 1}, nil, repository.ErrCategoryInUse},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			ctx := context.Background()
+			ctl := gomock.NewController(t)
+			repo := mock_repository.NewMockCategory(ctl)
+			svc := NewCategory(repo, WithCategoryLogger(log))
+
+			data := &model.Category{ID: 1, Name: "Food", Type: model.Expense}
+
+			repo.EXPECT().FindByID(ctx, uint64(1)).Return(data, nil)
+			if subtest.target != nil {
+				repo.EXPECT().FindByID(ctx, subtest.target.ID).Return(subtest.target, nil)
+			}
+			if subtest.err == nil {
+				repo.EXPECT().DeleteByID(ctx, uint64(1), subtest.request.ReassignTo).Return(data, nil)
+			}
+			if subtest.err == repository.ErrCategoryInUse {
+				repo.EXPECT().DeleteByID(ctx, uint64(1), nil).Return(nil, subtest.err)
+			}
+
+			response, err := svc.DeleteByID(ctx, subtest.request)
+			require.ErrorIs(t, err, subtest.err)
+			if subtest.err == nil {
+				require.Equal(t, &service.CategoryDeleteByIDResponse{Data: data}, response)
+			}
+		})
+	}
+}
+
+func TestCategory_DeleteByIDArchive(t *testing.T) {
+	ctx := context.Background()
+	ctl := gomock.NewController(t)
+	repo := mock_repository.NewMockCategory(ctl)
+	svc := NewCategory(repo, WithCategoryLogger(log))
+
+	data := &model.Category{ID: 1, Name: "Food", Type: model.Expense}
+
+	repo.EXPECT().FindByID(ctx, uint64(1)).Return(data, nil)
+	repo.EXPECT().
+		Archive(ctx, uint64(1), gomock.Any()).
+		DoAndReturn(func(_ context.Context, id uint64, at time.Time) (*model.Category, error) {
+			return &model.Category{ID: id, Name: "Food", Type: model.Expense, ArchivedAt: &at}, nil
+		})
+
+	response, err := svc.DeleteByID(ctx, &service.CategoryDeleteByIDRequest{ID: 1, Archive: true})
+	require.NoError(t, err)
+	require.NotNil(t, response.Data.ArchivedAt)
+}
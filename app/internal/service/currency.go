@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/pkg/currency"
+	"github.com/mustan989/wallet/service"
+)
+
+func NewCurrency(registry *currency.Registry) service.Currency {
+	return &currencies{registry: registry}
+}
+
+type currencies struct {
+	registry *currency.Registry
+}
+
+func (c *currencies) GetAll(_ context.Context, _ *service.CurrencyGetAllRequest) (*service.CurrencyGetAllResponse, error) {
+	return &service.CurrencyGetAllResponse{Data: c.registry.All()}, nil
+}
+
+// bind binds the amounts to the currency of the code, which must be registered and have enough minor digits
+// for them, e.g. JPY amounts have no cents
+func bind(code string, amounts ...*model.Money) error {
+	if err := model.Bind(code, amounts...); err != nil {
+		return fmt.Errorf("%w: %s", service.ErrInvalidArgument, err)
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/mustan989/wallet/app/internal/service"
+	"github.com/mustan989/wallet/pkg/currency"
+	"github.com/mustan989/wallet/service"
+)
+
+func TestCurrency_GetAll(t *testing.T) {
+	points := currency.Currency{Code: "PTS", Name: "Loyalty points", Symbol: "pt", Custom: true}
+	usd := currency.Currency{Code: "USD", Numeric: "840", Name: "US Dollar", Symbol: "$", MinorUnits: 2}
+
+	registry, err := currency.NewRegistry(usd, points)
+	require.NoError(t, err)
+
+	response, err := NewCurrency(registry).GetAll(context.Background(), &service.CurrencyGetAllRequest{})
+	require.NoError(t, err)
+	require.Equal(t, &service.CurrencyGetAllResponse{Data: []currency.Currency{points, usd}}, response)
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/pkg/logger"
+	"github.com/mustan989/wallet/repository"
+	"github.com/mustan989/wallet/service"
+)
+
+type MemberOption func(m *member)
+
+func WithMemberLogger(log logger.Logger) MemberOption {
+	return func(m *member) { m.log = log }
+}
+
+func NewMember(repo repository.Member, wallets repository.Wallet, options ...MemberOption) service.Member {
+	m := &member{
+		log:     logger.Default(),
+		repo:    repo,
+		wallets: wallets,
+	}
+
+	for _, option := range options {
+		option(m)
+	}
+
+	return m
+}
+
+type member struct {
+	log logger.Logger
+
+	repo    repository.Member
+	wallets repository.Wallet
+}
+
+func (m *member) GetAll(ctx context.Context, request *service.MemberGetAllRequest) (*service.MemberGetAllResponse, error) {
+	if err := authorize(ctx, m.repo, model.Viewer, request.WalletID); err != nil {
+		return nil, err
+	}
+
+	data, err := m.repo.FindAll(ctx, request.WalletID)
+	if err != nil {
+		m.log.Errorf("Error getting members of wallet %d: %s", request.WalletID, err)
+		return nil, err
+	}
+	return &service.MemberGetAllResponse{Data: data}, nil
+}
+
+func (m *member) Put(ctx context.Context, request *service.MemberPutRequest) (*service.MemberPutResponse, error) {
+	data := request.Data
+	if !data.Role.Valid() {
+		return nil, fmt.Errorf("%w: role must be %q, %q or %q", service.ErrInvalidArgument, model.Viewer, model.Editor, model.Owner)
+	}
+	if data.UserID == 0 {
+		return nil, fmt.Errorf("%w: user_id is required", service.ErrInvalidArgument)
+	}
+
+	if err := authorize(ctx, m.repo, model.Owner, data.WalletID); err != nil {
+		return nil, err
+	}
+
+	wallet, err := m.wallets.FindByID(ctx, data.WalletID)
+	if err != nil {
+		m.log.Errorf("Error getting wallet by id %d: %s", data.WalletID, err)
+		return nil, err
+	}
+	if wallet.Personal {
+		return nil, fmt.Errorf("%w: personal wallets cannot be shared", service.ErrInvalidArgument)
+	}
+	if wallet.OwnerID == data.UserID {
+		return nil, fmt.Errorf("%w: user owns the wallet", service.ErrInvalidArgument)
+	}
+
+	if err = m.repo.Save(ctx, data); err != nil {
+		m.log.Errorf("Error saving member: %s", err)
+		return nil, err
+	}
+	return &service.MemberPutResponse{Data: data}, nil
+}
+
+func (m *member) DeleteByID(ctx context.Context, request *service.MemberDeleteByIDRequest) (*service.MemberDeleteByIDResponse, error) {
+	userID, err := model.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// members may leave the wallet on their own
+	required := model.Owner
+	if request.UserID == userID {
+		required = model.Viewer
+	}
+	if err = authorize(ctx, m.repo, required, request.WalletID); err != nil {
+		return nil, err
+	}
+
+	deleted, err := m.repo.DeleteByID(ctx, request.WalletID, request.UserID)
+	if err != nil {
+		m.log.Errorf("Error deleting member: %s", err)
+		return nil, err
+	}
+	return &service.MemberDeleteByIDResponse{Data: deleted}, nil
+}
+
+// authorize makes sure the acting user has the required role in each of the wallets
+func authorize(ctx context.Context, members repository.Member, required model.Role, walletIDs ...uint64) error {
+	checked := make(map[uint64]bool, len(walletIDs))
+	for _, id := range walletIDs {
+		if checked[id] {
+			continue
+		}
+		checked[id] = true
+
+		role, err := members.FindRole(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !role.Includes(required) {
+			return fmt.Errorf("%w: %s role is required in wallet %d", service.ErrForbidden, required, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	mock_repository "github.com/mustan989/wallet/app/internal/repository/mock"
+	. "github.com/mustan989/wallet/app/internal/service"
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/repository"
+	"github.com/mustan989/wallet/service"
+)
+
+func expectRole(ctx context.Context, members *mock_repository.MockMember, role model.Role, walletIDs ...uint64) {
+	for _, id := range walletIDs {
+		members.EXPECT().
+			FindRole(ctx, id).
+			Return(role, nil)
+	}
+}
+
+func TestMember_GetAll(t *testing.T) {
+	ctx := context.Background()
+	ctl := gomock.NewController(t)
+	repo := mock_repository.NewMockMember(ctl)
+	svc := NewMember(repo, mock_repository.NewMockWallet(ctl), WithMemberLogger(log))
+
+	data := []*model.Member{{WalletID: 1, UserID: 2, Role: model.Viewer}}
+
+	expectRole(ctx, repo, model.Viewer, 1)
+	repo.EXPECT().
+		FindAll(ctx, uint64(1)).
+		Return(data, nil)
+
+	response, err := svc.GetAll(ctx, &service.MemberGetAllRequest{WalletID: 1})
+	require.NoError(t, err)
+	require.Equal(t, &service.MemberGetAllResponse{Data: data}, response)
+}
+
+func TestMember_GetAllNotFound(t *testing.T) {
+	ctx := context.Background()
+	ctl := gomock.NewController(t)
+	repo := mock_repository.NewMockMember(ctl)
+	svc := NewMember(repo, mock_repository.NewMockWallet(ctl), WithMemberLogger(log))
+
+	repo.EXPECT().
+		FindRole(ctx, uint64(1)).
+		Return(model.Role(""), repository.ErrWalletNotFound)
+
+	response, err := svc.GetAll(ctx, &service.MemberGetAllRequest{WalletID: 1})
+	require.Zero(t, response)
+	require.Equal(t, repository.ErrWalletNotFound, err)
+}
+
+func TestMember_Put(t *testing.T) {
+	ctx := context.Background()
+	ctl := gomock.NewController(t)
+	repo := mock_repository.NewMockMember(ctl)
+	wallets := mock_repository.NewMockWallet(ctl)
+	svc := NewMember(repo, wallets, WithMemberLogger(log))
+
+	data := &model.Member{WalletID: 1, UserID: 2, Role: model.Editor}
+
+	expectRole(ctx, repo, model.Owner, 1)
+	wallets.EXPECT().
+		FindByID(ctx, uint64(1)).
+		Return(&model.Wallet{ID: 1, OwnerID: 1}, nil)
+	repo.EXPECT().
+		Save(ctx, data).
+		Return(nil)
+
+	response, err := svc.Put(ctx, &service.MemberPutRequest{Data: data})
+	require.NoError(t, err)
+	require.Equal(t, &service.MemberPutResponse{Data: data}, response)
+}
+
+func TestMember_PutError(t *testing.T) {
+	subtests := [...]struct {
+		name   string
+		input  *model.Member
+		role   model.Role
+		wallet *model.Wallet
+		err    error
+	}{
+		{"Invalid role", &model.Member{WalletID: 1, UserID: 2, Role: "admin"}, "", nil, service.ErrInvalidArgument},
+		{"No user", &model.Member{WalletID: 1, Role: model.Viewer}, "", nil, service.ErrInvalidArgument},
+		{"Editor", &model.Member{WalletID: 1, UserID: 2, Role: model.Viewer}, model.Editor, nil, service.ErrForbidden},
+		{"Personal", &model.Member{WalletID: 1, UserID: 2, Role: model.Viewer}, model.Owner, &model.Wallet{ID: 1, OwnerID: 1, Personal: true}, service.ErrInvalidArgument},
+		{"Wallet owner", &model.Member{WalletID: 1, UserID: 1, Role: model.Viewer}, model.Owner, &model.Wallet{ID: 1, OwnerID: 1}, service.ErrInvalidArgument},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			ctx := context.Background()
+			ctl := gomock.NewController(t)
+			repo := mock_repository.NewMockMember(ctl)
+			wallets := mock_repository.NewMockWallet(ctl)
+			svc := NewMember(repo, wallets, WithMemberLogger(log))
+
+			if subtest.role != "" {
+				expectRole(ctx, repo, subtest.role, 1)
+			}
+			if subtest.wallet != nil {
+				wallets.EXPECT().
+					FindByID(ctx, uint64(1)).
+					Return(subtest.wallet, nil)
+			}
+
+			response, err := svc.Put(ctx, &service.MemberPutRequest{Data: subtest.input})
+			require.Zero(t, response)
+			require.ErrorIs(t, err, subtest.err)
+		})
+	}
+}
+
+func TestMember_DeleteByID(t *testing.T) {
+	subtests := [...]struct {
+		name   string
+		userID uint64
+		role   model.Role
+		err    error
+	}{
+		{"Owner", 3, model.Owner, nil},
+		{"Leave", 2, model.Viewer, nil},
+		{"Editor", 3, model.Editor, service.ErrForbidden},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			ctx := model.WithUserID(context.Background(), 2)
+			ctl := gomock.NewController(t)
+			repo := mock_repository.NewMockMember(ctl)
+			svc := NewMember(repo, mock_repository.NewMockWallet(ctl), WithMemberLogger(log))
+
+			data := &model.Member{WalletID: 1, UserID: subtest.userID, Role: model.Viewer}
+
+			expectRole(ctx, repo, subtest.role, 1)
+			if subtest.err == nil {
+				repo.EXPECT().
+					DeleteByID(ctx, uint64(1), subtest.userID).
+					Return(data, nil)
+			}
+
+			response, err := svc.DeleteByID(ctx, &service.MemberDeleteByIDRequest{WalletID: 1, UserID: subtest.userID})
+			if subtest.err != nil {
+				require.Zero(t, response)
+				require.ErrorIs(t, err, subtest.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, &service.MemberDeleteByIDResponse{Data: data}, response)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./service/currency.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	service "github.com/mustan989/wallet/service"
+)
+
+// MockCurrency is a mock of Currency interface.
+type MockCurrency struct {
+	ctrl     *gomock.Controller
+	recorder *MockCurrencyMockRecorder
+}
+
+// MockCurrencyMockRecorder is the mock recorder for MockCurrency.
+type MockCurrencyMockRecorder struct {
+	mock *MockCurrency
+}
+
+// NewMockCurrency creates a new mock instance.
+func NewMockCurrency(ctrl *gomock.Controller) *MockCurrency {
+	mock := &MockCurrency{ctrl: ctrl}
+	mock.recorder = &MockCurrencyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCurrency) EXPECT() *MockCurrencyMockRecorder {
+	return m.recorder
+}
+
+// GetAll mocks base method.
+func (m *MockCurrency) GetAll(ctx context.Context, request *service.CurrencyGetAllRequest) (*service.CurrencyGetAllResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, request)
+	ret0, _ := ret[0].(*service.CurrencyGetAllResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockCurrencyMockRecorder) GetAll(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockCurrency)(nil).GetAll), ctx, request)
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/pkg/logger"
+	"github.com/mustan989/wallet/repository"
+	"github.com/mustan989/wallet/service"
+)
+
+type TransactionOption func(t *transaction)
+
+func WithTransactionLogger(log logger.Logger) TransactionOption {
+	return func(t *transaction) { t.log = log }
+}
+
+func NewTransaction(repo repository.Transaction, wallets repository.Wallet, categories repository.Category, members repository.Member, options ...TransactionOption) service.Transaction {
+	t := &transaction{
+		log:        logger.Default(),
+		repo:       repo,
+		wallets:    wallets,
+		categories: categories,
+		members:    members,
+	}
+
+	for _, option := range options {
+		option(t)
+	}
+
+	return t
+}
+
+type transaction struct {
+	log logger.Logger
+
+	repo       repository.Transaction
+	wallets    repository.Wallet
+	categories repository.Category
+	members    repository.Member
+}
+
+func (t *transaction) Count(ctx context.Context, request *service.TransactionCountRequest) (*service.TransactionCountResponse, error) {
+	count, err := t.repo.CountAll(ctx, request.Filter)
+	if err != nil {
+		t.log.Errorf("Error getting transaction count: %s", err)
+		return nil, err
+	}
+	return &service.TransactionCountResponse{Count: count}, nil
+}
+
+func (t *transaction) GetAll(ctx context.Context, request *service.TransactionGetAllRequest) (*service.TransactionGetAllResponse, error) {
+	data, err := t.repo.FindAll(ctx, request.Filter)
+	if err != nil {
+		t.log.Errorf("Error getting transactions: %s", err)
+		return nil, err
+	}
+
+	count, err := t.Count(ctx, &service.TransactionCountRequest{Filter: request.Filter})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &service.TransactionGetAllResponse{
+		Data:  data,
+		Total: count.Count,
+	}
+	if request.Filter.Full(len(data)) {
+		response.Next = request.Filter.Next(data[len(data)-1])
+	}
+	return response, nil
+}
+
+func (t *transaction) GetByID(ctx context.Context, request *service.TransactionGetByIDRequest) (*service.TransactionGetByIDResponse, error) {
+	data, err := t.repo.FindByID(ctx, request.ID)
+	if err != nil {
+		t.log.Errorf("Error getting transaction by id %d: %s", request.ID, err)
+		return nil, err
+	}
+	return &service.TransactionGetByIDResponse{Data: data}, nil
+}
+
+func (t *transaction) Create(ctx context.Context, request *service.TransactionCreateRequest) (*service.TransactionCreateResponse, error) {
+	if err := validateTransaction(request.Data); err != nil {
+		return nil, err
+	}
+	if request.Data.Date.IsZero() {
+		request.Data.Date = time.Now()
+	}
+	if err := authorize(ctx, t.members, model.Editor, request.Data.WalletID); err != nil {
+		return nil, err
+	}
+	if err := t.checkCurrency(ctx, request.Data); err != nil {
+		return nil, err
+	}
+	if err := t.checkCategory(ctx, request.Data, nil); err != nil {
+		return nil, err
+	}
+
+	if err := t.repo.Create(ctx, request.Data); err != nil {
+		t.log.Errorf("Error creating transaction: %s", err)
+		return nil, err
+	}
+	return &service.TransactionCreateResponse{Data: request.Data}, nil
+}
+
+func (t *transaction) Update(ctx context.Context, request *service.TransactionUpdateRequest) (*service.TransactionUpdateResponse, error) {
+	if err := validateTransaction(request.Data); err != nil {
+		return nil, err
+	}
+	if request.Data.Date.IsZero() {
+		request.Data.Date = time.Now()
+	}
+
+	old, err := t.repo.FindByID(ctx, request.Data.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err = authorize(ctx, t.members, model.Editor, old.WalletID, request.Data.WalletID); err != nil {
+		return nil, err
+	}
+	if err = t.checkCurrency(ctx, request.Data); err != nil {
+		return nil, err
+	}
+	if err = t.checkCategory(ctx, request.Data, old); err != nil {
+		return nil, err
+	}
+
+	if err = t.repo.Update(ctx, request.Data); err != nil {
+		t.log.Errorf("Error updating transaction: %s", err)
+		return nil, err
+	}
+	return &service.TransactionUpdateResponse{Data: request.Data}, nil
+}
+
+func (t *transaction) DeleteByID(ctx context.Context, request *service.TransactionDeleteByIDRequest) (*service.TransactionDeleteByIDResponse, error) {
+	old, err := t.repo.FindByID(ctx, request.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err = authorize(ctx, t.members, model.Editor, old.WalletID); err != nil {
+		return nil, err
+	}
+
+	deleted, err := t.repo.DeleteByID(ctx, request.ID)
+	if err != nil {
+		t.log.Errorf("Error deleting transaction: %s", err)
+		return nil, err
+	}
+	return &service.TransactionDeleteByIDResponse{Data: deleted}, nil
+}
+
+// checkCurrency binds the amount to the currency of the wallet
+func (t *transaction) checkCurrency(ctx context.Context, data *model.Transaction) error {
+	wallet, err := t.wallets.FindByID(ctx, data.WalletID)
+	if err != nil {
+		t.log.Errorf("Error getting wallet by id %d: %s", data.WalletID, err)
+		return err
+	}
+	return bind(wallet.Currency, &data.Amount)
+}
+
+// checkCategory makes sure the category, if any, is of the transaction type and not archived,
+// unless the transaction was already filed under it
+func (t *transaction) checkCategory(ctx context.Context, data, old *model.Transaction) error {
+	if data.CategoryID == nil {
+		return nil
+	}
+
+	category, err := t.categories.FindByID(ctx, *data.CategoryID)
+	if err != nil {
+		t.log.Errorf("Error getting category by id %d: %s", *data.CategoryID, err)
+		return err
+	}
+	if category.Type != data.Type {
+		return fmt.Errorf("%w: category %d is for %s transactions", service.ErrInvalidArgument, category.ID, category.Type)
+	}
+	kept := old != nil && old.CategoryID != nil && *old.CategoryID == category.ID
+	if category.ArchivedAt != nil && !kept {
+		return fmt.Errorf("%w: category %d is archived", service.ErrInvalidArgument, category.ID)
+	}
+	return nil
+}
+
+func validateTransaction(data *model.Transaction) error {
+	if !data.Type.Valid() {
+		return fmt.Errorf("%w: type must be %q or %q", service.ErrInvalidArgument, model.Income, model.Expense)
+	}
+	if data.Amount.Sign() <= 0 {
+		return fmt.Errorf("%w: amount must be positive", service.ErrInvalidArgument)
+	}
+	if data.WalletID == 0 {
+		return fmt.Errorf("%w: wallet_id is required", service.ErrInvalidArgument)
+	}
+	return nil
+}
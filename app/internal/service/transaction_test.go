@@ -0,0 +1,368 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	mock_repository "github.com/mustan989/wallet/app/internal/repository/mock"
+	. "github.com/mustan989/wallet/app/internal/service"
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/repository"
+	"github.com/mustan989/wallet/service"
+)
+
+func TestTransaction_GetAll(t *testing.T) {
+	date := time.Date(1999, 2, 23, 4, 36, 0, 0, time.UTC)
+	walletID := uint64(1)
+
+	subtests := [...]struct {
+		name   string
+		input  *service.TransactionGetAllRequest
+		expect *service.TransactionGetAllResponse
+	}{
+		{
+			"None",
+			&service.TransactionGetAllRequest{Filter: &model.TransactionFilter{}},
+			&service.TransactionGetAllResponse{Data: []*model.Transaction{}, Total: 0},
+		},
+		{
+			"WalletID",
+			&service.TransactionGetAllRequest{Filter: &model.TransactionFilter{WalletID: &walletID}},
+			&service.TransactionGetAllResponse{Data: []*model.Transaction{
+				{ID: 1, WalletID: 1, Type: model.Income, Amount: amount("99.99"), Date: date, CreatedAt: date, UpdatedAt: date},
+			}, Total: 1},
+		},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			ctx := context.Background()
+			ctl := gomock.NewController(t)
+			repo := mock_repository.NewMockTransaction(ctl)
+			wallets := mock_repository.NewMockWallet(ctl)
+			categories := mock_repository.NewMockCategory(ctl)
+			members := mock_repository.NewMockMember(ctl)
+			svc := NewTransaction(repo, wallets, categories, members, WithTransactionLogger(log))
+
+			repo.EXPECT().
+				FindAll(ctx, subtest.input.Filter).
+				Return(subtest.expect.Data, nil)
+
+			repo.EXPECT().
+				CountAll(ctx, subtest.input.Filter).
+				Return(subtest.expect.Total, nil)
+
+			response, err := svc.GetAll(ctx, subtest.input)
+			require.NoError(t, err)
+			require.Equal(t, subtest.expect, response)
+		})
+	}
+}
+
+func TestTransaction_GetByIDError(t *testing.T) {
+	ctx := context.Background()
+	ctl := gomock.NewController(t)
+	repo := mock_repository.NewMockTransaction(ctl)
+	wallets := mock_repository.NewMockWallet(ctl)
+	categories := mock_repository.NewMockCategory(ctl)
+	members := mock_repository.NewMockMember(ctl)
+	svc := NewTransaction(repo, wallets, categories, members, WithTransactionLogger(log))
+
+	repo.EXPECT().
+		FindByID(ctx, uint64(1)).
+		Return(nil, repository.ErrTransactionNotFound)
+
+	response, err := svc.GetByID(ctx, &service.TransactionGetByIDRequest{ID: 1})
+	require.Zero(t, response)
+	require.Equal(t, repository.ErrTransactionNotFound, err)
+}
+
+func TestTransaction_Create(t *testing.T) {
+	date := time.Date(1999, 2, 23, 4, 36, 0, 0, time.UTC)
+
+	subtests := [...]struct {
+		name  string
+		input *model.Transaction
+	}{
+		{"Income", &model.Transaction{WalletID: 1, Type: model.Income, Amount: amount("99.99"), Date: date}},
+		{"Expense", &model.Transaction{WalletID: 1, Type: model.Expense, Amount: amount("99.99"), Date: date}},
+		{"Default date", &model.Transaction{WalletID: 1, Type: model.Expense, Amount: amount("0.01")}},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			ctx := context.Background()
+			ctl := gomock.NewController(t)
+			repo := mock_repository.NewMockTransaction(ctl)
+			wallets := mock_repository.NewMockWallet(ctl)
+			categories := mock_repository.NewMockCategory(ctl)
+			members := mock_repository.NewMockMember(ctl)
+			svc := NewTransaction(repo, wallets, categories, members, WithTransactionLogger(log))
+
+			expectRole(ctx, members, model.Editor, 1)
+			wallets.EXPECT().
+				FindByID(ctx, uint64(1)).
+				Return(&model.Wallet{ID: 1, Currency: "KZT"}, nil)
+
+			repo.EXPECT().
+				Create(ctx, subtest.input).
+				DoAndReturn(func(_ context.Context, data *model.Transaction) error {
+					data.ID = 1
+					return nil
+				})
+
+			response, err := svc.Create(ctx, &service.TransactionCreateRequest{Data: subtest.input})
+			require.NoError(t, err)
+			require.Equal(t, uint64(1), response.Data.ID)
+			require.NotZero(t, response.Data.Date)
+		})
+	}
+}
+
+func TestTransaction_CreateError(t *testing.T) {
+	subtests := [...]struct {
+		name    string
+		input   *model.Transaction
+		repoErr error
+		err     error
+	}{
+		{"Type", &model.Transaction{WalletID: 1, Type: "transfer", Amount: amount("0.01")}, nil, service.ErrInvalidArgument},
+		{"Zero amount", &model.Transaction{WalletID: 1, Type: model.Income}, nil, service.ErrInvalidArgument},
+		{"Negative amount", &model.Transaction{WalletID: 1, Type: model.Income, Amount: amount("-0.01")}, nil, service.ErrInvalidArgument},
+		{"Wallet", &model.Transaction{Type: model.Income, Amount: amount("0.01")}, nil, service.ErrInvalidArgument},
+		{"Wallet not found", &model.Transaction{WalletID: 1, Type: model.Income, Amount: amount("0.01")}, repository.ErrWalletNotFound, repository.ErrWalletNotFound},
+		{"error", &model.Transaction{WalletID: 1, Type: model.Income, Amount: amount("0.01")}, errors.New("error"), nil},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			ctx := context.Background()
+			ctl := gomock.NewController(t)
+			repo := mock_repository.NewMockTransaction(ctl)
+			wallets := mock_repository.NewMockWallet(ctl)
+			categories := mock_repository.NewMockCategory(ctl)
+			members := mock_repository.NewMockMember(ctl)
+			svc := NewTransaction(repo, wallets, categories, members, WithTransactionLogger(log))
+
+			if subtest.repoErr != nil {
+				expectRole(ctx, members, model.Editor, 1)
+				wallets.EXPECT().
+					FindByID(ctx, uint64(1)).
+					Return(&model.Wallet{ID: 1, Currency: "KZT"}, nil)
+				repo.EXPECT().
+					Create(ctx, subtest.input).
+					Return(subtest.repoErr)
+			}
+
+			response, err := svc.Create(ctx, &service.TransactionCreateRequest{Data: subtest.input})
+			require.Zero(t, response)
+			if subtest.err != nil {
+				require.ErrorIs(t, err, subtest.err)
+			} else {
+				require.Equal(t, subtest.repoErr, err)
+			}
+		})
+	}
+}
+
+func TestTransaction_CreateInexact(t *testing.T) {
+	ctx := context.Background()
+	ctl := gomock.NewController(t)
+	repo := mock_repository.NewMockTransaction(ctl)
+	wallets := mock_repository.NewMockWallet(ctl)
+	categories := mock_repository.NewMockCategory(ctl)
+	members := mock_repository.NewMockMember(ctl)
+	svc := NewTransaction(repo, wallets, categories, members, WithTransactionLogger(log))
+
+	expectRole(ctx, members, model.Editor, 1)
+	wallets.EXPECT().
+		FindByID(ctx, uint64(1)).
+		Return(&model.Wallet{ID: 1, Currency: "JPY"}, nil)
+
+	// yen have no cents
+	response, err := svc.Create(ctx, &service.TransactionCreateRequest{
+		Data: &model.Transaction{WalletID: 1, Type: model.Expense, Amount: amount("1.50")},
+	})
+	require.Zero(t, response)
+	require.ErrorIs(t, err, service.ErrInvalidArgument)
+}
+
+func TestTransaction_CreateCategory(t *testing.T) {
+	archived := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	subtests := [...]struct {
+		name     string
+		category *model.Category
+		err      error
+	}{
+		{"Same type", &model.Category{ID: 5, Type: model.Expense}, nil},
+		{"Other type", &model.Category{ID: 5, Type: model.Income}, service.ErrInvalidArgument},
+		{"Archived", &model.Category{ID: 5, Type: model.Expense, ArchivedAt: &archived}, service.ErrInvalidArgument},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			ctx := context.Background()
+			ctl := gomock.NewController(t)
+			repo := mock_repository.NewMockTransaction(ctl)
+			wallets := mock_repository.NewMockWallet(ctl)
+			categories := mock_repository.NewMockCategory(ctl)
+			members := mock_repository.NewMockMember(ctl)
+			svc := NewTransaction(repo, wallets, categories, members, WithTransactionLogger(log))
+
+			categoryID := uint64(5)
+			data := &model.Transaction{WalletID: 1, Type: model.Expense, Amount: amount("1.00"), CategoryID: &categoryID}
+
+			expectRole(ctx, members, model.Editor, 1)
+			wallets.EXPECT().
+				FindByID(ctx, uint64(1)).
+				Return(&model.Wallet{ID: 1, Currency: "KZT"}, nil)
+			categories.EXPECT().
+				FindByID(ctx, categoryID).
+				Return(subtest.category, nil)
+			if subtest.err == nil {
+				repo.EXPECT().
+					Create(ctx, data).
+					Return(nil)
+			}
+
+			response, err := svc.Create(ctx, &service.TransactionCreateRequest{Data: data})
+			if subtest.err != nil {
+				require.Zero(t, response)
+				require.ErrorIs(t, err, subtest.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTransaction_UpdateArchivedCategory(t *testing.T) {
+	ctx := context.Background()
+	ctl := gomock.NewController(t)
+	repo := mock_repository.NewMockTransaction(ctl)
+	wallets := mock_repository.NewMockWallet(ctl)
+	categories := mock_repository.NewMockCategory(ctl)
+	members := mock_repository.NewMockMember(ctl)
+	svc := NewTransaction(repo, wallets, categories, members, WithTransactionLogger(log))
+
+	archived, other := uint64(5), uint64(6)
+	data := &model.Transaction{ID: 1, WalletID: 1, Type: model.Expense, Amount: amount("2.00"), CategoryID: &archived, Date: time.Now()}
+
+	// the transaction was filed before the category got archived, so it keeps it
+	repo.EXPECT().
+		FindByID(ctx, uint64(1)).
+		Return(&model.Transaction{ID: 1, WalletID: 1, Type: model.Expense, Amount: amount("1.00"), CategoryID: &archived}, nil)
+	expectRole(ctx, members, model.Editor, 1)
+	wallets.EXPECT().
+		FindByID(ctx, uint64(1)).
+		Return(&model.Wallet{ID: 1, Currency: "KZT"}, nil)
+	categories.EXPECT().
+		FindByID(ctx, archived).
+		Return(&model.Category{ID: archived, Type: model.Expense, ArchivedAt: &data.Date}, nil)
+	repo.EXPECT().
+		Update(ctx, data).
+		Return(nil)
+
+	_, err := svc.Update(ctx, &service.TransactionUpdateRequest{Data: data})
+	require.NoError(t, err)
+
+	// but can not be moved into it
+	repo.EXPECT().
+		FindByID(ctx, uint64(1)).
+		Return(&model.Transaction{ID: 1, WalletID: 1, Type: model.Expense, Amount: amount("1.00"), CategoryID: &other}, nil)
+	expectRole(ctx, members, model.Editor, 1)
+	wallets.EXPECT().
+		FindByID(ctx, uint64(1)).
+		Return(&model.Wallet{ID: 1, Currency: "KZT"}, nil)
+	categories.EXPECT().
+		FindByID(ctx, archived).
+		Return(&model.Category{ID: archived, Type: model.Expense, ArchivedAt: &data.Date}, nil)
+
+	response, err := svc.Update(ctx, &service.TransactionUpdateRequest{Data: data})
+	require.Zero(t, response)
+	require.ErrorIs(t, err, service.ErrInvalidArgument)
+}
+
+func TestTransaction_Update(t *testing.T) {
+	ctx := context.Background()
+	ctl := gomock.NewController(t)
+	repo := mock_repository.NewMockTransaction(ctl)
+	wallets := mock_repository.NewMockWallet(ctl)
+	categories := mock_repository.NewMockCategory(ctl)
+	members := mock_repository.NewMockMember(ctl)
+	svc := NewTransaction(repo, wallets, categories, members, WithTransactionLogger(log))
+
+	data := &model.Transaction{ID: 1, WalletID: 2, Type: model.Expense, Amount: amount("1.00"), Date: time.Now()}
+
+	repo.EXPECT().
+		FindByID(ctx, uint64(1)).
+		Return(&model.Transaction{ID: 1, WalletID: 3, Type: model.Expense, Amount: amount("1.00")}, nil)
+	expectRole(ctx, members, model.Editor, 3, 2)
+	wallets.EXPECT().
+		FindByID(ctx, uint64(2)).
+		Return(&model.Wallet{ID: 2, Currency: "KZT"}, nil)
+
+	repo.EXPECT().
+		Update(ctx, data).
+		Return(nil)
+
+	response, err := svc.Update(ctx, &service.TransactionUpdateRequest{Data: data})
+	require.NoError(t, err)
+	require.Equal(t, &service.TransactionUpdateResponse{Data: data}, response)
+}
+
+func TestTransaction_DeleteByID(t *testing.T) {
+	ctx := context.Background()
+	ctl := gomock.NewController(t)
+	repo := mock_repository.NewMockTransaction(ctl)
+	wallets := mock_repository.NewMockWallet(ctl)
+	categories := mock_repository.NewMockCategory(ctl)
+	members := mock_repository.NewMockMember(ctl)
+	svc := NewTransaction(repo, wallets, categories, members, WithTransactionLogger(log))
+
+	data := &model.Transaction{ID: 1, WalletID: 1, Type: model.Expense, Amount: amount("1.00")}
+
+	repo.EXPECT().
+		FindByID(ctx, uint64(1)).
+		Return(data, nil)
+	expectRole(ctx, members, model.Owner, 1)
+
+	repo.EXPECT().
+		DeleteByID(ctx, uint64(1)).
+		Return(data, nil)
+
+	response, err := svc.DeleteByID(ctx, &service.TransactionDeleteByIDRequest{ID: 1})
+	require.NoError(t, err)
+	require.Equal(t, &service.TransactionDeleteByIDResponse{Data: data}, response)
+}
+
+func TestTransaction_Forbidden(t *testing.T) {
+	ctx := context.Background()
+	ctl := gomock.NewController(t)
+	repo := mock_repository.NewMockTransaction(ctl)
+	wallets := mock_repository.NewMockWallet(ctl)
+	categories := mock_repository.NewMockCategory(ctl)
+	members := mock_repository.NewMockMember(ctl)
+	svc := NewTransaction(repo, wallets, categories, members, WithTransactionLogger(log))
+
+	data := &model.Transaction{ID: 1, WalletID: 1, Type: model.Expense, Amount: amount("1.00")}
+
+	expectRole(ctx, members, model.Viewer, 1)
+	created, err := svc.Create(ctx, &service.TransactionCreateRequest{Data: data})
+	require.Zero(t, created)
+	require.ErrorIs(t, err, service.ErrForbidden)
+
+	repo.EXPECT().
+		FindByID(ctx, uint64(1)).
+		Return(data, nil)
+	expectRole(ctx, members, model.Viewer, 1)
+	deleted, err := svc.DeleteByID(ctx, &service.TransactionDeleteByIDRequest{ID: 1})
+	require.Zero(t, deleted)
+	require.ErrorIs(t, err, service.ErrForbidden)
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/pkg/logger"
+	"github.com/mustan989/wallet/repository"
+	"github.com/mustan989/wallet/service"
+)
+
+type TransferOption func(t *transfer)
+
+func WithTransferLogger(log logger.Logger) TransferOption {
+	return func(t *transfer) { t.log = log }
+}
+
+func NewTransfer(repo repository.Transfer, wallets repository.Wallet, members repository.Member, options ...TransferOption) service.Transfer {
+	t := &transfer{
+		log:     logger.Default(),
+		repo:    repo,
+		wallets: wallets,
+		members: members,
+	}
+
+	for _, option := range options {
+		option(t)
+	}
+
+	return t
+}
+
+type transfer struct {
+	log logger.Logger
+
+	repo    repository.Transfer
+	wallets repository.Wallet
+	members repository.Member
+}
+
+func (t *transfer) Count(ctx context.Context, request *service.TransferCountRequest) (*service.TransferCountResponse, error) {
+	count, err := t.repo.CountAll(ctx, request.Filter)
+	if err != nil {
+		t.log.Errorf("Error getting transfer count: %s", err)
+		return nil, err
+	}
+	return &service.TransferCountResponse{Count: count}, nil
+}
+
+func (t *transfer) GetAll(ctx context.Context, request *service.TransferGetAllRequest) (*service.TransferGetAllResponse, error) {
+	data, err := t.repo.FindAll(ctx, request.Filter)
+	if err != nil {
+		t.log.Errorf("Error getting transfers: %s", err)
+		return nil, err
+	}
+
+	count, err := t.Count(ctx, &service.TransferCountRequest{Filter: request.Filter})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &service.TransferGetAllResponse{
+		Data:  data,
+		Total: count.Count,
+	}
+	if request.Filter.Full(len(data)) {
+		response.Next = request.Filter.Next(data[len(data)-1])
+	}
+	return response, nil
+}
+
+func (t *transfer) GetByID(ctx context.Context, request *service.TransferGetByIDRequest) (*service.TransferGetByIDResponse, error) {
+	data, err := t.repo.FindByID(ctx, request.ID)
+	if err != nil {
+		t.log.Errorf("Error getting transfer by id %d: %s", request.ID, err)
+		return nil, err
+	}
+	return &service.TransferGetByIDResponse{Data: data}, nil
+}
+
+func (t *transfer) Create(ctx context.Context, request *service.TransferCreateRequest) (*service.TransferCreateResponse, error) {
+	if err := validateTransfer(request.Data); err != nil {
+		return nil, err
+	}
+	if request.Data.Date.IsZero() {
+		request.Data.Date = time.Now()
+	}
+	if err := authorize(ctx, t.members, model.Editor, request.Data.FromWalletID, request.Data.ToWalletID); err != nil {
+		return nil, err
+	}
+	if err := t.exchange(ctx, request.Data); err != nil {
+		return nil, err
+	}
+
+	if err := t.repo.Create(ctx, request.Data); err != nil {
+		t.log.Errorf("Error creating transfer: %s", err)
+		return nil, err
+	}
+	return &service.TransferCreateResponse{Data: request.Data}, nil
+}
+
+func (t *transfer) Update(ctx context.Context, request *service.TransferUpdateRequest) (*service.TransferUpdateResponse, error) {
+	if err := validateTransfer(request.Data); err != nil {
+		return nil, err
+	}
+	if request.Data.Date.IsZero() {
+		request.Data.Date = time.Now()
+	}
+
+	old, err := t.repo.FindByID(ctx, request.Data.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err = authorize(
+		ctx, t.members, model.Editor, old.FromWalletID, old.ToWalletID, request.Data.FromWalletID, request.Data.ToWalletID,
+	); err != nil {
+		return nil, err
+	}
+	if err = t.exchange(ctx, request.Data); err != nil {
+		return nil, err
+	}
+
+	if err = t.repo.Update(ctx, request.Data); err != nil {
+		t.log.Errorf("Error updating transfer: %s", err)
+		return nil, err
+	}
+	return &service.TransferUpdateResponse{Data: request.Data}, nil
+}
+
+func (t *transfer) DeleteByID(ctx context.Context, request *service.TransferDeleteByIDRequest) (*service.TransferDeleteByIDResponse, error) {
+	old, err := t.repo.FindByID(ctx, request.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err = authorize(ctx, t.members, model.Editor, old.FromWalletID, old.ToWalletID); err != nil {
+		return nil, err
+	}
+
+	deleted, err := t.repo.DeleteByID(ctx, request.ID)
+	if err != nil {
+		t.log.Errorf("Error deleting transfer: %s", err)
+		return nil, err
+	}
+	return &service.TransferDeleteByIDResponse{Data: deleted}, nil
+}
+
+func validateTransfer(data *model.Transfer) error {
+	if data.FromWalletID == 0 || data.ToWalletID == 0 {
+		return fmt.Errorf("%w: from_wallet_id and to_wallet_id are required", service.ErrInvalidArgument)
+	}
+	if data.FromWalletID == data.ToWalletID {
+		return fmt.Errorf("%w: wallets must differ", service.ErrInvalidArgument)
+	}
+	if data.Amount.Sign() <= 0 {
+		return fmt.Errorf("%w: amount must be positive", service.ErrInvalidArgument)
+	}
+	if data.Commission.Sign() < 0 {
+		return fmt.Errorf("%w: commission must not be negative", service.ErrInvalidArgument)
+	}
+	if data.ReceivedAmount.Sign() < 0 || data.Rate < 0 {
+		return fmt.Errorf("%w: received_amount and rate must not be negative", service.ErrInvalidArgument)
+	}
+	return nil
+}
+
+// exchange fills the received amount and the effective rate of the transfer depending on the currencies of its wallets.
+// Either the rate or the received amount is required between different currencies, the received amount wins if both are set
+func (t *transfer) exchange(ctx context.Context, data *model.Transfer) error {
+	from, err := t.wallets.FindByID(ctx, data.FromWalletID)
+	if err != nil {
+		t.log.Errorf("Error getting source wallet by id %d: %s", data.FromWalletID, err)
+		return err
+	}
+	to, err := t.wallets.FindByID(ctx, data.ToWalletID)
+	if err != nil {
+		t.log.Errorf("Error getting destination wallet by id %d: %s", data.ToWalletID, err)
+		return err
+	}
+
+	if err = bind(from.Currency, &data.Amount, &data.Commission); err != nil {
+		return err
+	}
+	if err = bind(to.Currency, &data.ReceivedAmount); err != nil {
+		return err
+	}
+
+	if from.Currency == to.Currency {
+		if data.Rate != 0 && data.Rate != model.OneRate || data.ReceivedAmount.Sign() != 0 && data.ReceivedAmount != data.Amount {
+			return fmt.Errorf("%w: wallets are in the same currency, amounts must be equal", service.ErrInvalidArgument)
+		}
+		data.ReceivedAmount, data.Rate = data.Amount, model.OneRate
+		return nil
+	}
+
+	switch {
+	case data.ReceivedAmount.Sign() > 0:
+		data.Rate, err = model.RateOf(data.Amount, data.ReceivedAmount)
+	case data.Rate > 0:
+		// the received amount is rounded to the minor unit of its currency, e.g. to whole yens
+		data.ReceivedAmount, err = data.Amount.Exchange(data.Rate, data.ReceivedAmount.Currency, model.HalfUp)
+	default:
+		return fmt.Errorf("%w: rate or received_amount is required to transfer %s to %s", service.ErrInvalidArgument, from.Currency, to.Currency)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %s", service.ErrInvalidArgument, err)
+	}
+	if data.ReceivedAmount.Sign() <= 0 || data.Rate <= 0 {
+		return fmt.Errorf("%w: amount is too small for the exchange", service.ErrInvalidArgument)
+	}
+
+	return nil
+}
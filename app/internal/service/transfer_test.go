@@ -0,0 +1,210 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	mock_repository "github.com/mustan989/wallet/app/internal/repository/mock"
+	. "github.com/mustan989/wallet/app/internal/service"
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/pkg/helper"
+	"github.com/mustan989/wallet/repository"
+	"github.com/mustan989/wallet/service"
+)
+
+func expectWallets(ctx context.Context, wallets *mock_repository.MockWallet, currencies ...string) {
+	for i, currency := range currencies {
+		id := uint64(i + 1)
+		wallets.EXPECT().
+			FindByID(ctx, id).
+			Return(&model.Wallet{ID: id, Currency: currency}, nil)
+	}
+}
+
+func TestTransfer_Create(t *testing.T) {
+	date := time.Date(1999, 2, 23, 4, 36, 0, 0, time.UTC)
+
+	subtests := [...]struct {
+		name       string
+		currencies []string
+		input      *model.Transfer
+		received   string
+		rate       model.Rate
+	}{
+		{"Without commission", []string{"KZT", "KZT"}, &model.Transfer{FromWalletID: 1, ToWalletID: 2, Amount: amount("99.99"), Date: date}, "99.99 KZT", model.OneRate},
+		{"With commission", []string{"KZT", "KZT"}, &model.Transfer{FromWalletID: 1, ToWalletID: 2, Amount: amount("99.99"), Commission: amount("1.00"), Date: date}, "99.99 KZT", model.OneRate},
+		{"Default date", []string{"KZT", "KZT"}, &model.Transfer{FromWalletID: 1, ToWalletID: 2, Amount: amount("99.99")}, "99.99 KZT", model.OneRate},
+		{"Same currency rate", []string{"KZT", "KZT"}, &model.Transfer{FromWalletID: 1, ToWalletID: 2, Amount: amount("99.99"), Rate: model.OneRate}, "99.99 KZT", model.OneRate},
+		{"Rate", []string{"USD", "KZT"}, &model.Transfer{FromWalletID: 1, ToWalletID: 2, Amount: amount("100.00"), Rate: 45012345678}, "45012.35 KZT", 45012345678},
+		{"Received amount", []string{"KZT", "USD"}, &model.Transfer{FromWalletID: 1, ToWalletID: 2, Amount: amount("45012.34"), ReceivedAmount: amount("100.00")}, "100.00 USD", 222161},
+		{"Received amount wins", []string{"USD", "KZT"}, &model.Transfer{FromWalletID: 1, ToWalletID: 2, Amount: amount("100.00"), ReceivedAmount: amount("45000.00"), Rate: 1}, "45000.00 KZT", 45000000000},
+		{"Rate to yen", []string{"USD", "JPY"}, &model.Transfer{FromWalletID: 1, ToWalletID: 2, Amount: amount("100.00"), Rate: 14987654321}, "14988 JPY", 14987654321},
+		{"Rate to dinar", []string{"USD", "KWD"}, &model.Transfer{FromWalletID: 1, ToWalletID: 2, Amount: amount("100.00"), Rate: 30712345}, "30.712 KWD", 30712345},
+		{"Received dinar", []string{"USD", "KWD"}, &model.Transfer{FromWalletID: 1, ToWalletID: 2, Amount: amount("100.00"), ReceivedAmount: amount("30.712")}, "30.712 KWD", 30712000},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			ctx := context.Background()
+			ctl := gomock.NewController(t)
+			repo := mock_repository.NewMockTransfer(ctl)
+			wallets := mock_repository.NewMockWallet(ctl)
+			members := mock_repository.NewMockMember(ctl)
+			svc := NewTransfer(repo, wallets, members, WithTransferLogger(log))
+
+			expectRole(ctx, members, model.Editor, 1, 2)
+			expectWallets(ctx, wallets, subtest.currencies...)
+
+			repo.EXPECT().
+				Create(ctx, subtest.input).
+				DoAndReturn(func(_ context.Context, data *model.Transfer) error {
+					data.ID = 1
+					return nil
+				})
+
+			response, err := svc.Create(ctx, &service.TransferCreateRequest{Data: subtest.input})
+			require.NoError(t, err)
+			require.Equal(t, uint64(1), response.Data.ID)
+			require.NotZero(t, response.Data.Date)
+			require.Equal(t, subtest.received, response.Data.ReceivedAmount.String())
+			require.Equal(t, subtest.rate, response.Data.Rate)
+		})
+	}
+}
+
+func TestTransfer_CreateError(t *testing.T) {
+	subtests := [...]struct {
+		name       string
+		currencies []string
+		input      *model.Transfer
+		repoErr    error
+		err        error
+	}{
+		{"No source", nil, &model.Transfer{ToWalletID: 2, Amount: amount("0.01")}, nil, service.ErrInvalidArgument},
+		{"No destination", nil, &model.Transfer{FromWalletID: 1, Amount: amount("0.01")}, nil, service.ErrInvalidArgument},
+		{"Same wallet", nil, &model.Transfer{FromWalletID: 1, ToWalletID: 1, Amount: amount("0.01")}, nil, service.ErrInvalidArgument},
+		{"Zero amount", nil, &model.Transfer{FromWalletID: 1, ToWalletID: 2}, nil, service.ErrInvalidArgument},
+		{"Negative commission", nil, &model.Transfer{FromWalletID: 1, ToWalletID: 2, Amount: amount("0.01"), Commission: amount("-0.01")}, nil, service.ErrInvalidArgument},
+		{"Negative rate", nil, &model.Transfer{FromWalletID: 1, ToWalletID: 2, Amount: amount("0.01"), Rate: -1}, nil, service.ErrInvalidArgument},
+		{"Same currency rate", []string{"KZT", "KZT"}, &model.Transfer{FromWalletID: 1, ToWalletID: 2, Amount: amount("0.01"), Rate: 2 * model.OneRate}, nil, service.ErrInvalidArgument},
+		{"Same currency received", []string{"KZT", "KZT"}, &model.Transfer{FromWalletID: 1, ToWalletID: 2, Amount: amount("0.01"), ReceivedAmount: amount("0.02")}, nil, service.ErrInvalidArgument},
+		{"No rate", []string{"USD", "KZT"}, &model.Transfer{FromWalletID: 1, ToWalletID: 2, Amount: amount("0.01")}, nil, service.ErrInvalidArgument},
+		{"Rounds to zero", []string{"KZT", "USD"}, &model.Transfer{FromWalletID: 1, ToWalletID: 2, Amount: amount("0.01"), Rate: 222161}, nil, service.ErrInvalidArgument},
+		{"Yen cents", []string{"JPY", "USD"}, &model.Transfer{FromWalletID: 1, ToWalletID: 2, Amount: amount("1.50"), Rate: 666666}, nil, service.ErrInvalidArgument},
+		{"Received yen cents", []string{"USD", "JPY"}, &model.Transfer{FromWalletID: 1, ToWalletID: 2, Amount: amount("1.00"), ReceivedAmount: amount("149.50")}, nil, service.ErrInvalidArgument},
+		{"Wallet not found", []string{"KZT", "KZT"}, &model.Transfer{FromWalletID: 1, ToWalletID: 2, Amount: amount("0.01")}, repository.ErrWalletNotFound, repository.ErrWalletNotFound},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			ctx := context.Background()
+			ctl := gomock.NewController(t)
+			repo := mock_repository.NewMockTransfer(ctl)
+			wallets := mock_repository.NewMockWallet(ctl)
+			members := mock_repository.NewMockMember(ctl)
+			svc := NewTransfer(repo, wallets, members, WithTransferLogger(log))
+
+			if subtest.currencies != nil {
+				expectRole(ctx, members, model.Editor, 1, 2)
+			}
+			expectWallets(ctx, wallets, subtest.currencies...)
+
+			if subtest.repoErr != nil {
+				repo.EXPECT().
+					Create(ctx, subtest.input).
+					Return(subtest.repoErr)
+			}
+
+			response, err := svc.Create(ctx, &service.TransferCreateRequest{Data: subtest.input})
+			require.Zero(t, response)
+			require.ErrorIs(t, err, subtest.err)
+		})
+	}
+}
+
+func TestTransfer_CreateWalletNotFound(t *testing.T) {
+	ctx := context.Background()
+	ctl := gomock.NewController(t)
+	repo := mock_repository.NewMockTransfer(ctl)
+	wallets := mock_repository.NewMockWallet(ctl)
+	members := mock_repository.NewMockMember(ctl)
+	svc := NewTransfer(repo, wallets, members, WithTransferLogger(log))
+
+	expectRole(ctx, members, model.Editor, 1, 2)
+	wallets.EXPECT().
+		FindByID(ctx, uint64(1)).
+		Return(nil, repository.ErrWalletNotFound)
+
+	response, err := svc.Create(ctx, &service.TransferCreateRequest{Data: &model.Transfer{FromWalletID: 1, ToWalletID: 2, Amount: amount("0.01")}})
+	require.Zero(t, response)
+	require.Equal(t, repository.ErrWalletNotFound, err)
+}
+
+func TestTransfer_UpdateError(t *testing.T) {
+	ctx := context.Background()
+	ctl := gomock.NewController(t)
+	repo := mock_repository.NewMockTransfer(ctl)
+	wallets := mock_repository.NewMockWallet(ctl)
+	members := mock_repository.NewMockMember(ctl)
+	svc := NewTransfer(repo, wallets, members, WithTransferLogger(log))
+
+	data := &model.Transfer{ID: 1, FromWalletID: 1, ToWalletID: 2, Amount: amount("0.01")}
+
+	repo.EXPECT().
+		FindByID(ctx, uint64(1)).
+		Return(&model.Transfer{ID: 1, FromWalletID: 1, ToWalletID: 3, Amount: amount("0.01")}, nil)
+	expectRole(ctx, members, model.Editor, 1, 3, 2)
+	expectWallets(ctx, wallets, "KZT", "KZT")
+
+	repo.EXPECT().
+		Update(ctx, data).
+		Return(repository.ErrTransferNotFound)
+
+	response, err := svc.Update(ctx, &service.TransferUpdateRequest{Data: data})
+	require.Zero(t, response)
+	require.Equal(t, repository.ErrTransferNotFound, err)
+}
+
+func TestTransfer_DeleteByID(t *testing.T) {
+	ctx := context.Background()
+	ctl := gomock.NewController(t)
+	repo := mock_repository.NewMockTransfer(ctl)
+	wallets := mock_repository.NewMockWallet(ctl)
+	members := mock_repository.NewMockMember(ctl)
+	svc := NewTransfer(repo, wallets, members, WithTransferLogger(log))
+
+	data := &model.Transfer{ID: 1, FromWalletID: 1, ToWalletID: 2, Amount: amount("0.01"), Commission: amount("0.01"), CommissionID: helper.Uint64(2)}
+
+	repo.EXPECT().
+		FindByID(ctx, uint64(1)).
+		Return(data, nil)
+	expectRole(ctx, members, model.Editor, 1, 2)
+
+	repo.EXPECT().
+		DeleteByID(ctx, uint64(1)).
+		Return(data, nil)
+
+	response, err := svc.DeleteByID(ctx, &service.TransferDeleteByIDRequest{ID: 1})
+	require.NoError(t, err)
+	require.Equal(t, &service.TransferDeleteByIDResponse{Data: data}, response)
+}
+
+func TestTransfer_CreateForbidden(t *testing.T) {
+	ctx := context.Background()
+	ctl := gomock.NewController(t)
+	repo := mock_repository.NewMockTransfer(ctl)
+	wallets := mock_repository.NewMockWallet(ctl)
+	members := mock_repository.NewMockMember(ctl)
+	svc := NewTransfer(repo, wallets, members, WithTransferLogger(log))
+
+	expectRole(ctx, members, model.Owner, 1)
+	expectRole(ctx, members, model.Viewer, 2)
+
+	response, err := svc.Create(ctx, &service.TransferCreateRequest{Data: &model.Transfer{FromWalletID: 1, ToWalletID: 2, Amount: amount("0.01")}})
+	require.Zero(t, response)
+	require.ErrorIs(t, err, service.ErrForbidden)
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/pkg/logger"
+	"github.com/mustan989/wallet/repository"
+	"github.com/mustan989/wallet/service"
+)
+
+type UserOption func(u *user)
+
+func WithUserLogger(log logger.Logger) UserOption {
+	return func(u *user) { u.log = log }
+}
+
+func NewUser(repo repository.User, options ...UserOption) service.User {
+	u := &user{
+		log:  logger.Default(),
+		repo: repo,
+	}
+
+	for _, option := range options {
+		option(u)
+	}
+
+	return u
+}
+
+type user struct {
+	log logger.Logger
+
+	repo repository.User
+}
+
+func (u *user) Get(ctx context.Context, _ *service.UserGetRequest) (*service.UserGetResponse, error) {
+	id, err := model.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := u.repo.FindByID(ctx, id)
+	if err != nil {
+		u.log.Errorf("Error getting user by id %d: %s", id, err)
+		return nil, err
+	}
+	return &service.UserGetResponse{Data: data}, nil
+}
+
+func (u *user) Update(ctx context.Context, request *service.UserUpdateRequest) (*service.UserUpdateResponse, error) {
+	id, err := model.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = validateUser(request.Data); err != nil {
+		return nil, err
+	}
+	request.Data.ID = id
+
+	if err = u.repo.Update(ctx, request.Data); err != nil {
+		u.log.Errorf("Error updating user: %s", err)
+		return nil, err
+	}
+	return &service.UserUpdateResponse{Data: request.Data}, nil
+}
+
+func validateUser(data *model.User) error {
+	data.Name = strings.TrimSpace(data.Name)
+	if data.Name == "" {
+		return fmt.Errorf("%w: name is required", service.ErrInvalidArgument)
+	}
+
+	address, err := mail.ParseAddress(strings.TrimSpace(data.Email))
+	if err != nil || address.Name != "" {
+		return fmt.Errorf("%w: email must be a valid address", service.ErrInvalidArgument)
+	}
+	data.Email = strings.ToLower(address.Address)
+
+	return nil
+}
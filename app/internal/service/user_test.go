@@ -0,0 +1,52 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	mock_repository "github.com/mustan989/wallet/app/internal/repository/mock"
+	. "github.com/mustan989/wallet/app/internal/service"
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/service"
+)
+
+func TestUser_Get(t *testing.T) {
+	ctl := gomock.NewController(t)
+	repo := mock_repository.NewMockUser(ctl)
+	svc := NewUser(repo, WithUserLogger(log))
+
+	t.Run("User", func(t *testing.T) {
+		ctx := model.WithUserID(context.Background(), 1)
+		data := &model.User{ID: 1, Name: "name", Email: "name@example.com"}
+
+		repo.EXPECT().FindByID(ctx, uint64(1)).Return(data, nil)
+
+		response, err := svc.Get(ctx, &service.UserGetRequest{})
+		require.NoError(t, err)
+		require.Equal(t, data, response.Data)
+	})
+
+	t.Run("No user", func(t *testing.T) {
+		response, err := svc.Get(context.Background(), &service.UserGetRequest{})
+		require.Zero(t, response)
+		require.Equal(t, model.ErrNoUser, err)
+	})
+}
+
+func TestUser_Update(t *testing.T) {
+	ctx := model.WithUserID(context.Background(), 1)
+	ctl := gomock.NewController(t)
+	repo := mock_repository.NewMockUser(ctl)
+	svc := NewUser(repo, WithUserLogger(log))
+
+	repo.EXPECT().
+		Update(ctx, &model.User{ID: 1, Name: "name", Email: "name@example.com"}).
+		Return(nil)
+
+	response, err := svc.Update(ctx, &service.UserUpdateRequest{Data: &model.User{ID: 2, Name: "name", Email: "name@example.com"}})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), response.Data.ID)
+}
@@ -2,8 +2,10 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"github.com/mustan989/wallet/model"
 	"github.com/mustan989/wallet/pkg/helper"
 	"github.com/mustan989/wallet/pkg/logger"
 	"github.com/mustan989/wallet/repository"
@@ -14,10 +16,11 @@ type WalletOption func(w *wallet)
 
 func WithLogger(log logger.Logger) WalletOption { return func(w *wallet) { w.log = log } }
 
-func NewWallet(repo repository.Wallet, options ...WalletOption) service.Wallet {
+func NewWallet(repo repository.Wallet, members repository.Member, options ...WalletOption) service.Wallet {
 	w := &wallet{
-		log:  logger.Default(),
-		repo: repo,
+		log:     logger.Default(),
+		repo:    repo,
+		members: members,
 	}
 
 	for _, option := range options {
@@ -30,10 +33,15 @@ func NewWallet(repo repository.Wallet, options ...WalletOption) service.Wallet {
 type wallet struct {
 	log logger.Logger
 
-	repo repository.Wallet
+	repo    repository.Wallet
+	members repository.Member
 }
 
 func (w *wallet) Count(ctx context.Context, request *service.WalletCountRequest) (*service.WalletCountResponse, error) {
+	if err := validateWalletFilter(request.Filter); err != nil {
+		return nil, err
+	}
+
 	count, err := w.repo.CountAll(ctx, request.Filter)
 	if err != nil {
 		w.log.Errorf("Error getting wallet count: %s", err)
@@ -43,6 +51,10 @@ func (w *wallet) Count(ctx context.Context, request *service.WalletCountRequest)
 }
 
 func (w *wallet) GetAll(ctx context.Context, request *service.WalletGetAllRequest) (*service.WalletGetAllResponse, error) {
+	if err := validateWalletFilter(request.Filter); err != nil {
+		return nil, err
+	}
+
 	data, err := w.repo.FindAll(ctx, request.Filter)
 	if err != nil {
 		w.log.Errorf("Error getting wallets: %s", err)
@@ -54,10 +66,14 @@ func (w *wallet) GetAll(ctx context.Context, request *service.WalletGetAllReques
 		return nil, err
 	}
 
-	return &service.WalletGetAllResponse{
+	response := &service.WalletGetAllResponse{
 		Data:  data,
 		Total: count.Count,
-	}, nil
+	}
+	if request.Filter.Full(len(data)) {
+		response.Next = request.Filter.Next(data[len(data)-1])
+	}
+	return response, nil
 }
 
 func (w *wallet) GetByID(ctx context.Context, request *service.WalletGetByIDRequest) (*service.WalletGetByIDResponse, error) {
@@ -70,6 +86,10 @@ func (w *wallet) GetByID(ctx context.Context, request *service.WalletGetByIDRequ
 }
 
 func (w *wallet) Create(ctx context.Context, request *service.WalletCreateRequest) (*service.WalletCreateResponse, error) {
+	if err := bind(request.Data.Currency, &request.Data.Amount); err != nil {
+		return nil, err
+	}
+
 	if err := w.repo.Create(ctx, request.Data); err != nil {
 		w.log.Errorf("Error creating wallet: %s", err)
 		return nil, err
@@ -78,7 +98,32 @@ func (w *wallet) Create(ctx context.Context, request *service.WalletCreateReques
 }
 
 func (w *wallet) Update(ctx context.Context, request *service.WalletUpdateRequest) (*service.WalletUpdateResponse, error) {
-	if err := w.repo.Update(ctx, request.Data); err != nil {
+	old, err := w.repo.FindByID(ctx, request.Data.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the owner only changes through the members, the deletion through DeleteByID and Restore
+	// and the amount through transactions and transfers
+	request.Data.OwnerID, request.Data.DeletedAt, request.Data.Amount = old.OwnerID, old.DeletedAt, old.Amount
+
+	// making the wallet personal hides it from the members, so it is up to the owner
+	required := model.Editor
+	if old.Personal != request.Data.Personal {
+		required = model.Owner
+	}
+	if err = authorize(ctx, w.members, required, old.ID); err != nil {
+		return nil, err
+	}
+	if err = changeCurrency(old, request.Data); err != nil {
+		return nil, err
+	}
+	// clients that do not send a version update the latest one
+	if request.Data.Version == 0 {
+		request.Data.Version = old.Version
+	}
+
+	if err = w.repo.Update(ctx, request.Data); err != nil {
 		w.log.Errorf("Error updating wallet: %s", err)
 		return nil, err
 	}
@@ -90,6 +135,9 @@ func (w *wallet) DeleteByID(ctx context.Context, request *service.WalletDeleteBy
 	if err != nil {
 		return nil, err
 	}
+	if err = authorize(ctx, w.members, model.Owner, data.ID); err != nil {
+		return nil, err
+	}
 
 	if data.DeletedAt == nil {
 		data.DeletedAt = helper.Timep(time.Now())
@@ -107,3 +155,69 @@ func (w *wallet) DeleteByID(ctx context.Context, request *service.WalletDeleteBy
 	}
 	return &service.WalletDeleteByIDResponse{Data: deleted}, nil
 }
+
+func (w *wallet) Restore(ctx context.Context, request *service.WalletRestoreRequest) (*service.WalletRestoreResponse, error) {
+	data, err := w.repo.FindByID(ctx, request.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err = authorize(ctx, w.members, model.Owner, data.ID); err != nil {
+		return nil, err
+	}
+	if data.DeletedAt == nil {
+		return nil, fmt.Errorf("%w: wallet is not deleted", service.ErrInvalidArgument)
+	}
+
+	data.DeletedAt = nil
+	if err = w.repo.Update(ctx, data); err != nil {
+		w.log.Errorf("Error restoring wallet: %s", err)
+		return nil, err
+	}
+	return &service.WalletRestoreResponse{Data: data}, nil
+}
+
+func (w *wallet) Purge(ctx context.Context, request *service.WalletPurgeRequest) (*service.WalletPurgeResponse, error) {
+	if request.Retention <= 0 {
+		return nil, fmt.Errorf("%w: retention must be positive", service.ErrInvalidArgument)
+	}
+
+	count, err := w.repo.Purge(ctx, time.Now().Add(-request.Retention))
+	if err != nil {
+		w.log.Errorf("Error purging deleted wallets: %s", err)
+		return nil, err
+	}
+	return &service.WalletPurgeResponse{Count: count}, nil
+}
+
+// changeCurrency binds the amount of the wallet to the currency it changes to. The history stays in the minor units
+// it was recorded in, so the currency must have as many of them at least, e.g. a wallet in USD can not change to JPY
+func changeCurrency(old, data *model.Wallet) error {
+	amount, err := old.Amount.In(model.Unbound)
+	if err != nil {
+		return err
+	}
+	if err = bind(data.Currency, &amount); err != nil {
+		return err
+	}
+	if amount.Currency.Exponent < old.Amount.Currency.Exponent {
+		return fmt.Errorf("%w: %s has fewer minor digits than %s", service.ErrInvalidArgument, data.Currency, old.Currency)
+	}
+	data.Amount = amount
+	return nil
+}
+
+func validateWalletFilter(filter *model.WalletFilter) error {
+	if !filter.Sort.Valid() {
+		return fmt.Errorf("%w: wallets can not be sorted by %q", service.ErrInvalidArgument, filter.Sort)
+	}
+	if !filter.Order.Valid() {
+		return fmt.Errorf("%w: order must be %q or %q", service.ErrInvalidArgument, model.Asc, model.Desc)
+	}
+	if !filter.Deleted.Valid() {
+		return fmt.Errorf("%w: deleted must be %q, %q or %q", service.ErrInvalidArgument, model.DeletedInclude, model.DeletedExclude, model.DeletedOnly)
+	}
+	if filter.AmountMin != nil && filter.AmountMax != nil && filter.AmountMin.Cmp(*filter.AmountMax) > 0 {
+		return fmt.Errorf("%w: amount min is greater than amount max", service.ErrInvalidArgument)
+	}
+	return nil
+}
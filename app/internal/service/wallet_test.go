@@ -30,6 +30,28 @@ func stringp(s string) *string     { return &s }
 func boolp(b bool) *bool           { return &b }
 func timep(t time.Time) *time.Time { return &t }
 
+// amount parses the amount as requests carry it, unbound to any currency
+func amount(text string) model.Money {
+	m, err := model.ParseMoney(text, model.Unbound)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// money parses the amount as repositories return it, bound to the currency of the code
+func money(text, code string) model.Money {
+	c, err := model.CurrencyOf(code)
+	if err != nil {
+		panic(err)
+	}
+	m, err := model.ParseMoney(text, c)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
 func TestWallet_Count(t *testing.T) {
 	subtests := [...]struct {
 		name   string
@@ -79,7 +101,8 @@ func TestWallet_Count(t *testing.T) {
 			ctx := context.Background()
 			ctl := gomock.NewController(t)
 			repo := mock_repository.NewMockWallet(ctl)
-			svc := NewWallet(repo, WithLogger(log))
+			members := mock_repository.NewMockMember(ctl)
+			svc := NewWallet(repo, members, WithLogger(log))
 
 			repo.EXPECT().
 				CountAll(ctx, subtest.input.Filter).
@@ -110,7 +133,8 @@ func TestWallet_CountError(t *testing.T) {
 			ctx := context.Background()
 			ctl := gomock.NewController(t)
 			repo := mock_repository.NewMockWallet(ctl)
-			svc := NewWallet(repo, WithLogger(log))
+			members := mock_repository.NewMockMember(ctl)
+			svc := NewWallet(repo, members, WithLogger(log))
 
 			repo.EXPECT().
 				CountAll(ctx, subtest.input.Filter).
@@ -143,7 +167,7 @@ func TestWallet_GetAll(t *testing.T) {
 					Name:        "name 1",
 					Description: stringp("desc 1"),
 					Currency:    "KZT",
-					Amount:      9999,
+					Amount:      money("99.99", "KZT"),
 					Personal:    true,
 					CreatedAt:   time.Now().Add(-24 * time.Hour),
 					UpdatedAt:   time.Now().Add(-15 * time.Minute),
@@ -160,7 +184,7 @@ func TestWallet_GetAll(t *testing.T) {
 					Name:        "name 1",
 					Description: stringp("desc 1"),
 					Currency:    "KZT",
-					Amount:      9999,
+					Amount:      money("99.99", "KZT"),
 					Personal:    true,
 					CreatedAt:   time.Now().Add(-24 * time.Hour),
 					UpdatedAt:   time.Now().Add(-15 * time.Minute),
@@ -179,7 +203,7 @@ func TestWallet_GetAll(t *testing.T) {
 					Name:        "name 1",
 					Description: stringp("desc 1"),
 					Currency:    "KZT",
-					Amount:      9999,
+					Amount:      money("99.99", "KZT"),
 					Personal:    true,
 					CreatedAt:   time.Now().Add(-24 * time.Hour),
 					UpdatedAt:   time.Now().Add(-15 * time.Minute),
@@ -196,7 +220,7 @@ func TestWallet_GetAll(t *testing.T) {
 					Name:        "name 1",
 					Description: stringp("desc 1"),
 					Currency:    "KZT",
-					Amount:      9999,
+					Amount:      money("99.99", "KZT"),
 					Personal:    true,
 					CreatedAt:   time.Now().Add(-24 * time.Hour),
 					UpdatedAt:   time.Now().Add(-15 * time.Minute),
@@ -213,7 +237,7 @@ func TestWallet_GetAll(t *testing.T) {
 					Name:        "name 1",
 					Description: stringp("desc 1"),
 					Currency:    "KZT",
-					Amount:      9999,
+					Amount:      money("99.99", "KZT"),
 					Personal:    true,
 					CreatedAt:   time.Now().Add(-24 * time.Hour),
 					UpdatedAt:   time.Now().Add(-15 * time.Minute),
@@ -230,7 +254,7 @@ func TestWallet_GetAll(t *testing.T) {
 					Name:        "name 1",
 					Description: stringp("desc 1"),
 					Currency:    "KZT",
-					Amount:      9999,
+					Amount:      money("99.99", "KZT"),
 					Personal:    true,
 					CreatedAt:   time.Now().Add(-24 * time.Hour),
 					UpdatedAt:   time.Now().Add(-15 * time.Minute),
@@ -244,7 +268,8 @@ func TestWallet_GetAll(t *testing.T) {
 			ctx := context.Background()
 			ctl := gomock.NewController(t)
 			repo := mock_repository.NewMockWallet(ctl)
-			svc := NewWallet(repo, WithLogger(log))
+			members := mock_repository.NewMockMember(ctl)
+			svc := NewWallet(repo, members, WithLogger(log))
 
 			repo.EXPECT().
 				FindAll(ctx, subtest.input.Filter).
@@ -279,7 +304,8 @@ func TestWallet_GetAllError(t *testing.T) {
 			ctx := context.Background()
 			ctl := gomock.NewController(t)
 			repo := mock_repository.NewMockWallet(ctl)
-			svc := NewWallet(repo, WithLogger(log))
+			members := mock_repository.NewMockMember(ctl)
+			svc := NewWallet(repo, members, WithLogger(log))
 
 			repo.EXPECT().
 				FindAll(ctx, subtest.input.Filter).
@@ -292,6 +318,65 @@ func TestWallet_GetAllError(t *testing.T) {
 	}
 }
 
+func TestWallet_GetAllNext(t *testing.T) {
+	ctx := context.Background()
+	ctl := gomock.NewController(t)
+	repo := mock_repository.NewMockWallet(ctl)
+	members := mock_repository.NewMockMember(ctl)
+	svc := NewWallet(repo, members, WithLogger(log))
+
+	data := []*model.Wallet{{ID: 5, Name: "name 5"}, {ID: 4, Name: "name 4"}}
+
+	full := &model.WalletFilter{Filter: model.Filter{Limit: 2}, Sort: model.WalletSortName}
+	repo.EXPECT().FindAll(ctx, full).Return(data, nil)
+	repo.EXPECT().CountAll(ctx, full).Return(uint64(3), nil)
+
+	response, err := svc.GetAll(ctx, &service.WalletGetAllRequest{Filter: full})
+	require.NoError(t, err)
+	require.Equal(t, model.NewCursor("name asc", "name 4", 4), response.Next)
+
+	// the last page is not full
+	last := &model.WalletFilter{Filter: model.Filter{Limit: 2, Cursor: response.Next}, Sort: model.WalletSortName}
+	repo.EXPECT().FindAll(ctx, last).Return(data[:1], nil)
+	repo.EXPECT().CountAll(ctx, last).Return(uint64(3), nil)
+
+	response, err = svc.GetAll(ctx, &service.WalletGetAllRequest{Filter: last})
+	require.NoError(t, err)
+	require.Nil(t, response.Next)
+}
+
+func TestWallet_GetAllInvalidFilter(t *testing.T) {
+	min, max := amount("99.99"), amount("1.00")
+
+	subtests := [...]struct {
+		name   string
+		filter *model.WalletFilter
+	}{
+		{"Sort", &model.WalletFilter{Sort: "owner_id"}},
+		{"Order", &model.WalletFilter{Sort: model.WalletSortName, Order: "up"}},
+		{"Deleted", &model.WalletFilter{Deleted: "yes"}},
+		{"Amount range", &model.WalletFilter{AmountMin: &min, AmountMax: &max}},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			ctx := context.Background()
+			ctl := gomock.NewController(t)
+			repo := mock_repository.NewMockWallet(ctl)
+			members := mock_repository.NewMockMember(ctl)
+			svc := NewWallet(repo, members, WithLogger(log))
+
+			response, err := svc.GetAll(ctx, &service.WalletGetAllRequest{Filter: subtest.filter})
+			require.Zero(t, response)
+			require.ErrorIs(t, err, service.ErrInvalidArgument)
+
+			count, err := svc.Count(ctx, &service.WalletCountRequest{Filter: subtest.filter})
+			require.Zero(t, count)
+			require.ErrorIs(t, err, service.ErrInvalidArgument)
+		})
+	}
+}
+
 func TestWallet_GetAllCountError(t *testing.T) {
 	subtests := [...]struct {
 		name  string
@@ -310,7 +395,8 @@ func TestWallet_GetAllCountError(t *testing.T) {
 			ctx := context.Background()
 			ctl := gomock.NewController(t)
 			repo := mock_repository.NewMockWallet(ctl)
-			svc := NewWallet(repo, WithLogger(log))
+			members := mock_repository.NewMockMember(ctl)
+			svc := NewWallet(repo, members, WithLogger(log))
 
 			repo.EXPECT().
 				FindAll(ctx, subtest.input.Filter).
@@ -342,7 +428,7 @@ func TestWallet_GetByID(t *testing.T) {
 				Name:        "name",
 				Description: stringp("desc"),
 				Currency:    "KZT",
-				Amount:      9999,
+				Amount:      money("99.99", "KZT"),
 				Personal:    true,
 				CreatedAt:   time.Now(),
 				UpdatedAt:   time.Now(),
@@ -356,7 +442,8 @@ func TestWallet_GetByID(t *testing.T) {
 			ctx := context.Background()
 			ctl := gomock.NewController(t)
 			repo := mock_repository.NewMockWallet(ctl)
-			svc := NewWallet(repo, WithLogger(log))
+			members := mock_repository.NewMockMember(ctl)
+			svc := NewWallet(repo, members, WithLogger(log))
 
 			repo.EXPECT().
 				FindByID(ctx, subtest.input.ID).
@@ -392,7 +479,8 @@ func TestWallet_GetByIDError(t *testing.T) {
 			ctx := context.Background()
 			ctl := gomock.NewController(t)
 			repo := mock_repository.NewMockWallet(ctl)
-			svc := NewWallet(repo, WithLogger(log))
+			members := mock_repository.NewMockMember(ctl)
+			svc := NewWallet(repo, members, WithLogger(log))
 
 			repo.EXPECT().
 				FindByID(ctx, subtest.input.ID).
@@ -419,7 +507,7 @@ func TestWallet_Create(t *testing.T) {
 				Name:        "name",
 				Description: stringp("desc"),
 				Currency:    "KZT",
-				Amount:      9999,
+				Amount:      amount("99.99"),
 				Personal:    true,
 			}},
 			&service.WalletCreateResponse{Data: &model.Wallet{
@@ -427,7 +515,7 @@ func TestWallet_Create(t *testing.T) {
 				Name:        "name",
 				Description: stringp("desc"),
 				Currency:    "KZT",
-				Amount:      9999,
+				Amount:      money("99.99", "KZT"),
 				Personal:    true,
 				CreatedAt:   now,
 				UpdatedAt:   now,
@@ -441,7 +529,8 @@ func TestWallet_Create(t *testing.T) {
 			ctx := context.Background()
 			ctl := gomock.NewController(t)
 			repo := mock_repository.NewMockWallet(ctl)
-			svc := NewWallet(repo, WithLogger(log))
+			members := mock_repository.NewMockMember(ctl)
+			svc := NewWallet(repo, members, WithLogger(log))
 
 			repo.EXPECT().
 				Create(ctx, subtest.input.Data).
@@ -471,7 +560,7 @@ func TestWallet_CreateError(t *testing.T) {
 				Name:        "name",
 				Description: stringp("desc"),
 				Currency:    "KZT",
-				Amount:      9999,
+				Amount:      amount("99.99"),
 				Personal:    true,
 			}},
 			errors.New("error"),
@@ -483,7 +572,8 @@ func TestWallet_CreateError(t *testing.T) {
 			ctx := context.Background()
 			ctl := gomock.NewController(t)
 			repo := mock_repository.NewMockWallet(ctl)
-			svc := NewWallet(repo, WithLogger(log))
+			members := mock_repository.NewMockMember(ctl)
+			svc := NewWallet(repo, members, WithLogger(log))
 
 			repo.EXPECT().
 				Create(ctx, subtest.input.Data).
@@ -501,17 +591,20 @@ func TestWallet_Update(t *testing.T) {
 
 	subtests := [...]struct {
 		name   string
+		role   model.Role
 		input  *service.WalletUpdateRequest
 		expect *service.WalletUpdateResponse
 	}{
 		{
+			// the amount is kept as stored
 			"Update",
+			model.Editor,
 			&service.WalletUpdateRequest{Data: &model.Wallet{
 				ID:          1,
 				Name:        "name",
 				Description: stringp("desc"),
 				Currency:    "KZT",
-				Amount:      9999,
+				Amount:      amount("99.99"),
 				Personal:    true,
 			}},
 			&service.WalletUpdateResponse{Data: &model.Wallet{
@@ -519,36 +612,13 @@ func TestWallet_Update(t *testing.T) {
 				Name:        "name",
 				Description: stringp("desc"),
 				Currency:    "KZT",
-				Amount:      9999,
+				Amount:      money("5.00", "KZT"),
 				Personal:    true,
 				CreatedAt:   now.Add(-24 * time.Hour),
 				UpdatedAt:   now,
 				DeletedAt:   nil,
 			}},
 		},
-		{
-			"Delete",
-			&service.WalletUpdateRequest{Data: &model.Wallet{
-				ID:          1,
-				Name:        "name",
-				Description: stringp("desc"),
-				Currency:    "KZT",
-				Amount:      9999,
-				Personal:    true,
-				DeletedAt:   timep(now),
-			}},
-			&service.WalletUpdateResponse{Data: &model.Wallet{
-				ID:          1,
-				Name:        "name",
-				Description: stringp("desc"),
-				Currency:    "KZT",
-				Amount:      9999,
-				Personal:    true,
-				CreatedAt:   now.Add(-24 * time.Hour),
-				UpdatedAt:   now,
-				DeletedAt:   timep(now),
-			}},
-		},
 	}
 
 	for _, subtest := range subtests {
@@ -556,14 +626,19 @@ func TestWallet_Update(t *testing.T) {
 			ctx := context.Background()
 			ctl := gomock.NewController(t)
 			repo := mock_repository.NewMockWallet(ctl)
-			svc := NewWallet(repo, WithLogger(log))
+			members := mock_repository.NewMockMember(ctl)
+			svc := NewWallet(repo, members, WithLogger(log))
+
+			repo.EXPECT().
+				FindByID(ctx, subtest.input.Data.ID).
+				Return(&model.Wallet{ID: subtest.input.Data.ID, Currency: "KZT", Amount: money("5.00", "KZT"), Personal: subtest.input.Data.Personal}, nil)
+			expectRole(ctx, members, subtest.role, subtest.input.Data.ID)
 
 			repo.EXPECT().
 				Update(ctx, subtest.input.Data).
 				DoAndReturn(func(_ context.Context, data *model.Wallet) error {
 					data.CreatedAt = subtest.expect.Data.CreatedAt
 					data.UpdatedAt = subtest.expect.Data.UpdatedAt
-					data.DeletedAt = subtest.expect.Data.DeletedAt
 					return nil
 				})
 
@@ -574,6 +649,125 @@ func TestWallet_Update(t *testing.T) {
 	}
 }
 
+func TestWallet_UpdateDeletion(t *testing.T) {
+	deletedAt := time.Now().Add(-time.Hour)
+
+	subtests := [...]struct {
+		name      string
+		deletedAt *time.Time
+		input     *time.Time
+	}{
+		{"Restore", &deletedAt, nil},
+		{"Delete", nil, timep(time.Now())},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			ctx := context.Background()
+			ctl := gomock.NewController(t)
+			repo := mock_repository.NewMockWallet(ctl)
+			members := mock_repository.NewMockMember(ctl)
+			svc := NewWallet(repo, members, WithLogger(log))
+
+			repo.EXPECT().
+				FindByID(ctx, uint64(1)).
+				Return(&model.Wallet{ID: 1, OwnerID: 2, Name: "name", Currency: "KZT", DeletedAt: subtest.deletedAt, Version: 1}, nil)
+			expectRole(ctx, members, model.Editor, 1)
+
+			// the editor can neither delete, restore nor hand over the wallet by sending it back changed
+			repo.EXPECT().
+				Update(ctx, gomock.Any()).
+				DoAndReturn(func(_ context.Context, data *model.Wallet) error {
+					require.Equal(t, uint64(2), data.OwnerID)
+					require.Equal(t, subtest.deletedAt, data.DeletedAt)
+					return nil
+				})
+
+			response, err := svc.Update(ctx, &service.WalletUpdateRequest{Data: &model.Wallet{
+				ID: 1, OwnerID: 3, Name: "renamed", Currency: "KZT", DeletedAt: subtest.input,
+			}})
+			require.NoError(t, err)
+			require.Equal(t, "renamed", response.Data.Name)
+			require.Equal(t, subtest.deletedAt, response.Data.DeletedAt)
+		})
+	}
+}
+
+func TestWallet_UpdateVersion(t *testing.T) {
+	subtests := [...]struct {
+		name    string
+		version uint64
+		expect  uint64
+		err     error
+	}{
+		{"Latest", 0, 3, nil},
+		{"Current", 3, 3, nil},
+		{"Stale", 2, 2, repository.ErrWalletStale},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			ctx := context.Background()
+			ctl := gomock.NewController(t)
+			repo := mock_repository.NewMockWallet(ctl)
+			members := mock_repository.NewMockMember(ctl)
+			svc := NewWallet(repo, members, WithLogger(log))
+
+			repo.EXPECT().
+				FindByID(ctx, uint64(1)).
+				Return(&model.Wallet{ID: 1, Name: "name", Currency: "KZT", Amount: money("0", "KZT"), Version: 3}, nil)
+			expectRole(ctx, members, model.Editor, 1)
+			repo.EXPECT().
+				Update(ctx, &model.Wallet{ID: 1, Name: "new name", Currency: "KZT", Amount: money("0", "KZT"), Version: subtest.expect}).
+				Return(subtest.err)
+
+			_, err := svc.Update(ctx, &service.WalletUpdateRequest{
+				Data: &model.Wallet{ID: 1, Name: "new name", Currency: "KZT", Version: subtest.version},
+			})
+			require.Equal(t, subtest.err, err)
+		})
+	}
+}
+
+func TestWallet_UpdateCurrency(t *testing.T) {
+	subtests := [...]struct {
+		name     string
+		currency string
+		expect   model.Money
+		err      error
+	}{
+		{"Same", "USD", money("1.00", "USD"), nil},
+		{"More minor digits", "KWD", money("1.000", "KWD"), nil},
+		{"Fewer minor digits", "JPY", model.Money{}, service.ErrInvalidArgument},
+		{"Invalid", "usd", model.Money{}, service.ErrInvalidArgument},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			ctx := context.Background()
+			ctl := gomock.NewController(t)
+			repo := mock_repository.NewMockWallet(ctl)
+			members := mock_repository.NewMockMember(ctl)
+			svc := NewWallet(repo, members, WithLogger(log))
+
+			repo.EXPECT().
+				FindByID(ctx, uint64(1)).
+				Return(&model.Wallet{ID: 1, Name: "name", Currency: "USD", Amount: money("1.00", "USD"), Version: 1}, nil)
+			expectRole(ctx, members, model.Editor, 1)
+			if subtest.err == nil {
+				repo.EXPECT().
+					Update(ctx, &model.Wallet{ID: 1, Name: "name", Currency: subtest.currency, Amount: subtest.expect, Version: 1}).
+					Return(nil)
+			}
+
+			_, err := svc.Update(ctx, &service.WalletUpdateRequest{
+				Data: &model.Wallet{ID: 1, Name: "name", Currency: subtest.currency},
+			})
+			require.ErrorIs(t, err, subtest.err)
+		})
+	}
+}
+
 func TestWallet_UpdateError(t *testing.T) {
 	subtests := [...]struct {
 		name  string
@@ -587,7 +781,7 @@ func TestWallet_UpdateError(t *testing.T) {
 				Name:        "name",
 				Description: stringp("desc"),
 				Currency:    "KZT",
-				Amount:      9999,
+				Amount:      amount("99.99"),
 				Personal:    true,
 			}},
 			errors.New("error"),
@@ -599,7 +793,13 @@ func TestWallet_UpdateError(t *testing.T) {
 			ctx := context.Background()
 			ctl := gomock.NewController(t)
 			repo := mock_repository.NewMockWallet(ctl)
-			svc := NewWallet(repo, WithLogger(log))
+			members := mock_repository.NewMockMember(ctl)
+			svc := NewWallet(repo, members, WithLogger(log))
+
+			repo.EXPECT().
+				FindByID(ctx, subtest.input.Data.ID).
+				Return(&model.Wallet{ID: subtest.input.Data.ID, Personal: subtest.input.Data.Personal}, nil)
+			expectRole(ctx, members, model.Editor, subtest.input.Data.ID)
 
 			repo.EXPECT().
 				Update(ctx, subtest.input.Data).
@@ -628,7 +828,7 @@ func TestWallet_DeleteByID(t *testing.T) {
 				Name:        "name",
 				Description: stringp("desc"),
 				Currency:    "KZT",
-				Amount:      9999,
+				Amount:      money("99.99", "KZT"),
 				Personal:    true,
 				CreatedAt:   now.Add(-24 * time.Hour),
 				UpdatedAt:   now.Add(-12 * time.Hour),
@@ -642,11 +842,13 @@ func TestWallet_DeleteByID(t *testing.T) {
 			ctx := context.Background()
 			ctl := gomock.NewController(t)
 			repo := mock_repository.NewMockWallet(ctl)
-			svc := NewWallet(repo, WithLogger(log))
+			members := mock_repository.NewMockMember(ctl)
+			svc := NewWallet(repo, members, WithLogger(log))
 
 			repo.EXPECT().
 				FindByID(ctx, subtest.input.ID).
 				Return(subtest.expect.Data, nil)
+			expectRole(ctx, members, model.Owner, subtest.input.ID)
 
 			repo.EXPECT().
 				DeleteByID(ctx, subtest.input.ID).
@@ -675,7 +877,7 @@ func TestWallet_DeleteByIDSoft(t *testing.T) {
 				Name:        "name",
 				Description: stringp("desc"),
 				Currency:    "KZT",
-				Amount:      9999,
+				Amount:      money("99.99", "KZT"),
 				Personal:    true,
 				CreatedAt:   now.Add(-24 * time.Hour),
 				UpdatedAt:   now,
@@ -689,7 +891,8 @@ func TestWallet_DeleteByIDSoft(t *testing.T) {
 			ctx := context.Background()
 			ctl := gomock.NewController(t)
 			repo := mock_repository.NewMockWallet(ctl)
-			svc := NewWallet(repo, WithLogger(log))
+			members := mock_repository.NewMockMember(ctl)
+			svc := NewWallet(repo, members, WithLogger(log))
 
 			data := subtest.expect.Data
 			repo.EXPECT().
@@ -698,6 +901,7 @@ func TestWallet_DeleteByIDSoft(t *testing.T) {
 					data.DeletedAt = nil
 					return data, nil
 				})
+			expectRole(ctx, members, model.Owner, subtest.input.ID)
 
 			repo.EXPECT().
 				Update(ctx, data).
@@ -731,12 +935,14 @@ func TestWallet_DeleteByIDError(t *testing.T) {
 			ctx := context.Background()
 			ctl := gomock.NewController(t)
 			repo := mock_repository.NewMockWallet(ctl)
-			svc := NewWallet(repo, WithLogger(log))
+			members := mock_repository.NewMockMember(ctl)
+			svc := NewWallet(repo, members, WithLogger(log))
 
 			data := &model.Wallet{ID: subtest.input.ID, DeletedAt: helper.Timep(time.Now())}
 			repo.EXPECT().
 				FindByID(ctx, subtest.input.ID).
 				Return(data, nil)
+			expectRole(ctx, members, model.Owner, subtest.input.ID)
 
 			repo.EXPECT().
 				DeleteByID(ctx, subtest.input.ID).
@@ -772,7 +978,8 @@ func TestWallet_DeleteByIDFindError(t *testing.T) {
 			ctx := context.Background()
 			ctl := gomock.NewController(t)
 			repo := mock_repository.NewMockWallet(ctl)
-			svc := NewWallet(repo, WithLogger(log))
+			members := mock_repository.NewMockMember(ctl)
+			svc := NewWallet(repo, members, WithLogger(log))
 
 			repo.EXPECT().
 				FindByID(ctx, subtest.input.ID).
@@ -803,12 +1010,14 @@ func TestWallet_DeleteByIDSoftError(t *testing.T) {
 			ctx := context.Background()
 			ctl := gomock.NewController(t)
 			repo := mock_repository.NewMockWallet(ctl)
-			svc := NewWallet(repo, WithLogger(log))
+			members := mock_repository.NewMockMember(ctl)
+			svc := NewWallet(repo, members, WithLogger(log))
 
 			data := &model.Wallet{ID: subtest.input.ID}
 			repo.EXPECT().
 				FindByID(ctx, subtest.input.ID).
 				Return(data, nil)
+			expectRole(ctx, members, model.Owner, subtest.input.ID)
 
 			repo.EXPECT().
 				Update(ctx, data).
@@ -820,3 +1029,136 @@ func TestWallet_DeleteByIDSoftError(t *testing.T) {
 		})
 	}
 }
+
+func TestWallet_Forbidden(t *testing.T) {
+	subtests := [...]struct {
+		name string
+		role model.Role
+		call func(svc service.Wallet) (any, error)
+	}{
+		{"Viewer update", model.Viewer, func(svc service.Wallet) (any, error) {
+			return svc.Update(context.Background(), &service.WalletUpdateRequest{Data: &model.Wallet{ID: 1, Name: "name"}})
+		}},
+		{"Editor make personal", model.Editor, func(svc service.Wallet) (any, error) {
+			return svc.Update(context.Background(), &service.WalletUpdateRequest{Data: &model.Wallet{ID: 1, Name: "name", Personal: true}})
+		}},
+		{"Editor delete", model.Editor, func(svc service.Wallet) (any, error) {
+			return svc.DeleteByID(context.Background(), &service.WalletDeleteByIDRequest{ID: 1})
+		}},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			ctx := context.Background()
+			ctl := gomock.NewController(t)
+			repo := mock_repository.NewMockWallet(ctl)
+			members := mock_repository.NewMockMember(ctl)
+			svc := NewWallet(repo, members, WithLogger(log))
+
+			repo.EXPECT().
+				FindByID(ctx, uint64(1)).
+				Return(&model.Wallet{ID: 1, OwnerID: 2, Name: "name"}, nil)
+			expectRole(ctx, members, subtest.role, 1)
+
+			response, err := subtest.call(svc)
+			require.Nil(t, response)
+			require.ErrorIs(t, err, service.ErrForbidden)
+		})
+	}
+}
+
+func TestWallet_CreateInexact(t *testing.T) {
+	ctl := gomock.NewController(t)
+	repo := mock_repository.NewMockWallet(ctl)
+	members := mock_repository.NewMockMember(ctl)
+	svc := NewWallet(repo, members, WithLogger(log))
+
+	subtests := [...]struct {
+		name  string
+		input *model.Wallet
+	}{
+		{"Yen cents", &model.Wallet{Name: "name", Currency: "JPY", Amount: amount("1.50")}},
+		{"Tenths of fils", &model.Wallet{Name: "name", Currency: "KWD", Amount: amount("1.2345")}},
+		{"Invalid currency", &model.Wallet{Name: "name", Currency: "usd"}},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			response, err := svc.Create(context.Background(), &service.WalletCreateRequest{Data: subtest.input})
+			require.Nil(t, response)
+			require.ErrorIs(t, err, service.ErrInvalidArgument)
+		})
+	}
+}
+
+func TestWallet_Restore(t *testing.T) {
+	deletedAt := time.Now().Add(-time.Hour)
+
+	subtests := [...]struct {
+		name      string
+		deletedAt *time.Time
+		role      model.Role
+		err       error
+	}{
+		{"Restored", &deletedAt, model.Owner, nil},
+		{"Not deleted", nil, model.Owner, service.ErrInvalidArgument},
+		{"Forbidden", &deletedAt, model.Editor, service.ErrForbidden},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			ctx := context.Background()
+			ctl := gomock.NewController(t)
+			repo := mock_repository.NewMockWallet(ctl)
+			members := mock_repository.NewMockMember(ctl)
+			svc := NewWallet(repo, members, WithLogger(log))
+
+			data := &model.Wallet{ID: 1, Name: "name", Currency: "KZT", DeletedAt: subtest.deletedAt}
+
+			repo.EXPECT().
+				FindByID(ctx, uint64(1)).
+				Return(data, nil)
+			expectRole(ctx, members, subtest.role, 1)
+			if subtest.err == nil {
+				repo.EXPECT().
+					Update(ctx, &model.Wallet{ID: 1, Name: "name", Currency: "KZT"}).
+					Return(nil)
+			}
+
+			response, err := svc.Restore(ctx, &service.WalletRestoreRequest{ID: 1})
+			if subtest.err != nil {
+				require.Nil(t, response)
+				require.ErrorIs(t, err, subtest.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Nil(t, response.Data.DeletedAt)
+		})
+	}
+}
+
+func TestWallet_Purge(t *testing.T) {
+	ctx := context.Background()
+	ctl := gomock.NewController(t)
+	repo := mock_repository.NewMockWallet(ctl)
+	members := mock_repository.NewMockMember(ctl)
+	svc := NewWallet(repo, members, WithLogger(log))
+
+	retention := 30 * 24 * time.Hour
+	from := time.Now().Add(-retention)
+
+	repo.EXPECT().
+		Purge(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, before time.Time) (uint64, error) {
+			require.WithinDuration(t, from, before, time.Second)
+			return 2, nil
+		})
+
+	response, err := svc.Purge(ctx, &service.WalletPurgeRequest{Retention: retention})
+	require.NoError(t, err)
+	require.Equal(t, &service.WalletPurgeResponse{Count: 2}, response)
+
+	response, err = svc.Purge(ctx, &service.WalletPurgeRequest{})
+	require.Nil(t, response)
+	require.ErrorIs(t, err, service.ErrInvalidArgument)
+}
@@ -2,83 +2,105 @@ package main
 
 import (
 	"context"
-	"errors"
+	"flag"
 	"fmt"
-	"net/http"
+	"io"
 	"os"
-	"time"
 
-	"github.com/labstack/echo/v4"
-
-	. "github.com/mustan989/wallet/app/config"
-	"github.com/mustan989/wallet/pkg/config"
 	"github.com/mustan989/wallet/pkg/logger"
-	"github.com/mustan989/wallet/pkg/postgres"
-	"github.com/mustan989/wallet/pkg/shutdown"
 )
 
-// TODO: mv to env
-const configPath = "configs/sandbox.yaml"
+const (
+	defaultConfigPath = "configs/sandbox.yaml"
+	// configPathEnv overrides the default config path, the -config flag overrides both
+	configPathEnv = "CONFIG_PATH"
+)
 
-func main() {
-	ctx := context.Background()
+// command is a subcommand of the binary, run gets the arguments following its name
+type command struct {
+	usage       string
+	description string
+	run         func(ctx context.Context, a *app, args []string) error
+}
 
-	log := logger.NewLogger(
-		logger.WithOutputFormat(logger.JSON),
-		logger.WithSeverityWriter(logger.Error, os.Stderr),
-	)
+var commands = map[string]*command{
+	"serve":   {"serve", "start the http server", serve},
+	"migrate": {"migrate up|down|to <version>|baseline <version>|status", "manage the database schema", migrateCommand},
+	"seed":    {"seed -email <email> -password <password> [-name <name>]", "register a user with default categories and a wallet", seed},
+	"export":  {"export -email <email> [-file <path>]", "write the wallets of a user as json, stdout by default", export},
+	"import":  {"import -email <email> [-file <path>]", "recreate exported wallets for a user, stdin by default", importCommand},
+	"purge":   {"purge [-retention <duration>]", "permanently delete wallets deleted longer than the retention ago", purge},
+}
 
-	log.Infof("Starting app")
-	log.Infof("Getting config")
+// commandOrder is the order of the commands in the usage
+var commandOrder = []string{"serve", "migrate", "seed", "export", "import", "purge"}
 
-	var cfg Config
+func main() {
+	ctx := context.Background()
 
-	if err := config.ParseConfigFile(configPath, &cfg); err != nil {
-		log.Fatalf("Error parsing config file: %s", err)
+	configPath := defaultConfigPath
+	if path, ok := os.LookupEnv(configPathEnv); ok && path != "" {
+		configPath = path
 	}
 
-	log.Infof("Config successfully loaded")
+	flags := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	flags.StringVar(&configPath, "config", configPath, "path to the config file, also set with "+configPathEnv)
+	flags.Usage = func() { usage(flags) }
+	_ = flags.Parse(os.Args[1:])
 
-	connCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
+	// the server is started when no command is given
+	name, args := "serve", flags.Args()
+	if len(args) > 0 {
+		name, args = args[0], args[1:]
+	}
 
-	log.Infof("Connecting to database")
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", name)
+		usage(flags)
+		os.Exit(2)
+	}
 
-	pool, err := postgres.Connect(connCtx, cfg.Database)
+	log := newLogger(name)
+	a, err := bootstrap(ctx, configPath, log)
 	if err != nil {
-		log.Fatalf("Error connecting to database: %s", err)
+		log.Fatalf("Error starting app: %s", err)
 	}
-	if err = pool.Ping(ctx); err != nil {
-		log.Fatalf("Error pinging database: %s", err)
+
+	err = cmd.run(ctx, a, args)
+	a.close()
+	if err != nil {
+		log.Fatalf("Error running %s: %s", name, err)
 	}
 
-	log.Infof("Successfully connected to database")
-
-	e := echo.New()
-	e.HideBanner = true
-	e.HidePort = true
-
-	log.Infof("Starting server on port :%d", cfg.Server.Port)
-
-	go func() {
-		<-shutdown.GracefulShutdown(
-			ctx,
-			map[string]shutdown.Operation{
-				"Database": func(_ context.Context) error {
-					pool.Close()
-					return nil
-				},
-				"Server": func(ctx context.Context) error {
-					return e.Shutdown(ctx)
-				},
-			},
-			shutdown.WithLogger(log), shutdown.WithTimeout(1*time.Second),
-		)
-	}()
+	log.Infof("App finished successfully")
+}
 
-	if err = e.Start(fmt.Sprint(":", cfg.Server.Port)); err != nil && !errors.Is(err, http.ErrServerClosed) {
-		log.Errorf("Error running server: %s", err)
+// newLogger logs the server to stdout, the other commands log to stderr as stdout carries their output, e.g. the export dump
+func newLogger(name string) logger.FatalLogger {
+	if name == "serve" {
+		return logger.NewLogger(
+			logger.WithOutputFormat(logger.JSON),
+			logger.WithSeverityWriter(logger.Error, os.Stderr),
+		)
 	}
 
-	log.Infof("App finished successfully")
+	return logger.NewLogger(
+		logger.WithOutputFormat(logger.JSON),
+		logger.WithWriters(map[logger.Severity]io.Writer{
+			logger.Debug:   os.Stderr,
+			logger.Info:    os.Stderr,
+			logger.Warning: os.Stderr,
+		}),
+	)
+}
+
+func usage(flags *flag.FlagSet) {
+	out := flags.Output()
+	fmt.Fprintf(out, "Usage: %s [-config <path>] <command> [arguments]\n\nCommands:\n", flags.Name())
+	for _, name := range commandOrder {
+		fmt.Fprintf(out, "  %-60s %s\n", commands[name].usage, commands[name].description)
+	}
+	fmt.Fprintln(out, "\nFlags:")
+	flags.PrintDefaults()
 }
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/service"
+)
+
+// TestExportImport imports what export wrote to stdout, so anything else written there breaks the dump
+func TestExportImport(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	config := fmt.Sprintf("database:\n  driver: sqlite\n  name: %s\nauth:\n  secret: c2VjcmV0\n", filepath.Join(dir, "wallet.db"))
+	require.NoError(t, os.WriteFile(configPath, []byte(config), 0o600))
+
+	dump, err := os.Create(filepath.Join(dir, "dump.json"))
+	require.NoError(t, err)
+	stdout := os.Stdout
+	os.Stdout = dump
+	t.Cleanup(func() { os.Stdout = stdout })
+
+	ctx := context.Background()
+	a, err := bootstrap(ctx, configPath, newLogger("export"))
+	require.NoError(t, err)
+	t.Cleanup(a.close)
+
+	require.NoError(t, migrateCommand(ctx, a, []string{"up"}))
+	require.NoError(t, seed(ctx, a, []string{"-email", "from@example.com", "-password", "password", "-currency", "KWD"}))
+	s := a.services()
+	_, err = s.auth.Register(ctx, &service.AuthRegisterRequest{Data: &model.User{Name: "to", Email: "to@example.com"}, Password: "password"})
+	require.NoError(t, err)
+
+	// an expense in fils filed under a category archived since
+	fromCtx, _, err := s.actAs(ctx, "from@example.com")
+	require.NoError(t, err)
+	wallets, err := s.wallet.GetAll(fromCtx, &service.WalletGetAllRequest{Filter: &model.WalletFilter{}})
+	require.NoError(t, err)
+	categories, err := s.category.GetAll(fromCtx, &service.CategoryGetAllRequest{Filter: &model.CategoryFilter{Type: model.Expense}})
+	require.NoError(t, err)
+	category := categories.Data[0]
+	_, err = s.transaction.Create(fromCtx, &service.TransactionCreateRequest{Data: &model.Transaction{
+		WalletID: wallets.Data[0].ID, CategoryID: &category.ID, Type: model.Expense, Amount: model.Money{Amount: 12550, Currency: model.Unbound},
+	}})
+	require.NoError(t, err)
+	_, err = s.category.DeleteByID(fromCtx, &service.CategoryDeleteByIDRequest{ID: category.ID, Archive: true})
+	require.NoError(t, err)
+
+	// and a wallet deleted since, which the import deletes as well
+	savings, err := s.wallet.Create(fromCtx, &service.WalletCreateRequest{Data: &model.Wallet{Name: "Savings", Currency: "KZT"}})
+	require.NoError(t, err)
+	deleted, err := s.wallet.DeleteByID(fromCtx, &service.WalletDeleteByIDRequest{ID: savings.Data.ID})
+	require.NoError(t, err)
+
+	exported, err := s.wallet.GetAll(fromCtx, &service.WalletGetAllRequest{Filter: &model.WalletFilter{Deleted: model.DeletedInclude}})
+	require.NoError(t, err)
+
+	require.NoError(t, export(ctx, a, []string{"-email", "from@example.com"}))
+	os.Stdout = stdout
+	require.NoError(t, dump.Close())
+
+	require.NoError(t, importCommand(ctx, a, []string{"-email", "to@example.com", "-file", dump.Name()}))
+
+	toCtx, _, err := s.actAs(ctx, "to@example.com")
+	require.NoError(t, err)
+	transactions, err := s.transaction.GetAll(toCtx, &service.TransactionGetAllRequest{Filter: &model.TransactionFilter{}})
+	require.NoError(t, err)
+	require.Len(t, transactions.Data, 1)
+	require.Equal(t, "1.255 KWD", transactions.Data[0].Amount.String())
+
+	// the history brings the wallets to the exported amounts
+	wallets, err = s.wallet.GetAll(toCtx, &service.WalletGetAllRequest{Filter: &model.WalletFilter{Deleted: model.DeletedInclude}})
+	require.NoError(t, err)
+	require.Len(t, wallets.Data, len(exported.Data))
+	amounts := map[string]model.Money{}
+	for _, wallet := range exported.Data {
+		amounts[wallet.Name] = wallet.Amount
+	}
+	for _, wallet := range wallets.Data {
+		require.Equal(t, amounts[wallet.Name], wallet.Amount, wallet.Name)
+	}
+
+	imported, err := s.category.GetByID(toCtx, &service.CategoryGetByIDRequest{ID: *transactions.Data[0].CategoryID})
+	require.NoError(t, err)
+	require.Equal(t, category.Name, imported.Data.Name)
+	require.NotNil(t, imported.Data.ArchivedAt)
+
+	restored, err := s.wallet.GetAll(toCtx, &service.WalletGetAllRequest{Filter: &model.WalletFilter{Deleted: model.DeletedOnly}})
+	require.NoError(t, err)
+	require.Len(t, restored.Data, 1)
+	require.Equal(t, "Savings", restored.Data[0].Name)
+	require.True(t, deleted.Data.DeletedAt.Equal(*restored.Data[0].DeletedAt))
+}
+
+// TestServeMemory serves the wallets kept in memory alone, the transactions and transfers fail plainly
+func TestServeMemory(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	config := fmt.Sprintf("storage: memory\ndatabase:\n  driver: sqlite\n  name: %s\nauth:\n  secret: c2VjcmV0\n", filepath.Join(dir, "wallet.db"))
+	require.NoError(t, os.WriteFile(configPath, []byte(config), 0o600))
+
+	ctx := context.Background()
+	a, err := bootstrap(ctx, configPath, newLogger("serve"))
+	require.NoError(t, err)
+	t.Cleanup(a.close)
+	require.NoError(t, migrateCommand(ctx, a, []string{"up"}))
+
+	s := a.services()
+	_, err = s.auth.Register(ctx, &service.AuthRegisterRequest{Data: &model.User{Name: "name", Email: "name@example.com"}, Password: "password"})
+	require.NoError(t, err)
+	login, err := s.auth.Login(ctx, &service.AuthLoginRequest{Email: "name@example.com", Password: "password"})
+	require.NoError(t, err)
+
+	e := a.router(s)
+	request := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+login.Data.AccessToken)
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := request(http.MethodPost, "/wallets", `{"name":"cash","currency":"KZT","amount":"100.00"}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	rec = request(http.MethodGet, "/wallets/1", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	for _, path := range []string{"/transactions", "/transfers/1"} {
+		rec = request(http.MethodGet, path, "")
+		require.Equal(t, http.StatusNotImplemented, rec.Code)
+		require.Contains(t, rec.Body.String(), `storage \"database\"`)
+	}
+	rec = request(http.MethodPost, "/transactions", `{"wallet_id":1,"type":"income","amount":"1.00"}`)
+	require.Equal(t, http.StatusNotImplemented, rec.Code)
+
+	require.Error(t, seed(ctx, a, []string{"-email", "other@example.com", "-password", "password"}))
+	require.Error(t, purge(ctx, a, nil))
+}
+
+// TestServeExpiredToken keeps the auth routes open to clients whose access token has expired
+func TestServeExpiredToken(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	config := fmt.Sprintf("database:\n  driver: sqlite\n  name: %s\nauth:\n  secret: c2VjcmV0\n", filepath.Join(dir, "wallet.db"))
+	require.NoError(t, os.WriteFile(configPath, []byte(config), 0o600))
+
+	ctx := context.Background()
+	a, err := bootstrap(ctx, configPath, newLogger("serve"))
+	require.NoError(t, err)
+	t.Cleanup(a.close)
+	require.NoError(t, migrateCommand(ctx, a, []string{"up"}))
+
+	s := a.services()
+	_, err = s.auth.Register(ctx, &service.AuthRegisterRequest{Data: &model.User{Name: "name", Email: "name@example.com"}, Password: "password"})
+	require.NoError(t, err)
+	login, err := s.auth.Login(ctx, &service.AuthLoginRequest{Email: "name@example.com", Password: "password"})
+	require.NoError(t, err)
+
+	e := a.router(s)
+	request := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer expired")
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := request(http.MethodGet, "/wallets", "")
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+	rec = request(http.MethodPost, "/auth/login", `{"email":"name@example.com","password":"password"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	rec = request(http.MethodPost, "/auth/logout", fmt.Sprintf(`{"refresh_token":%q}`, login.Data.RefreshToken))
+	require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+	rec = request(http.MethodPost, "/auth/refresh", fmt.Sprintf(`{"refresh_token":%q}`, login.Data.RefreshToken))
+	require.Equal(t, http.StatusUnauthorized, rec.Code, rec.Body.String())
+}
+
+func TestBootstrapStorage(t *testing.T) {
+	subtests := [...]struct {
+		name    string
+		storage string
+		expect  string
+	}{
+		{"Default", "", storageDatabase},
+		{"Database", "database", storageDatabase},
+		{"Postgres before sqlite", "postgres", storageDatabase},
+		{"Memory", "memory", storageMemory},
+		{"Unknown", "disk", ""},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			dir := t.TempDir()
+			configPath := filepath.Join(dir, "config.yaml")
+			config := fmt.Sprintf("storage: %q\ndatabase:\n  driver: sqlite\n  name: %s\nauth:\n  secret: c2VjcmV0\n", subtest.storage, filepath.Join(dir, "wallet.db"))
+			require.NoError(t, os.WriteFile(configPath, []byte(config), 0o600))
+
+			a, err := bootstrap(context.Background(), configPath, newLogger("migrate"))
+			if subtest.expect == "" {
+				require.ErrorContains(t, err, `unknown storage "disk"`)
+				return
+			}
+			require.NoError(t, err)
+			t.Cleanup(a.close)
+			require.Equal(t, subtest.expect, a.cfg.Storage)
+		})
+	}
+}
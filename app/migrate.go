@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mustan989/wallet/pkg/migrate"
+)
+
+// migrateCommand runs migrate up|down|to <version>|baseline <version>|status
+func migrateCommand(ctx context.Context, a *app, args []string) error {
+	if len(args) == 0 {
+		return errors.New("command is required: up, down, to <version>, baseline <version> or status")
+	}
+
+	return a.migrate(ctx, func(migrator *migrate.Migrator) error {
+		switch args[0] {
+		case "up":
+			return migrator.Up(ctx)
+		case "down":
+			return migrator.Down(ctx)
+		case "to", "baseline":
+			if len(args) < 2 {
+				return errors.New("version is required")
+			}
+			version, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("parse version: %w", err)
+			}
+			if args[0] == "baseline" {
+				// the schema was migrated by hand up to the version
+				return migrator.Baseline(ctx, version)
+			}
+			return migrator.To(ctx, version)
+		case "status":
+			statuses, err := migrator.Status(ctx)
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+			for _, status := range statuses {
+				appliedAt := "pending"
+				if status.AppliedAt != nil {
+					appliedAt = status.AppliedAt.Format(time.RFC3339)
+				}
+				if status.Missing {
+					status.Name = "(missing)"
+				}
+				fmt.Fprintf(w, "%d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+			}
+			return w.Flush()
+		default:
+			return fmt.Errorf("unknown command %q", args[0])
+		}
+	})
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"time"
+
+	"github.com/mustan989/wallet/pkg/logger"
+	"github.com/mustan989/wallet/service"
+)
+
+// defaultPurgeInterval is used when the purge retention is configured without an interval
+const defaultPurgeInterval = time.Hour
+
+// purge permanently deletes the wallets that stayed deleted longer than the retention, the configured one by default
+func purge(ctx context.Context, a *app, args []string) error {
+	flags := flag.NewFlagSet("purge", flag.ContinueOnError)
+	retention := flags.Duration("retention", a.cfg.Purge.Retention, "how long deleted wallets are kept")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := a.requireDatabase(); err != nil {
+		return err
+	}
+
+	response, err := a.services().wallet.Purge(ctx, &service.WalletPurgeRequest{Retention: *retention})
+	if err != nil {
+		return err
+	}
+
+	a.log.Infof("Purged %d deleted wallets", response.Count)
+	return nil
+}
+
+// purgeLoop purges deleted wallets right away and then every interval until the context is done,
+// errors are logged by the service and the next run tries again
+func purgeLoop(ctx context.Context, log logger.Logger, wallets service.Wallet, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if response, err := wallets.Purge(ctx, &service.WalletPurgeRequest{Retention: retention}); err == nil && response.Count != 0 {
+			log.Infof("Purged %d deleted wallets", response.Count)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
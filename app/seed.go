@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/service"
+)
+
+// defaultCategories are created for seeded users
+var defaultCategories = []struct {
+	name     string
+	kind     model.TransactionType
+	children []string
+}{
+	{"Salary", model.Income, nil},
+	{"Gifts", model.Income, nil},
+	{"Food", model.Expense, []string{"Groceries", "Restaurants"}},
+	{"Transport", model.Expense, nil},
+	{"Housing", model.Expense, []string{"Rent", "Utilities"}},
+	{"Health", model.Expense, nil},
+	{"Entertainment", model.Expense, nil},
+}
+
+// seed registers a user with the default categories and a cash wallet
+func seed(ctx context.Context, a *app, args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	email := flags.String("email", "", "email of the user")
+	password := flags.String("password", "", "password of the user")
+	name := flags.String("name", "", "name of the user, the email by default")
+	currency := flags.String("currency", "USD", "currency of the cash wallet")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *name == "" {
+		*name = *email
+	}
+	if err := a.requireDatabase(); err != nil {
+		return err
+	}
+
+	s := a.services()
+
+	registered, err := s.auth.Register(ctx, &service.AuthRegisterRequest{
+		Data:     &model.User{Name: *name, Email: *email},
+		Password: *password,
+	})
+	if err != nil {
+		return err
+	}
+	user := registered.Data
+	ctx = model.WithUserID(ctx, user.ID)
+
+	for _, category := range defaultCategories {
+		parent, err := s.category.Create(ctx, &service.CategoryCreateRequest{
+			Data: &model.Category{Name: category.name, Type: category.kind},
+		})
+		if err != nil {
+			return err
+		}
+		for _, child := range category.children {
+			if _, err = s.category.Create(ctx, &service.CategoryCreateRequest{
+				Data: &model.Category{ParentID: &parent.Data.ID, Name: child, Type: category.kind},
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	if _, err = s.wallet.Create(ctx, &service.WalletCreateRequest{
+		Data: &model.Wallet{Name: "Cash", Currency: *currency},
+	}); err != nil {
+		return err
+	}
+
+	a.log.Infof("Seeded user %d %s", user.ID, user.Email)
+	return nil
+}
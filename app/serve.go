@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/mustan989/wallet/app/internal/handler"
+	"github.com/mustan989/wallet/pkg/migrate"
+	"github.com/mustan989/wallet/pkg/shutdown"
+)
+
+func serve(ctx context.Context, a *app, _ []string) error {
+	if err := a.migrate(ctx, func(migrator *migrate.Migrator) error { return migrator.Check(ctx) }); err != nil {
+		return fmt.Errorf("check database schema: %w, run migrate up", err)
+	}
+
+	s := a.services()
+	e := a.router(s)
+
+	operations := map[string]shutdown.Operation{
+		"Server": func(ctx context.Context) error {
+			return e.Shutdown(ctx)
+		},
+	}
+
+	if retention := a.cfg.Purge.Retention; retention > 0 {
+		a.log.Infof("Purging wallets deleted longer than %s ago every %s", retention, a.cfg.Purge.Interval)
+
+		purgeCtx, stop := context.WithCancel(ctx)
+		go purgeLoop(purgeCtx, a.log, s.wallet, retention, a.cfg.Purge.Interval)
+		operations["Purge"] = func(context.Context) error {
+			stop()
+			return nil
+		}
+	}
+
+	a.log.Infof("Starting server on port :%d", a.cfg.Server.Port)
+
+	go func() {
+		<-shutdown.GracefulShutdown(ctx, operations, shutdown.WithLogger(a.log), shutdown.WithTimeout(1*time.Second))
+	}()
+
+	if err := e.Start(fmt.Sprint(":", a.cfg.Server.Port)); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("run server: %w", err)
+	}
+	return nil
+}
+
+func (a *app) router(s *services) *echo.Echo {
+	e := echo.New()
+	e.HideBanner = true
+	e.HidePort = true
+	e.HTTPErrorHandler = handler.ErrorHandler(a.log)
+
+	// the auth routes stay open, so expired access tokens do not stand in the way of refresh and logout
+	authHandler := handler.NewAuth(s.auth)
+	authenticate := authHandler.Authenticate
+
+	wallets := e.Group("/wallets", authenticate)
+	handler.NewWallet(s.wallet).Register(wallets)
+	handler.NewMember(s.member).Register(wallets)
+	if a.cfg.Storage == storageMemory {
+		// the balances they move are kept with the wallets, which the database does not have
+		unsupported := func(echo.Context) error {
+			return echo.NewHTTPError(http.StatusNotImplemented, errMemoryStorage.Error())
+		}
+		for _, path := range []string{"/transactions", "/transfers"} {
+			e.Any(path, unsupported)
+			e.Any(path+"/*", unsupported)
+		}
+	} else {
+		handler.NewTransaction(s.transaction).Register(e.Group("/transactions", authenticate))
+		handler.NewTransfer(s.transfer).Register(e.Group("/transfers", authenticate))
+	}
+	handler.NewCategory(s.category).Register(e.Group("/categories", authenticate))
+	handler.NewUser(s.user).Register(e.Group("/users", authenticate))
+	handler.NewCurrency(s.currency).Register(e.Group("/currencies"))
+	authHandler.Register(e.Group("/auth"))
+
+	return e
+}
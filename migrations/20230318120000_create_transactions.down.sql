@@ -0,0 +1 @@
+drop table transactions;
\ No newline at end of file
@@ -0,0 +1,13 @@
+create table transactions
+(
+    id          bigserial primary key,
+    wallet_id   bigint         not null references wallets (id) on delete cascade,
+    "type"      varchar(10)    not null check ("type" in ('income', 'expense')),
+    amount      decimal(19, 2) not null check (amount > 0),
+    description varchar(300),
+    "date"      timestamptz    not null default now(),
+    created_at  timestamptz    not null default now(),
+    updated_at  timestamptz    not null default now()
+);
+
+create index transactions_wallet_id_idx on transactions (wallet_id);
@@ -0,0 +1,2 @@
+alter table transactions drop column transfer_id;
+drop table transfers;
\ No newline at end of file
@@ -0,0 +1,18 @@
+create table transfers
+(
+    id             bigserial primary key,
+    from_wallet_id bigint         not null references wallets (id) on delete cascade,
+    to_wallet_id   bigint         not null references wallets (id) on delete cascade,
+    amount         decimal(19, 2) not null check (amount > 0),
+    description    varchar(300),
+    "date"         timestamptz    not null default now(),
+    created_at     timestamptz    not null default now(),
+    updated_at     timestamptz    not null default now(),
+    check (from_wallet_id <> to_wallet_id)
+);
+
+create index transfers_from_wallet_id_idx on transfers (from_wallet_id);
+create index transfers_to_wallet_id_idx on transfers (to_wallet_id);
+
+alter table transactions
+    add column transfer_id bigint unique references transfers (id) on delete cascade;
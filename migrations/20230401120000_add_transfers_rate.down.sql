@@ -0,0 +1,3 @@
+alter table transfers
+    drop column received_amount,
+    drop column rate;
\ No newline at end of file
@@ -0,0 +1,10 @@
+alter table transfers
+    add column received_amount decimal(19, 2),
+    add column rate            decimal(19, 8) not null default 1 check (rate > 0);
+
+update transfers
+set received_amount = amount;
+
+alter table transfers
+    alter column received_amount set not null,
+    add check (received_amount > 0);
@@ -0,0 +1,2 @@
+alter table transactions drop column category_id;
+drop table categories;
\ No newline at end of file
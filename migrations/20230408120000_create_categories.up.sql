@@ -0,0 +1,18 @@
+create table categories
+(
+    id          bigserial primary key,
+    parent_id   bigint references categories (id),
+    "name"      varchar(50) not null,
+    "type"      varchar(10) not null check ("type" in ('income', 'expense')),
+    created_at  timestamptz not null default now(),
+    updated_at  timestamptz not null default now(),
+    archived_at timestamptz,
+    check (parent_id <> id)
+);
+
+create unique index categories_parent_id_type_name_idx on categories (coalesce(parent_id, 0), "type", lower("name"));
+
+alter table transactions
+    add column category_id bigint references categories (id) on delete restrict;
+
+create index transactions_category_id_idx on transactions (category_id);
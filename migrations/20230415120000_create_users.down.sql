@@ -0,0 +1,7 @@
+drop index categories_owner_id_parent_id_type_name_idx;
+
+create unique index categories_parent_id_type_name_idx on categories (coalesce(parent_id, 0), "type", lower("name"));
+
+alter table categories drop column owner_id;
+alter table wallets drop column owner_id;
+drop table users;
\ No newline at end of file
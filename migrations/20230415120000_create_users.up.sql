@@ -0,0 +1,39 @@
+create table users
+(
+    id         bigserial primary key,
+    "name"     varchar(50)  not null,
+    email      varchar(254) not null,
+    created_at timestamptz  not null default now(),
+    updated_at timestamptz  not null default now()
+);
+
+create unique index users_email_idx on users (lower(email));
+
+-- data created before users existed is given to the first user
+insert into users ("name", email)
+select 'owner', 'owner@localhost'
+where exists(select from wallets) or exists(select from categories);
+
+alter table wallets
+    add column owner_id bigint references users (id) on delete cascade;
+
+update wallets
+set owner_id = (select min(id) from users);
+
+alter table wallets
+    alter column owner_id set not null;
+
+create index wallets_owner_id_idx on wallets (owner_id);
+
+alter table categories
+    add column owner_id bigint references users (id) on delete cascade;
+
+update categories
+set owner_id = (select min(id) from users);
+
+alter table categories
+    alter column owner_id set not null;
+
+drop index categories_parent_id_type_name_idx;
+
+create unique index categories_owner_id_parent_id_type_name_idx on categories (owner_id, coalesce(parent_id, 0), "type", lower("name"));
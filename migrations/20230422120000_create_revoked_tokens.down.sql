@@ -0,0 +1,2 @@
+drop table revoked_tokens;
+alter table users drop column password_hash;
\ No newline at end of file
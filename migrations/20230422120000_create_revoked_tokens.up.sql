@@ -0,0 +1,11 @@
+alter table users
+    add column password_hash bytea;
+
+create table revoked_tokens
+(
+    id         varchar(64) primary key,
+    expires_at timestamptz not null,
+    revoked_at timestamptz not null default now()
+);
+
+create index revoked_tokens_expires_at_idx on revoked_tokens (expires_at);
@@ -0,0 +1 @@
+drop table wallet_members;
\ No newline at end of file
@@ -0,0 +1,11 @@
+create table wallet_members
+(
+    wallet_id  bigint      not null references wallets (id) on delete cascade,
+    user_id    bigint      not null references users (id) on delete cascade,
+    "role"     varchar(10) not null check ("role" in ('viewer', 'editor', 'owner')),
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now(),
+    primary key (wallet_id, user_id)
+);
+
+create index wallet_members_user_id_idx on wallet_members (user_id);
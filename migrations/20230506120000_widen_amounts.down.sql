@@ -0,0 +1,7 @@
+alter table transfers
+    alter column received_amount type decimal(19, 2),
+    alter column amount type decimal(19, 2);
+alter table transactions
+    alter column amount type decimal(19, 2);
+alter table wallets
+    alter column amount type decimal(19, 2);
\ No newline at end of file
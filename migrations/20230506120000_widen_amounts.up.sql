@@ -0,0 +1,8 @@
+-- ISO 4217 currencies have up to 4 minor digits, e.g. KWD has 3 and CLF has 4
+alter table wallets
+    alter column amount type numeric(21, 4);
+alter table transactions
+    alter column amount type numeric(21, 4);
+alter table transfers
+    alter column amount type numeric(21, 4),
+    alter column received_amount type numeric(21, 4);
@@ -0,0 +1,7 @@
+alter table transfers
+    drop constraint transfers_received_amount_range,
+    drop constraint transfers_amount_range;
+alter table transactions
+    drop constraint transactions_amount_range;
+alter table wallets
+    drop constraint wallets_amount_range;
\ No newline at end of file
@@ -0,0 +1,8 @@
+-- amounts must fit model.Decimal, which holds ±92233720368547757.99
+alter table wallets
+    add constraint wallets_amount_range check (amount between -92233720368547757.99 and 92233720368547757.99);
+alter table transactions
+    add constraint transactions_amount_range check (amount <= 92233720368547757.99);
+alter table transfers
+    add constraint transfers_amount_range check (amount <= 92233720368547757.99),
+    add constraint transfers_received_amount_range check (received_amount <= 92233720368547757.99);
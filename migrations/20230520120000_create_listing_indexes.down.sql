@@ -0,0 +1,3 @@
+drop index if exists categories_owner_name_id_idx;
+drop index if exists transfers_date_id_idx;
+drop index if exists transactions_date_id_idx;
\ No newline at end of file
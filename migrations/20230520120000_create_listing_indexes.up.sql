@@ -0,0 +1,4 @@
+-- keyset pages seek by the listing order and then by id
+create index transactions_date_id_idx on transactions (date desc, id desc);
+create index transfers_date_id_idx on transfers (date desc, id desc);
+create index categories_owner_name_id_idx on categories (owner_id, name, id);
@@ -0,0 +1,2 @@
+alter table wallets
+    drop column version;
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- version increments on every write of a wallet, updates are made against the version they read
+alter table wallets
+    add column version bigint not null default 1;
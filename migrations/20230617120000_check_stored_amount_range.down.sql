@@ -0,0 +1,11 @@
+alter table transfers
+    drop constraint transfers_received_amount_range,
+    drop constraint transfers_amount_range,
+    add constraint transfers_amount_range check (amount <= 92233720368547757.99),
+    add constraint transfers_received_amount_range check (received_amount <= 92233720368547757.99);
+alter table transactions
+    drop constraint transactions_amount_range,
+    add constraint transactions_amount_range check (amount <= 92233720368547757.99);
+alter table wallets
+    drop constraint wallets_amount_range,
+    add constraint wallets_amount_range check (amount between -92233720368547757.99 and 92233720368547757.99);
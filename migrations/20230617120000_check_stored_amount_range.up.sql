@@ -0,0 +1,13 @@
+-- amounts of every currency are stored in its minor units and read back as integer ten-thousandths,
+-- model.MaxStored, so they are kept within ±922337203685477.5799. Amounts out of the range fail the migration
+alter table wallets
+    drop constraint wallets_amount_range,
+    add constraint wallets_amount_range check (amount between -922337203685477.5799 and 922337203685477.5799);
+alter table transactions
+    drop constraint transactions_amount_range,
+    add constraint transactions_amount_range check (amount <= 922337203685477.5799);
+alter table transfers
+    drop constraint transfers_amount_range,
+    drop constraint transfers_received_amount_range,
+    add constraint transfers_amount_range check (amount <= 922337203685477.5799),
+    add constraint transfers_received_amount_range check (received_amount <= 922337203685477.5799);
@@ -0,0 +1,20 @@
+package migrations_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mustan989/wallet/migrations"
+	"github.com/mustan989/wallet/pkg/migrate"
+)
+
+func TestFS(t *testing.T) {
+	loaded, err := migrate.Load(migrations.FS)
+	require.NoError(t, err)
+	require.NotEmpty(t, loaded)
+
+	for _, m := range loaded {
+		require.NotEmpty(t, m.Down, "%d_%s has no down file", m.Version, m.Name)
+	}
+}
@@ -0,0 +1,7 @@
+drop table revoked_tokens;
+drop table transactions;
+drop table transfers;
+drop table categories;
+drop table wallet_members;
+drop table wallets;
+drop table users;
\ No newline at end of file
@@ -0,0 +1,109 @@
+-- the schema of the postgres migrations up to 20230527120000_add_wallets_version.
+-- Amounts are integer hundredths of model.Decimal and rates are integer hundred-millionths of model.Rate,
+-- times are utc text with microseconds, e.g. 2023-06-03 12:00:00.000000+00:00, so they sort in time order
+create table users
+(
+    id            integer primary key autoincrement,
+    "name"        varchar(50)  not null,
+    email         varchar(254) not null,
+    password_hash blob,
+    created_at    datetime     not null default (strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')),
+    updated_at    datetime     not null default (strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now'))
+);
+
+create unique index users_email_idx on users (lower(email));
+
+create table wallets
+(
+    id          integer primary key autoincrement,
+    owner_id    integer      not null references users (id) on delete cascade,
+    "name"      varchar(50)  not null,
+    description varchar(300),
+    currency    char(3)      not null,
+    amount      integer      not null default 0,
+    personal    boolean      not null default false,
+    created_at  datetime     not null default (strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')),
+    updated_at  datetime     not null default (strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')),
+    deleted_at  datetime,
+    version     integer      not null default 1,
+    constraint wallets_amount_range check (amount between -9223372036854775799 and 9223372036854775799)
+);
+
+create index wallets_owner_id_idx on wallets (owner_id);
+
+create table wallet_members
+(
+    wallet_id  integer     not null references wallets (id) on delete cascade,
+    user_id    integer     not null references users (id) on delete cascade,
+    "role"     varchar(10) not null check ("role" in ('viewer', 'editor', 'owner')),
+    created_at datetime    not null default (strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')),
+    updated_at datetime    not null default (strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')),
+    primary key (wallet_id, user_id)
+);
+
+create index wallet_members_user_id_idx on wallet_members (user_id);
+
+create table categories
+(
+    id          integer primary key autoincrement,
+    owner_id    integer     not null references users (id) on delete cascade,
+    parent_id   integer references categories (id),
+    "name"      varchar(50) not null,
+    "type"      varchar(10) not null check ("type" in ('income', 'expense')),
+    created_at  datetime    not null default (strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')),
+    updated_at  datetime    not null default (strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')),
+    archived_at datetime,
+    check (parent_id <> id)
+);
+
+create unique index categories_owner_id_parent_id_type_name_idx on categories (owner_id, coalesce(parent_id, 0), "type", lower("name"));
+create index categories_owner_name_id_idx on categories (owner_id, "name", id);
+
+create table transfers
+(
+    id              integer primary key autoincrement,
+    from_wallet_id  integer      not null references wallets (id) on delete cascade,
+    to_wallet_id    integer      not null references wallets (id) on delete cascade,
+    amount          integer      not null check (amount > 0),
+    received_amount integer      not null check (received_amount > 0),
+    rate            integer      not null default 100000000 check (rate > 0),
+    description     varchar(300),
+    "date"          datetime     not null default (strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')),
+    created_at      datetime     not null default (strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')),
+    updated_at      datetime     not null default (strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')),
+    check (from_wallet_id <> to_wallet_id),
+    constraint transfers_amount_range check (amount <= 9223372036854775799),
+    constraint transfers_received_amount_range check (received_amount <= 9223372036854775799)
+);
+
+create index transfers_from_wallet_id_idx on transfers (from_wallet_id);
+create index transfers_to_wallet_id_idx on transfers (to_wallet_id);
+create index transfers_date_id_idx on transfers ("date" desc, id desc);
+
+create table transactions
+(
+    id          integer primary key autoincrement,
+    wallet_id   integer      not null references wallets (id) on delete cascade,
+    category_id integer references categories (id) on delete restrict,
+    "type"      varchar(10)  not null check ("type" in ('income', 'expense')),
+    amount      integer      not null check (amount > 0),
+    description varchar(300),
+    "date"      datetime     not null default (strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')),
+    transfer_id integer unique references transfers (id) on delete cascade,
+    created_at  datetime     not null default (strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')),
+    updated_at  datetime     not null default (strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')),
+    constraint transactions_amount_range check (amount <= 9223372036854775799)
+);
+
+create index transactions_wallet_id_idx on transactions (wallet_id);
+create index transactions_category_id_idx on transactions (category_id);
+create index transactions_date_id_idx on transactions ("date" desc, id desc);
+
+create table revoked_tokens
+(
+    id         varchar(64) primary key,
+    expires_at datetime    not null,
+    revoked_at datetime    not null default (strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now'))
+);
+
+create index revoked_tokens_expires_at_idx on revoked_tokens (expires_at);
@@ -0,0 +1,8 @@
+-- amounts with more than two fraction digits become null, so they fail the migration rather than being rounded
+update transfers
+set amount          = case when amount % 100 = 0 then amount / 100 end,
+    received_amount = case when received_amount % 100 = 0 then received_amount / 100 end;
+update transactions
+set amount = case when amount % 100 = 0 then amount / 100 end;
+update wallets
+set amount = case when amount % 100 = 0 then amount / 100 end;
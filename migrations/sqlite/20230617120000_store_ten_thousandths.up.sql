@@ -0,0 +1,9 @@
+-- amounts of every currency are integer ten-thousandths of model.Unbound rather than hundredths, e.g. 1.234 KWD is 12340,
+-- the range checks keep their bounds, which are model.MaxStored now. Amounts out of the range fail the migration
+update wallets
+set amount = amount * 100;
+update transactions
+set amount = amount * 100;
+update transfers
+set amount          = amount * 100,
+    received_amount = received_amount * 100;
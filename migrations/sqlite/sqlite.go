@@ -0,0 +1,9 @@
+// Package sqlite embeds the sql migrations of the sqlite database into the binary
+package sqlite
+
+import "embed"
+
+// FS holds the <version>_<name>.up.sql and <version>_<name>.down.sql files
+//
+//go:embed *.sql
+var FS embed.FS
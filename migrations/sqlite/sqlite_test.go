@@ -0,0 +1,47 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mustan989/wallet/app/config"
+	"github.com/mustan989/wallet/migrations/sqlite"
+	"github.com/mustan989/wallet/pkg/migrate"
+	connect "github.com/mustan989/wallet/pkg/sqlite"
+)
+
+func TestFS(t *testing.T) {
+	loaded, err := migrate.Load(sqlite.FS)
+	require.NoError(t, err)
+	require.NotEmpty(t, loaded)
+
+	for _, m := range loaded {
+		require.NotEmpty(t, m.Down, "%d_%s has no down file", m.Version, m.Name)
+	}
+}
+
+func TestFS_UpDown(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := connect.Connect(&config.Database{Name: ":memory:"})
+	require.NoError(t, err)
+	defer db.Close()
+
+	loaded, err := migrate.Load(sqlite.FS)
+	require.NoError(t, err)
+	migrator := migrate.NewSQLite(db, loaded)
+
+	require.NoError(t, migrator.Up(ctx))
+	require.NoError(t, migrator.Check(ctx))
+
+	require.NoError(t, migrator.To(ctx, 0))
+	var tables int
+	require.NoError(t, db.QueryRowContext(ctx, `SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name NOT IN ('schema_migrations', 'sqlite_sequence')`).Scan(&tables))
+	assert.Zero(t, tables)
+
+	// the schema can be applied again after it is dropped
+	require.NoError(t, migrator.Up(ctx))
+}
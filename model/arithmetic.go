@@ -0,0 +1,206 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+)
+
+var (
+	ErrDecimalOverflow = errors.New("decimal result is out of range")
+	ErrDivisionByZero  = errors.New("division by zero")
+	ErrInvalidRatios   = errors.New("ratios must not be negative and must not all be zero")
+)
+
+// RoundingMode decides how a result is rounded to Decimal or Rate precision
+type RoundingMode uint8
+
+const (
+	// HalfEven rounds to the nearest, ties to the even neighbour, also known as banker's rounding
+	HalfEven RoundingMode = iota
+	// HalfUp rounds to the nearest, ties away from zero
+	HalfUp
+	// Down rounds towards zero, i.e. truncates
+	Down
+	// Up rounds away from zero
+	Up
+)
+
+// percentScale is 100% as Rate, Rate(150000000) is 1.5% for the percentage helpers
+const percentScale = 100 * rateScale
+
+func (d Decimal) Add(other Decimal) (Decimal, error) {
+	sum := d + other
+	if other > 0 && sum < d || other < 0 && sum > d {
+		return 0, ErrDecimalOverflow
+	}
+	return sum, nil
+}
+
+func (d Decimal) Sub(other Decimal) (Decimal, error) {
+	diff := d - other
+	if other > 0 && diff > d || other < 0 && diff < d {
+		return 0, ErrDecimalOverflow
+	}
+	return diff, nil
+}
+
+func (d Decimal) Neg() (Decimal, error) {
+	if d == math.MinInt64 {
+		return 0, ErrDecimalOverflow
+	}
+	return -d, nil
+}
+
+func (d Decimal) Abs() (Decimal, error) {
+	if d < 0 {
+		return d.Neg()
+	}
+	return d, nil
+}
+
+// Cmp returns -1, 0 or +1 if d is less than, equal to or greater than other
+func (d Decimal) Cmp(other Decimal) int {
+	switch {
+	case d < other:
+		return -1
+	case d > other:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of d
+func (d Decimal) Sign() int { return d.Cmp(0) }
+
+// Mul returns d multiplied by the rate
+func (d Decimal) Mul(r Rate, mode RoundingMode) (Decimal, error) {
+	return decimalOf(big.NewInt(int64(d)), big.NewInt(int64(r)), big.NewInt(rateScale), mode)
+}
+
+// Div returns d divided by the rate
+func (d Decimal) Div(r Rate, mode RoundingMode) (Decimal, error) {
+	if r == 0 {
+		return 0, ErrDivisionByZero
+	}
+	return decimalOf(big.NewInt(int64(d)), big.NewInt(rateScale), big.NewInt(int64(r)), mode)
+}
+
+// Percent returns p percent of d, e.g. a commission or an interest
+func (d Decimal) Percent(p Rate, mode RoundingMode) (Decimal, error) {
+	return decimalOf(big.NewInt(int64(d)), big.NewInt(int64(p)), big.NewInt(percentScale), mode)
+}
+
+// PercentOf returns how many percent part is of whole
+func PercentOf(part, whole Decimal, mode RoundingMode) (Rate, error) {
+	if whole == 0 {
+		return 0, ErrDivisionByZero
+	}
+	n := new(big.Int).Mul(big.NewInt(int64(part)), big.NewInt(percentScale))
+	q := roundQuo(n, big.NewInt(int64(whole)), mode)
+	if !q.IsInt64() {
+		return 0, ErrDecimalOverflow
+	}
+	return Rate(q.Int64()), nil
+}
+
+// Split divides d into n parts which differ by a cent at most and add up to d, earlier parts get the extra cents
+func (d Decimal) Split(n int) ([]Decimal, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("%w: cannot split into %d parts", ErrInvalidRatios, n)
+	}
+	ratios := make([]int64, n)
+	for i := range ratios {
+		ratios[i] = 1
+	}
+	return d.Allocate(ratios...)
+}
+
+// Allocate divides d in proportion to the ratios, the parts always add up to d.
+// The cents left after rounding the parts towards zero go to the parts with the largest remainders,
+// the earlier part wins a tie, e.g. 100.00 allocated 1:1:1 is 33.34, 33.33 and 33.33
+func (d Decimal) Allocate(ratios ...int64) ([]Decimal, error) {
+	amounts, err := allocate(int64(d), ratios)
+	if err != nil {
+		return nil, err
+	}
+	parts := make([]Decimal, len(amounts))
+	for i, amount := range amounts {
+		parts[i] = Decimal(amount)
+	}
+	return parts, nil
+}
+
+// allocate implements the largest remainder method over integer minor units
+func allocate(amount int64, ratios []int64) ([]int64, error) {
+	total := new(big.Int)
+	for _, ratio := range ratios {
+		if ratio < 0 {
+			return nil, fmt.Errorf("%w: %d", ErrInvalidRatios, ratio)
+		}
+		total.Add(total, big.NewInt(ratio))
+	}
+	if total.Sign() == 0 {
+		return nil, ErrInvalidRatios
+	}
+
+	parts := make([]int64, len(ratios))
+	remainders := make([]*big.Int, len(ratios))
+	left := amount
+	for i, ratio := range ratios {
+		q, r := new(big.Int).QuoRem(new(big.Int).Mul(big.NewInt(amount), big.NewInt(ratio)), total, new(big.Int))
+		// |q| <= |amount| as ratio <= total, so it fits int64
+		parts[i], remainders[i] = q.Int64(), r.Abs(r)
+		left -= parts[i]
+	}
+
+	order := make([]int, len(ratios))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return remainders[order[i]].Cmp(remainders[order[j]]) > 0 })
+
+	step := int64(1)
+	if left < 0 {
+		step = -1
+	}
+	for i := 0; left != 0; i++ {
+		parts[order[i]] += step
+		left -= step
+	}
+	return parts, nil
+}
+
+// decimalOf returns x*y/z rounded with the mode
+func decimalOf(x, y, z *big.Int, mode RoundingMode) (Decimal, error) {
+	q := roundQuo(new(big.Int).Mul(x, y), z, mode)
+	if !q.IsInt64() {
+		return 0, ErrDecimalOverflow
+	}
+	return Decimal(q.Int64()), nil
+}
+
+// roundQuo divides n by d rounding the quotient with the mode
+func roundQuo(n, d *big.Int, mode RoundingMode) *big.Int {
+	q, m := new(big.Int).QuoRem(n, d, new(big.Int))
+	if m.Sign() == 0 {
+		return q
+	}
+
+	var away bool
+	switch mode {
+	case Up:
+		away = true
+	case HalfUp, HalfEven:
+		half := new(big.Int).Mul(new(big.Int).Abs(m), big.NewInt(2)).Cmp(new(big.Int).Abs(d))
+		away = half > 0 || half == 0 && (mode == HalfUp || q.Bit(0) == 1)
+	}
+
+	if away {
+		q.Add(q, big.NewInt(int64(n.Sign()*d.Sign())))
+	}
+	return q
+}
@@ -0,0 +1,201 @@
+package model_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mustan989/wallet/model"
+)
+
+func TestDecimal_Add(t *testing.T) {
+	subtests := [...]struct {
+		name   string
+		a, b   model.Decimal
+		expect model.Decimal
+		err    error
+	}{
+		{"Positive", 150, 275, 425, nil},
+		{"Negative", -150, 100, -50, nil},
+		{"Max value", math.MaxInt64 - 1, 1, math.MaxInt64, nil},
+		{"Overflow", math.MaxInt64, 1, 0, model.ErrDecimalOverflow},
+		{"Underflow", math.MinInt64, -1, 0, model.ErrDecimalOverflow},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			sum, err := subtest.a.Add(subtest.b)
+			require.Equal(t, subtest.err, err)
+			require.Equal(t, subtest.expect, sum)
+		})
+	}
+}
+
+func TestDecimal_Sub(t *testing.T) {
+	subtests := [...]struct {
+		name   string
+		a, b   model.Decimal
+		expect model.Decimal
+		err    error
+	}{
+		{"Positive", 425, 275, 150, nil},
+		{"Negative result", 100, 150, -50, nil},
+		{"Overflow", math.MaxInt64, -1, 0, model.ErrDecimalOverflow},
+		{"Underflow", math.MinInt64, 1, 0, model.ErrDecimalOverflow},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			diff, err := subtest.a.Sub(subtest.b)
+			require.Equal(t, subtest.err, err)
+			require.Equal(t, subtest.expect, diff)
+		})
+	}
+}
+
+func TestDecimal_NegAbs(t *testing.T) {
+	neg, err := model.Decimal(150).Neg()
+	require.NoError(t, err)
+	require.Equal(t, model.Decimal(-150), neg)
+
+	abs, err := neg.Abs()
+	require.NoError(t, err)
+	require.Equal(t, model.Decimal(150), abs)
+
+	_, err = model.Decimal(math.MinInt64).Neg()
+	require.Equal(t, model.ErrDecimalOverflow, err)
+	_, err = model.Decimal(math.MinInt64).Abs()
+	require.Equal(t, model.ErrDecimalOverflow, err)
+}
+
+func TestDecimal_Cmp(t *testing.T) {
+	require.Equal(t, -1, model.Decimal(1).Cmp(2))
+	require.Equal(t, 0, model.Decimal(2).Cmp(2))
+	require.Equal(t, 1, model.Decimal(3).Cmp(2))
+	require.Equal(t, -1, model.Decimal(-5).Sign())
+	require.Equal(t, 0, model.Decimal(0).Sign())
+}
+
+func TestDecimal_Mul(t *testing.T) {
+	// 0.25 * 0.5 = 0.125 and 0.35 * 0.5 = 0.175 are ties between cents
+	subtests := [...]struct {
+		name   string
+		input  model.Decimal
+		rate   model.Rate
+		mode   model.RoundingMode
+		expect model.Decimal
+	}{
+		{"Exact", 1000, 150000000, model.HalfEven, 1500},
+		{"Half even down", 25, 50000000, model.HalfEven, 12},
+		{"Half even up", 35, 50000000, model.HalfEven, 18},
+		{"Half up", 25, 50000000, model.HalfUp, 13},
+		{"Negative half up", -25, 50000000, model.HalfUp, -13},
+		{"Down", 199, 50000000, model.Down, 99},
+		{"Negative down", -199, 50000000, model.Down, -99},
+		{"Up", 101, 50000000, model.Up, 51},
+		{"Negative up", -101, 50000000, model.Up, -51},
+		{"Above half", 27, 50000000, model.HalfEven, 14},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			product, err := subtest.input.Mul(subtest.rate, subtest.mode)
+			require.NoError(t, err)
+			require.Equal(t, subtest.expect, product)
+		})
+	}
+
+	_, err := model.Decimal(math.MaxInt64).Mul(2*model.OneRate, model.HalfEven)
+	require.Equal(t, model.ErrDecimalOverflow, err)
+}
+
+func TestDecimal_Div(t *testing.T) {
+	quotient, err := model.Decimal(1000).Div(3*model.OneRate, model.HalfEven)
+	require.NoError(t, err)
+	require.Equal(t, model.Decimal(333), quotient)
+
+	quotient, err = model.Decimal(1000).Div(3*model.OneRate, model.Up)
+	require.NoError(t, err)
+	require.Equal(t, model.Decimal(334), quotient)
+
+	_, err = model.Decimal(1000).Div(0, model.HalfEven)
+	require.Equal(t, model.ErrDivisionByZero, err)
+
+	_, err = model.Decimal(math.MaxInt64).Div(model.OneRate/2, model.HalfEven)
+	require.Equal(t, model.ErrDecimalOverflow, err)
+}
+
+func TestDecimal_Percent(t *testing.T) {
+	// 1.5% of 123.45 is 1.85175
+	commission, err := model.Decimal(12345).Percent(150000000, model.HalfUp)
+	require.NoError(t, err)
+	require.Equal(t, model.Decimal(185), commission)
+
+	commission, err = model.Decimal(12345).Percent(150000000, model.Up)
+	require.NoError(t, err)
+	require.Equal(t, model.Decimal(186), commission)
+}
+
+func TestPercentOf(t *testing.T) {
+	percent, err := model.PercentOf(185, 12345, model.HalfEven)
+	require.NoError(t, err)
+	require.Equal(t, model.Rate(149858242), percent)
+
+	_, err = model.PercentOf(185, 0, model.HalfEven)
+	require.Equal(t, model.ErrDivisionByZero, err)
+}
+
+func TestDecimal_Split(t *testing.T) {
+	subtests := [...]struct {
+		name   string
+		input  model.Decimal
+		n      int
+		expect []model.Decimal
+	}{
+		{"Three ways", 10000, 3, []model.Decimal{3334, 3333, 3333}},
+		{"Even", 10000, 4, []model.Decimal{2500, 2500, 2500, 2500}},
+		{"Fewer cents than parts", 2, 3, []model.Decimal{1, 1, 0}},
+		{"Negative", -10000, 3, []model.Decimal{-3334, -3333, -3333}},
+		{"One part", 10000, 1, []model.Decimal{10000}},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			parts, err := subtest.input.Split(subtest.n)
+			require.NoError(t, err)
+			require.Equal(t, subtest.expect, parts)
+		})
+	}
+
+	_, err := model.Decimal(100).Split(0)
+	require.ErrorIs(t, err, model.ErrInvalidRatios)
+}
+
+func TestDecimal_Allocate(t *testing.T) {
+	subtests := [...]struct {
+		name   string
+		input  model.Decimal
+		ratios []int64
+		expect []model.Decimal
+	}{
+		{"Largest remainder", 10000, []int64{1, 2, 2}, []model.Decimal{2000, 4000, 4000}},
+		{"Remainder to largest fraction", 100, []int64{70, 20, 10}, []model.Decimal{70, 20, 10}},
+		{"Uneven", 1, []int64{30, 70}, []model.Decimal{0, 1}},
+		{"Zero ratio", 10000, []int64{1, 0, 2}, []model.Decimal{3333, 0, 6667}},
+		{"Max value", math.MaxInt64, []int64{1, 1}, []model.Decimal{4611686018427387904, 4611686018427387903}},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			parts, err := subtest.input.Allocate(subtest.ratios...)
+			require.NoError(t, err)
+			require.Equal(t, subtest.expect, parts)
+		})
+	}
+
+	_, err := model.Decimal(100).Allocate(1, -1)
+	require.ErrorIs(t, err, model.ErrInvalidRatios)
+	_, err = model.Decimal(100).Allocate(0, 0)
+	require.ErrorIs(t, err, model.ErrInvalidRatios)
+}
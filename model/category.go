@@ -0,0 +1,38 @@
+package model
+
+import "time"
+
+// Category groups transactions of one type, categories can be nested with ParentID
+type Category struct {
+	ID         uint64          `json:"id"`
+	OwnerID    uint64          `json:"owner_id"`
+	ParentID   *uint64         `json:"parent_id"`
+	Name       string          `json:"name"`
+	Type       TransactionType `json:"type"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+	ArchivedAt *time.Time      `json:"archived_at"`
+}
+
+type CategoryFilter struct {
+	Filter
+	// ParentID filters children of the category, 0 filters root categories
+	ParentID *uint64         `query:"parent_id"`
+	NameLike string          `query:"name_like"`
+	Type     TransactionType `query:"type"`
+	Archived *bool           `query:"archived"`
+}
+
+// categoriesSort is the only order categories are listed in
+const categoriesSort = "name asc"
+
+// Next returns the cursor of the page following the last category
+func (f *CategoryFilter) Next(last *Category) *Cursor {
+	return NewCursor(categoriesSort, last.Name, last.ID)
+}
+
+// After returns the name of the filter cursor
+func (f *CategoryFilter) After() (name string, err error) {
+	err = f.Cursor.Decode(categoriesSort, &name)
+	return
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/mustan989/wallet/pkg/currency"
+)
+
+var ErrInvalidCurrency = errors.New("invalid currency")
+
+// MaxExponent is the largest number of minor digits of a currency, Money holds amounts of any of them
+const MaxExponent = currency.MaxMinorUnits
+
+// Currency is an ISO 4217 currency with the number of digits of its minor unit,
+// e.g. a cent is 1/100 of USD so its Exponent is 2
+type Currency struct {
+	Code     string
+	Exponent uint8
+}
+
+// CurrencyOf returns the currency of the code registered in currency.Default()
+func CurrencyOf(code string) (Currency, error) {
+	c, err := currency.Default().Lookup(code)
+	if err != nil {
+		return Currency{}, fmt.Errorf("%w: %s", ErrInvalidCurrency, err)
+	}
+	return Currency{Code: c.Code, Exponent: c.MinorUnits}, nil
+}
+
+func (c Currency) String() string {
+	if c.Code == "" {
+		return "any currency"
+	}
+	return c.Code
+}
+
+func pow10(n uint8) int64 {
+	p := int64(1)
+	for i := uint8(0); i < n; i++ {
+		p *= 10
+	}
+	return p
+}
+
+// Round rounds the two digit decimal to the minor unit of the currency
+func (c Currency) Round(d Decimal, mode RoundingMode) (Decimal, error) {
+	if c.Exponent >= 2 {
+		return d, nil
+	}
+	factor := big.NewInt(pow10(2 - c.Exponent))
+	rounded := roundQuo(big.NewInt(int64(d)), factor, mode)
+	return decimalOf(rounded, factor, big.NewInt(1), mode)
+}
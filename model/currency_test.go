@@ -0,0 +1,50 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mustan989/wallet/model"
+)
+
+func TestCurrencyOf(t *testing.T) {
+	subtests := [...]struct {
+		name   string
+		code   string
+		expect model.Currency
+		err    error
+	}{
+		{"Two digits", "KZT", model.Currency{Code: "KZT", Exponent: 2}, nil},
+		{"No minor unit", "JPY", model.Currency{Code: "JPY", Exponent: 0}, nil},
+		{"Three digits", "KWD", model.Currency{Code: "KWD", Exponent: 3}, nil},
+		{"Four digits", "CLF", model.Currency{Code: "CLF", Exponent: 4}, nil},
+		{"Lower case", "usd", model.Currency{}, model.ErrInvalidCurrency},
+		{"Too long", "USDT", model.Currency{}, model.ErrInvalidCurrency},
+		{"Not registered", "ABC", model.Currency{}, model.ErrInvalidCurrency},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			currency, err := model.CurrencyOf(subtest.code)
+			require.ErrorIs(t, err, subtest.err)
+			require.Equal(t, subtest.expect, currency)
+		})
+	}
+}
+
+func TestCurrency_Round(t *testing.T) {
+	yen := model.Currency{Code: "JPY"}
+
+	rounded, err := yen.Round(14987654, model.HalfUp)
+	require.NoError(t, err)
+	require.Equal(t, model.Decimal(14987700), rounded)
+
+	rounded, err = yen.Round(-14987649, model.HalfUp)
+	require.NoError(t, err)
+	require.Equal(t, model.Decimal(-14987600), rounded)
+
+	rounded, err = model.Currency{Code: "KWD", Exponent: 3}.Round(14987654, model.HalfUp)
+	require.NoError(t, err)
+	require.Equal(t, model.Decimal(14987654), rounded)
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor is the position right after a record in a sorted listing, clients pass it back as an opaque string
+type Cursor struct {
+	// Sort is the ordering the cursor was made for, e.g. "amount desc"
+	Sort string `json:"s"`
+	// Key is the sort key of the record in json, it is empty when the records are sorted by id only
+	Key json.RawMessage `json:"k,omitempty"`
+	ID  uint64          `json:"i"`
+}
+
+// NewCursor returns the cursor following the record with the id and the sort key, key is nil for id sorts
+func NewCursor(sort string, key any, id uint64) *Cursor {
+	c := &Cursor{Sort: sort, ID: id}
+	if key != nil {
+		// keys are strings, decimals and times which always marshal
+		c.Key, _ = json.Marshal(key)
+	}
+	return c
+}
+
+// Decode checks the cursor was made for the sort and unmarshals its key into key
+func (c *Cursor) Decode(sort string, key any) error {
+	if c.Sort != sort {
+		return ErrInvalidCursor
+	}
+	if key == nil {
+		return nil
+	}
+	if len(c.Key) == 0 || json.Unmarshal(c.Key, key) != nil {
+		return ErrInvalidCursor
+	}
+	return nil
+}
+
+func (c Cursor) MarshalText() ([]byte, error) {
+	data, err := json.Marshal(struct {
+		Sort string          `json:"s"`
+		Key  json.RawMessage `json:"k,omitempty"`
+		ID   uint64          `json:"i"`
+	}(c))
+	if err != nil {
+		return nil, err
+	}
+	return []byte(base64.RawURLEncoding.EncodeToString(data)), nil
+}
+
+func (c *Cursor) UnmarshalText(text []byte) error {
+	data, err := base64.RawURLEncoding.DecodeString(string(text))
+	if err != nil {
+		return ErrInvalidCursor
+	}
+
+	var parsed struct {
+		Sort string          `json:"s"`
+		Key  json.RawMessage `json:"k,omitempty"`
+		ID   uint64          `json:"i"`
+	}
+	if err = json.Unmarshal(data, &parsed); err != nil || parsed.Sort == "" || parsed.ID == 0 {
+		return ErrInvalidCursor
+	}
+	*c = Cursor(parsed)
+	return nil
+}
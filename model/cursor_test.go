@@ -0,0 +1,81 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mustan989/wallet/model"
+)
+
+func decimalp(d model.Decimal) *model.Decimal { return &d }
+func moneyp(m model.Money) *model.Money       { return &m }
+
+func TestCursor_Text(t *testing.T) {
+	date := time.Date(1999, 2, 23, 4, 36, 0, 123000, time.UTC)
+
+	subtests := [...]struct {
+		name   string
+		cursor *model.Cursor
+	}{
+		{"ID", model.NewCursor("id desc", nil, 7)},
+		{"String", model.NewCursor("name asc", "name 1", 7)},
+		{"Decimal", model.NewCursor("amount desc", model.Decimal(-9999), 7)},
+		{"Time", model.NewCursor("date desc", date, 7)},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			text, err := subtest.cursor.MarshalText()
+			require.NoError(t, err)
+
+			parsed := &model.Cursor{}
+			require.NoError(t, parsed.UnmarshalText(text))
+			require.Equal(t, subtest.cursor, parsed)
+		})
+	}
+}
+
+func TestCursor_UnmarshalTextError(t *testing.T) {
+	for _, text := range []string{"", "not base64!", "bm90IGpzb24", "e30"} {
+		require.ErrorIs(t, (&model.Cursor{}).UnmarshalText([]byte(text)), model.ErrInvalidCursor, text)
+	}
+}
+
+func TestWalletFilter_Cursor(t *testing.T) {
+	date := time.Date(1999, 2, 23, 4, 36, 0, 0, time.UTC)
+	last := &model.Wallet{ID: 3, Name: "name", Currency: "KZT", Amount: model.Money{Amount: 9999, Currency: usd}, CreatedAt: date, UpdatedAt: date}
+
+	subtests := [...]struct {
+		name   string
+		filter model.WalletFilter
+		key    any
+	}{
+		{"Default", model.WalletFilter{}, nil},
+		{"ID ascending", model.WalletFilter{Sort: model.WalletSortID, Order: model.Asc}, nil},
+		{"Name", model.WalletFilter{Sort: model.WalletSortName}, stringp("name")},
+		{"Amount descending", model.WalletFilter{Sort: model.WalletSortAmount, Order: model.Desc}, moneyp(model.Money{Amount: 999900, Currency: model.Unbound})},
+		{"Created", model.WalletFilter{Sort: model.WalletSortCreatedAt}, timep(date)},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			filter := subtest.filter
+			filter.Cursor = filter.Next(last)
+			require.Equal(t, uint64(3), filter.Cursor.ID)
+
+			key, err := filter.After()
+			require.NoError(t, err)
+			require.Equal(t, subtest.key, key)
+
+			// a cursor is bound to the order it was made for
+			filter.Order = model.Desc
+			if subtest.filter.Order == model.Desc || subtest.filter.Sort == "" {
+				filter.Order = model.Asc
+			}
+			_, err = filter.After()
+			require.ErrorIs(t, err, model.ErrInvalidCursor)
+		})
+	}
+}
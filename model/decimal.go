@@ -1,10 +1,7 @@
 package model
 
 import (
-	"errors"
 	"fmt"
-	"strconv"
-	"strings"
 )
 
 type Decimal int64
@@ -25,42 +22,24 @@ func (d Decimal) String() string {
 
 func (d Decimal) MarshalText() ([]byte, error) { return []byte(d.String()), nil }
 
+// UnmarshalText parses a plain decimal number with an optional sign, e.g. "-12.5" or "+12.50"
 func (d *Decimal) UnmarshalText(data []byte) error {
-	parts := strings.Split(string(data), ".")
-
-	if len(parts) > 2 {
-		return errors.New("number must be delimited by one point only")
-	}
-
-	var frac int64
-
-	exp, err := strconv.ParseInt(parts[0], 10, 64)
+	parsed, err := ParseDecimal(string(data), AllowPlus)
 	if err != nil {
-		return fmt.Errorf("exponent: %w", err)
-	}
-
-	if len(parts) == 2 {
-		frac, err = strconv.ParseInt(parts[1], 10, 64)
-		if err != nil {
-			return fmt.Errorf("fraction: %w", err)
-		}
-		if frac < 0 || 99 < frac {
-			return fmt.Errorf("fraction must be value between 0 and 99")
-		}
+		return err
 	}
+	*d = parsed
+	return nil
+}
 
-	var int64max int64 = 2 << 62 / 100
-	if exp > int64max-1 || exp < -(int64max)+1 {
-		return fmt.Errorf("exponent must be in range between -92233720368547757 and 92233720368547757")
-	}
+func (d Decimal) MarshalJSON() ([]byte, error) { return d.MarshalText() }
 
-	if exp < 0 || exp == 0 && []rune(parts[0])[0] == '-' {
-		*d = Decimal(exp*100 - frac)
-	} else {
-		*d = Decimal(exp*100 + frac)
+// UnmarshalJSON accepts numbers and strings, strings may also have thousands separators, e.g. "1,234.50"
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	parsed, err := ParseDecimal(string(data), AllowQuotes, AllowPlus, AllowGrouping)
+	if err != nil {
+		return err
 	}
-
+	*d = parsed
 	return nil
 }
-func (d Decimal) MarshalJSON() ([]byte, error)     { return d.MarshalText() }
-func (d *Decimal) UnmarshalJSON(data []byte) error { return d.UnmarshalText(data) }
@@ -0,0 +1,111 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrDecimalSyntax    = errors.New("invalid decimal syntax")
+	ErrDecimalPrecision = errors.New("decimal has more than 2 fraction digits")
+)
+
+// maxExponent is the largest integer part ParseDecimal accepts, so every parsed value prints back the same
+const maxExponent = 92233720368547757
+
+// DecimalError is returned by ParseDecimal, Err is ErrDecimalSyntax, ErrDecimalPrecision or ErrDecimalOverflow
+type DecimalError struct {
+	Text string
+	Err  error
+}
+
+func (e *DecimalError) Error() string { return fmt.Sprintf("parse decimal %q: %s", e.Text, e.Err) }
+func (e *DecimalError) Unwrap() error { return e.Err }
+
+// ParseOption relaxes the syntax accepted by ParseDecimal
+type ParseOption uint8
+
+const (
+	// AllowQuotes accepts the number in double quotes, as JSON clients often send amounts
+	AllowQuotes ParseOption = 1 << iota
+	// AllowPlus accepts an explicit plus sign
+	AllowPlus
+	// AllowGrouping accepts commas between groups of three integer digits, e.g. 1,234,567.89
+	AllowGrouping
+)
+
+// ParseDecimal parses a decimal number with up to 2 fraction digits, missing digits are zeros, so "1.5" is 1.50
+func ParseDecimal(text string, options ...ParseOption) (Decimal, error) {
+	fail := func(err error) (Decimal, error) { return 0, &DecimalError{Text: text, Err: err} }
+
+	negative, whole, frac, err := splitNumber(text, options...)
+	if err != nil {
+		return 0, err
+	}
+	if len(frac) > 2 {
+		return fail(ErrDecimalPrecision)
+	}
+
+	exp, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || exp > maxExponent {
+		return fail(fmt.Errorf("%w: integer part must be at most %d", ErrDecimalOverflow, maxExponent))
+	}
+	fraction, _ := strconv.ParseInt(frac+strings.Repeat("0", 2-len(frac)), 10, 64)
+
+	d := Decimal(exp*100 + fraction)
+	if negative {
+		d = -d
+	}
+	return d, nil
+}
+
+// splitNumber checks the syntax of the decimal number and splits it into its sign, integer and fraction digits
+func splitNumber(text string, options ...ParseOption) (negative bool, whole, frac string, err error) {
+	var allowed ParseOption
+	for _, option := range options {
+		allowed |= option
+	}
+	fail := func(err error) (bool, string, string, error) {
+		return false, "", "", &DecimalError{Text: text, Err: err}
+	}
+
+	s := text
+	if allowed&AllowQuotes != 0 && len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		s = s[1 : len(s)-1]
+	}
+
+	switch {
+	case strings.HasPrefix(s, "-"):
+		negative, s = true, s[1:]
+	case strings.HasPrefix(s, "+") && allowed&AllowPlus != 0:
+		s = s[1:]
+	}
+
+	whole, frac, point := strings.Cut(s, ".")
+	if allowed&AllowGrouping != 0 && strings.Contains(whole, ",") {
+		var ok bool
+		if whole, ok = ungroup(whole); !ok {
+			return fail(fmt.Errorf("%w: digits must be grouped by three", ErrDecimalSyntax))
+		}
+	}
+	if whole == "" || !digits(whole) {
+		return fail(fmt.Errorf("%w: integer part must be digits", ErrDecimalSyntax))
+	}
+	if point && (frac == "" || !digits(frac)) {
+		return fail(fmt.Errorf("%w: fraction must be digits", ErrDecimalSyntax))
+	}
+	return negative, whole, frac, nil
+}
+
+// ungroup removes the thousands separators if every group but the first has three digits
+func ungroup(s string) (string, bool) {
+	groups := strings.Split(s, ",")
+	for i, group := range groups {
+		if i == 0 && (len(group) == 0 || len(group) > 3) || i > 0 && len(group) != 3 {
+			return "", false
+		}
+	}
+	return strings.Join(groups, ""), true
+}
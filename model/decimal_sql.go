@@ -0,0 +1,81 @@
+package model
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// MaxDecimal is the largest amount that can be parsed and stored, the database keeps amounts within ±MaxDecimal
+	MaxDecimal Decimal = maxExponent*100 + 99
+	MinDecimal         = -MaxDecimal
+)
+
+// ScanNumeric implements pgtype.NumericScanner, so NUMERIC columns are scanned without a float or text round trip
+func (d *Decimal) ScanNumeric(n pgtype.Numeric) error {
+	if !n.Valid {
+		return errors.New("cannot scan NULL into Decimal")
+	}
+	if n.NaN || n.InfinityModifier != pgtype.Finite {
+		return errors.New("cannot scan NaN or infinity into Decimal")
+	}
+
+	v := new(big.Int).Set(n.Int)
+	if exp := n.Exp + 2; exp >= 0 {
+		v.Mul(v, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil))
+	} else {
+		var rem *big.Int
+		v, rem = v.QuoRem(v, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-exp)), nil), new(big.Int))
+		if rem.Sign() != 0 {
+			return fmt.Errorf("scan numeric %s: %w", numericString(n), ErrDecimalPrecision)
+		}
+	}
+
+	if !v.IsInt64() || Decimal(v.Int64()) > MaxDecimal || Decimal(v.Int64()) < MinDecimal {
+		return fmt.Errorf("scan numeric %s: %w: Decimal holds %s to %s", numericString(n), ErrDecimalOverflow, MinDecimal, MaxDecimal)
+	}
+
+	*d = Decimal(v.Int64())
+	return nil
+}
+
+// NumericValue implements pgtype.NumericValuer
+func (d Decimal) NumericValue() (pgtype.Numeric, error) {
+	return pgtype.Numeric{Int: big.NewInt(int64(d)), Exp: -2, Valid: true}, nil
+}
+
+// Scan implements sql.Scanner for drivers which return NUMERIC as text or as an integer
+func (d *Decimal) Scan(src any) error {
+	var n pgtype.Numeric
+	switch src := src.(type) {
+	case string:
+		if err := n.Scan(src); err != nil {
+			return err
+		}
+	case []byte:
+		if err := n.Scan(string(src)); err != nil {
+			return err
+		}
+	case int64:
+		n = pgtype.Numeric{Int: big.NewInt(src), Valid: true}
+	case nil:
+	default:
+		return fmt.Errorf("cannot scan %T into Decimal", src)
+	}
+	return d.ScanNumeric(n)
+}
+
+// Value implements driver.Valuer
+func (d Decimal) Value() (driver.Value, error) { return d.String(), nil }
+
+func numericString(n pgtype.Numeric) string {
+	value, err := n.Value()
+	if err != nil {
+		return "?"
+	}
+	return fmt.Sprint(value)
+}
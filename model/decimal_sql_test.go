@@ -0,0 +1,99 @@
+package model_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mustan989/wallet/model"
+)
+
+func TestDecimal_ScanNumeric(t *testing.T) {
+	subtests := [...]struct {
+		name   string
+		input  pgtype.Numeric
+		expect model.Decimal
+	}{
+		{"Cents", pgtype.Numeric{Int: big.NewInt(1234), Exp: -2, Valid: true}, 1234},
+		{"Wide scale", pgtype.Numeric{Int: big.NewInt(-125000), Exp: -4, Valid: true}, -1250},
+		{"Positive exponent", pgtype.Numeric{Int: big.NewInt(12), Exp: 3, Valid: true}, 1200000},
+		{"Max value", pgtype.Numeric{Int: big.NewInt(int64(model.MaxDecimal)), Exp: -2, Valid: true}, model.MaxDecimal},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			var d model.Decimal
+			require.NoError(t, d.ScanNumeric(subtest.input))
+			require.Equal(t, subtest.expect, d)
+		})
+	}
+}
+
+func TestDecimal_ScanNumericError(t *testing.T) {
+	subtests := [...]struct {
+		name  string
+		input pgtype.Numeric
+		err   error
+	}{
+		{"Sub cent", pgtype.Numeric{Int: big.NewInt(12345), Exp: -3, Valid: true}, model.ErrDecimalPrecision},
+		{"Over max value", pgtype.Numeric{Int: big.NewInt(int64(model.MaxDecimal) + 1), Exp: -2, Valid: true}, model.ErrDecimalOverflow},
+		{"Over int64", pgtype.Numeric{Int: big.NewInt(1), Exp: 19, Valid: true}, model.ErrDecimalOverflow},
+		{"NULL", pgtype.Numeric{}, nil},
+		{"NaN", pgtype.Numeric{NaN: true, Valid: true}, nil},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			var d model.Decimal
+			err := d.ScanNumeric(subtest.input)
+			require.Error(t, err)
+			if subtest.err != nil {
+				require.ErrorIs(t, err, subtest.err)
+			}
+			require.Zero(t, d)
+		})
+	}
+}
+
+func TestDecimal_NumericValue(t *testing.T) {
+	n, err := model.Decimal(-1234).NumericValue()
+	require.NoError(t, err)
+
+	var d model.Decimal
+	require.NoError(t, d.ScanNumeric(n))
+	require.Equal(t, model.Decimal(-1234), d)
+}
+
+func TestDecimal_Scan(t *testing.T) {
+	subtests := [...]struct {
+		name   string
+		input  any
+		expect model.Decimal
+	}{
+		{"String", "12.34", 1234},
+		{"Wide scale", "12.5000", 1250},
+		{"Bytes", []byte("-0.05"), -5},
+		{"Integer", int64(12), 1200},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			var d model.Decimal
+			require.NoError(t, d.Scan(subtest.input))
+			require.Equal(t, subtest.expect, d)
+		})
+	}
+
+	var d model.Decimal
+	require.Error(t, d.Scan(nil))
+	require.Error(t, d.Scan(1.5))
+	require.ErrorIs(t, d.Scan("92233720368547758.00"), model.ErrDecimalOverflow)
+}
+
+func TestDecimal_Value(t *testing.T) {
+	value, err := model.Decimal(-1234).Value()
+	require.NoError(t, err)
+	require.Equal(t, "-12.34", value)
+}
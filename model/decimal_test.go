@@ -72,6 +72,9 @@ func TestDecimal_UnmarshalTextNoError(t *testing.T) {
 		{"Exponent only", []byte(`99.00`), 9900},
 		{"Integer", []byte(`99`), 9900},
 		{"Positive", []byte(`99.99`), 9999},
+		{"Short fraction", []byte(`1.5`), 150},
+		{"Short fraction only", []byte(`0.5`), 50},
+		{"Plus", []byte(`+1.50`), 150},
 		{"Max value", []byte(`92233720368547757.99`), 9223372036854775799},
 		{"Negative precision only", []byte(`-0.99`), -99},
 		{"Negative exponent only", []byte(`-99.00`), -9900},
@@ -149,6 +152,10 @@ func TestDecimal_UnmarshalJSONNoError(t *testing.T) {
 		{"Exponent only", []byte(`99.00`), 9900},
 		{"Integer", []byte(`99`), 9900},
 		{"Positive", []byte(`99.99`), 9999},
+		{"Short fraction", []byte(`1.5`), 150},
+		{"Quoted string", []byte(`"99.99"`), 9999},
+		{"Quoted plus", []byte(`"+1.5"`), 150},
+		{"Quoted grouping", []byte(`"1,234,567.50"`), 123456750},
 		{"Max value", []byte(`92233720368547757.99`), 9223372036854775799},
 		{"Negative precision only", []byte(`-0.99`), -99},
 		{"Negative exponent only", []byte(`-99.00`), -9900},
@@ -176,7 +183,8 @@ func TestDecimal_UnmarshalJSONError(t *testing.T) {
 		{"String fraction", []byte(`zero.99`)},
 		{"Fraction over limit", []byte(`99.100`)},
 		{"Fraction below limit", []byte(`99.-1`)},
-		{"Quoted string", []byte(`"99.99"`)},
+		{"Quoted letters", []byte(`"99.zero"`)},
+		{"Bad grouping", []byte(`"1,23.00"`)},
 		{"Over top limit", []byte(`92233720368547758.00`)},
 		{"Below bottom limit", []byte(`-92233720368547758.00`)},
 	}
@@ -188,3 +196,35 @@ func TestDecimal_UnmarshalJSONError(t *testing.T) {
 		})
 	}
 }
+
+func TestParseDecimalError(t *testing.T) {
+	subtests := [...]struct {
+		name    string
+		input   string
+		options []model.ParseOption
+		err     error
+	}{
+		{"Empty", ``, nil, model.ErrDecimalSyntax},
+		{"Sign only", `-`, nil, model.ErrDecimalSyntax},
+		{"Point only", `1.`, nil, model.ErrDecimalSyntax},
+		{"Plus not allowed", `+1`, nil, model.ErrDecimalSyntax},
+		{"Quotes not allowed", `"1"`, nil, model.ErrDecimalSyntax},
+		{"Grouping not allowed", `1,000`, nil, model.ErrDecimalSyntax},
+		{"Long first group", `1234,567`, []model.ParseOption{model.AllowGrouping}, model.ErrDecimalSyntax},
+		{"Three fraction digits", `1.505`, nil, model.ErrDecimalPrecision},
+		{"Over top limit", `92233720368547758`, nil, model.ErrDecimalOverflow},
+		{"Over int64", `9223372036854775808`, nil, model.ErrDecimalOverflow},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			d, err := model.ParseDecimal(subtest.input, subtest.options...)
+			require.Zero(t, d)
+			require.ErrorIs(t, err, subtest.err)
+
+			var decimalErr *model.DecimalError
+			require.ErrorAs(t, err, &decimalErr)
+			require.Equal(t, subtest.input, decimalErr.Text)
+		})
+	}
+}
@@ -3,4 +3,32 @@ package model
 type Filter struct {
 	Limit  uint64 `json:"limit" query:"limit"`
 	Offset uint64 `json:"offset" query:"offset"`
+	// Cursor starts the page after the record it was made for, it can be combined with Offset
+	Cursor *Cursor `json:"cursor" query:"cursor"`
+}
+
+// Full reports whether a page of n records is full, so the listing may go on after it
+func (f Filter) Full(n int) bool { return f.Limit != 0 && uint64(n) == f.Limit }
+
+// SortOrder is the direction of a sort, the zero value leaves it to the sorted field
+type SortOrder string
+
+const (
+	Asc  SortOrder = "asc"
+	Desc SortOrder = "desc"
+)
+
+func (o SortOrder) Valid() bool { return o == "" || o == Asc || o == Desc }
+
+// DeletedState selects records by their soft deleted state, the zero value leaves deleted records out
+type DeletedState string
+
+const (
+	DeletedInclude DeletedState = "include"
+	DeletedExclude DeletedState = "exclude"
+	DeletedOnly    DeletedState = "only"
+)
+
+func (s DeletedState) Valid() bool {
+	return s == "" || s == DeletedInclude || s == DeletedExclude || s == DeletedOnly
 }
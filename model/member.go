@@ -0,0 +1,32 @@
+package model
+
+import "time"
+
+// Role of a user in a shared wallet, each role grants everything the previous one does
+type Role string
+
+const (
+	// Viewer can only read the wallet and its transactions
+	Viewer Role = "viewer"
+	// Editor can also add, change and remove transactions and transfers of the wallet
+	Editor Role = "editor"
+	// Owner can also change, delete and share the wallet
+	Owner Role = "owner"
+)
+
+var roleRanks = map[Role]int{Viewer: 1, Editor: 2, Owner: 3}
+
+func (r Role) Valid() bool { return roleRanks[r] != 0 }
+
+// Includes reports whether the role grants everything the other one does
+func (r Role) Includes(other Role) bool { return r.Valid() && roleRanks[r] >= roleRanks[other] }
+
+// Member is a user the wallet is shared with.
+// The owner of the wallet is not a member, it always has the Owner role
+type Member struct {
+	WalletID  uint64    `json:"wallet_id"`
+	UserID    uint64    `json:"user_id"`
+	Role      Role      `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,31 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mustan989/wallet/model"
+)
+
+func TestRole_Includes(t *testing.T) {
+	subtests := [...]struct {
+		name   string
+		role   model.Role
+		other  model.Role
+		expect bool
+	}{
+		{"Owner editor", model.Owner, model.Editor, true},
+		{"Editor editor", model.Editor, model.Editor, true},
+		{"Editor owner", model.Editor, model.Owner, false},
+		{"Viewer editor", model.Viewer, model.Editor, false},
+		{"Invalid viewer", "admin", model.Viewer, false},
+		{"None viewer", "", model.Viewer, false},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			require.Equal(t, subtest.expect, subtest.role.Includes(subtest.other))
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/mustan989/wallet/pkg/currency"
+)
+
+var (
+	ErrCurrencyMismatch = errors.New("amounts are in different currencies")
+	ErrInexactAmount    = errors.New("amount has more fraction digits than the currency")
+)
+
+// Money is an amount in the minor units of its currency, e.g. Money{1234, BHD} is 1.234 BHD.
+// Amounts come from requests and from the database in Unbound, In binds them to the currency of their wallet
+type Money struct {
+	Amount   int64
+	Currency Currency
+}
+
+// Unbound is the currency of amounts not bound to one yet, it has the most minor digits, so it holds them exactly.
+// The database stores amounts of every currency in its minor units
+var Unbound = Currency{Exponent: MaxExponent}
+
+// MaxStored is the largest amount the database keeps in the minor units of Unbound, i.e. 922337203685477.5799,
+// it keeps amounts within ±MaxStored. The bound is the integer of MaxDecimal, so the integer columns of SQLite keep their range
+const MaxStored = int64(MaxDecimal)
+
+// ParseMoney parses a plain decimal number of major units, e.g. "-12.5", into the currency.
+// Fraction digits beyond the currency exponent are accepted only if they are zeros, like NUMERIC columns print them
+func ParseMoney(text string, currency Currency) (Money, error) {
+	negative, whole, frac, err := splitNumber(text)
+	if err != nil {
+		return Money{}, err
+	}
+	return moneyOf(negative, whole, frac, currency)
+}
+
+func moneyOf(negative bool, whole, frac string, currency Currency) (Money, error) {
+	exponent := int(currency.Exponent)
+	if len(frac) > exponent {
+		if strings.Trim(frac[exponent:], "0") != "" {
+			return Money{}, fmt.Errorf("%w: %s has %d", ErrInexactAmount, currency, exponent)
+		}
+		frac = frac[:exponent]
+	}
+	frac += strings.Repeat("0", exponent-len(frac))
+
+	amount, _ := new(big.Int).SetString(whole+frac, 10)
+	if negative {
+		amount.Neg(amount)
+	}
+	if !amount.IsInt64() {
+		return Money{}, ErrDecimalOverflow
+	}
+	return Money{Amount: amount.Int64(), Currency: currency}, nil
+}
+
+// MoneyOf converts the two digit decimal into the currency, failing if the currency cannot represent it exactly
+func MoneyOf(d Decimal, currency Currency) (Money, error) {
+	return Money{Amount: int64(d), Currency: Currency{Exponent: 2}}.In(currency)
+}
+
+// In binds the money to the currency keeping its value, e.g. 1.5 in Unbound is 1.500 in KWD.
+// It fails with ErrCurrencyMismatch if the money is bound to another currency
+// and with ErrInexactAmount if the currency has too few minor digits for it
+func (m Money) In(c Currency) (Money, error) {
+	if m.Currency.Code != "" && c.Code != "" && m.Currency.Code != c.Code {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, c)
+	}
+
+	switch {
+	case c.Exponent < m.Currency.Exponent:
+		factor := pow10(m.Currency.Exponent - c.Exponent)
+		if m.Amount%factor != 0 {
+			return Money{}, fmt.Errorf("%w: %s has %d", ErrInexactAmount, c, c.Exponent)
+		}
+		return Money{Amount: m.Amount / factor, Currency: c}, nil
+	case c.Exponent > m.Currency.Exponent:
+		amount := new(big.Int).Mul(big.NewInt(m.Amount), big.NewInt(pow10(c.Exponent-m.Currency.Exponent)))
+		if !amount.IsInt64() {
+			return Money{}, ErrDecimalOverflow
+		}
+		return Money{Amount: amount.Int64(), Currency: c}, nil
+	default:
+		return Money{Amount: m.Amount, Currency: c}, nil
+	}
+}
+
+// Bind binds the amounts to the currency of the code, see In
+func Bind(code string, amounts ...*Money) error {
+	c, err := CurrencyOf(code)
+	if err != nil {
+		return err
+	}
+	for _, amount := range amounts {
+		if *amount, err = amount.In(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Decimal converts the amount to two fraction digits, rounding currencies with more minor digits with the mode
+func (m Money) Decimal(mode RoundingMode) (Decimal, error) {
+	if m.Currency.Exponent > 2 {
+		return decimalOf(big.NewInt(m.Amount), big.NewInt(1), big.NewInt(pow10(m.Currency.Exponent-2)), mode)
+	}
+	return decimalOf(big.NewInt(m.Amount), big.NewInt(pow10(2-m.Currency.Exponent)), big.NewInt(1), mode)
+}
+
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	sum, err := Decimal(m.Amount).Add(Decimal(other.Amount))
+	return Money{Amount: int64(sum), Currency: m.Currency}, err
+}
+
+func (m Money) Sub(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	diff, err := Decimal(m.Amount).Sub(Decimal(other.Amount))
+	return Money{Amount: int64(diff), Currency: m.Currency}, err
+}
+
+// Neg returns the money with the opposite sign, amounts within ±MaxStored always have one
+func (m Money) Neg() Money { return Money{Amount: -m.Amount, Currency: m.Currency} }
+
+// Sign returns -1, 0 or +1 depending on the sign of the amount
+func (m Money) Sign() int { return Decimal(m.Amount).Sign() }
+
+// Cmp compares the values of the amounts whatever their currencies, the way the database orders them
+func (m Money) Cmp(other Money) int {
+	scaled := func(m Money) *big.Int {
+		return new(big.Int).Mul(big.NewInt(m.Amount), big.NewInt(pow10(MaxExponent-m.Currency.Exponent)))
+	}
+	return scaled(m).Cmp(scaled(other))
+}
+
+// Exchange converts the money at the rate into the currency, rounding to its minor unit with the mode, e.g. to whole yens
+func (m Money) Exchange(r Rate, to Currency, mode RoundingMode) (Money, error) {
+	n := new(big.Int).Mul(big.NewInt(m.Amount), big.NewInt(int64(r)))
+	n.Mul(n, big.NewInt(pow10(to.Exponent)))
+	d := new(big.Int).Mul(big.NewInt(rateScale), big.NewInt(pow10(m.Currency.Exponent)))
+
+	q := roundQuo(n, d, mode)
+	if !q.IsInt64() {
+		return Money{}, ErrDecimalOverflow
+	}
+	return Money{Amount: q.Int64(), Currency: to}, nil
+}
+
+// Allocate divides the money in proportion to the ratios in minor units of its currency, see Decimal.Allocate
+func (m Money) Allocate(ratios ...int64) ([]Money, error) {
+	amounts, err := allocate(m.Amount, ratios)
+	if err != nil {
+		return nil, err
+	}
+	parts := make([]Money, len(amounts))
+	for i, amount := range amounts {
+		parts[i] = Money{Amount: amount, Currency: m.Currency}
+	}
+	return parts, nil
+}
+
+// Number formats the amount with exactly the currency exponent fraction digits, e.g. "1.234" or "1000"
+func (m Money) Number() string {
+	text := new(big.Int).Abs(big.NewInt(m.Amount)).String()
+	exponent := int(m.Currency.Exponent)
+	if len(text) <= exponent {
+		text = strings.Repeat("0", exponent-len(text)+1) + text
+	}
+	if exponent > 0 {
+		text = text[:len(text)-exponent] + "." + text[len(text)-exponent:]
+	}
+	if m.Amount < 0 {
+		text = "-" + text
+	}
+	return text
+}
+
+func (m Money) String() string { return m.Number() + " " + m.Currency.Code }
+
+// Format writes the money as the locale expects, e.g. "$1,234.50" in en-US or "1 234,50 ₸" in kk-KZ
+func (m Money) Format(l currency.Locale) string {
+	return l.Format(m.Amount, symbolOf(m.Currency))
+}
+
+// ParseLocalized parses the amount written in the locale, e.g. typed in by a user, the symbol is optional
+func ParseLocalized(text string, c Currency, l currency.Locale) (Money, error) {
+	amount, err := l.Parse(text, symbolOf(c))
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: amount, Currency: c}, nil
+}
+
+// symbolOf returns the registered currency, the code serves as the symbol of unregistered ones
+func symbolOf(c Currency) currency.Currency {
+	registered, err := currency.Default().Lookup(c.Code)
+	if err != nil {
+		registered = currency.Currency{Code: c.Code, Symbol: c.Code}
+	}
+	registered.MinorUnits = c.Exponent
+	return registered
+}
+
+func (m Money) MarshalText() ([]byte, error) { return []byte(m.Number()), nil }
+
+// UnmarshalText parses a plain decimal number with an optional sign into Unbound, e.g. "-12.5" or "+1.234"
+func (m *Money) UnmarshalText(data []byte) error { return m.parse(string(data), AllowPlus) }
+
+// MarshalJSON writes the amount as a bare number with the digits of its currency, e.g. 1.234 or 1000
+func (m Money) MarshalJSON() ([]byte, error) { return m.MarshalText() }
+
+// UnmarshalJSON accepts numbers and strings into Unbound, strings may also have thousands separators, e.g. "1,234.500"
+func (m *Money) UnmarshalJSON(data []byte) error {
+	return m.parse(string(data), AllowQuotes, AllowPlus, AllowGrouping)
+}
+
+func (m *Money) parse(text string, options ...ParseOption) error {
+	negative, whole, frac, err := splitNumber(text, options...)
+	if err != nil {
+		return err
+	}
+	money, err := moneyOf(negative, whole, frac, Unbound)
+	if err != nil {
+		return &DecimalError{Text: text, Err: err}
+	}
+	*m = money
+	return nil
+}
+
+func digits(s string) bool {
+	for _, r := range s {
+		if r < '0' || '9' < r {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ScanNumeric implements pgtype.NumericScanner, the amount is scanned in Unbound until it is bound to its currency
+func (m *Money) ScanNumeric(n pgtype.Numeric) error {
+	if !n.Valid {
+		return errors.New("cannot scan NULL into Money")
+	}
+	if n.NaN || n.InfinityModifier != pgtype.Finite {
+		return errors.New("cannot scan NaN or infinity into Money")
+	}
+
+	v := new(big.Int).Set(n.Int)
+	if exp := n.Exp + MaxExponent; exp >= 0 {
+		v.Mul(v, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil))
+	} else {
+		var rem *big.Int
+		v, rem = v.QuoRem(v, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-exp)), nil), new(big.Int))
+		if rem.Sign() != 0 {
+			return fmt.Errorf("scan numeric %s: %w: %s has %d", numericString(n), ErrInexactAmount, Unbound, MaxExponent)
+		}
+	}
+
+	if !v.IsInt64() {
+		return fmt.Errorf("scan numeric %s: %w", numericString(n), ErrDecimalOverflow)
+	}
+
+	*m = Money{Amount: v.Int64(), Currency: Unbound}
+	return nil
+}
+
+// NumericValue implements pgtype.NumericValuer, the amount keeps the minor digits of its currency
+func (m Money) NumericValue() (pgtype.Numeric, error) {
+	return pgtype.Numeric{Int: big.NewInt(m.Amount), Exp: -int32(m.Currency.Exponent), Valid: true}, nil
+}
@@ -0,0 +1,63 @@
+package model_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mustan989/wallet/model"
+)
+
+func TestMoney_ScanNumeric(t *testing.T) {
+	subtests := [...]struct {
+		name   string
+		input  pgtype.Numeric
+		expect int64
+	}{
+		{"Fils", pgtype.Numeric{Int: big.NewInt(1234), Exp: -3, Valid: true}, 12340},
+		{"Column scale", pgtype.Numeric{Int: big.NewInt(-125000), Exp: -4, Valid: true}, -125000},
+		{"Positive exponent", pgtype.Numeric{Int: big.NewInt(12), Exp: 3, Valid: true}, 120000000},
+		{"Max stored", pgtype.Numeric{Int: big.NewInt(model.MaxStored), Exp: -4, Valid: true}, model.MaxStored},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			var m model.Money
+			require.NoError(t, m.ScanNumeric(subtest.input))
+			require.Equal(t, model.Money{Amount: subtest.expect, Currency: model.Unbound}, m)
+		})
+	}
+}
+
+func TestMoney_ScanNumericError(t *testing.T) {
+	subtests := [...]struct {
+		name  string
+		input pgtype.Numeric
+		err   error
+	}{
+		{"Fifth digit", pgtype.Numeric{Int: big.NewInt(12345), Exp: -5, Valid: true}, model.ErrInexactAmount},
+		{"Over int64", pgtype.Numeric{Int: big.NewInt(1), Exp: 16, Valid: true}, model.ErrDecimalOverflow},
+		{"NULL", pgtype.Numeric{}, nil},
+		{"NaN", pgtype.Numeric{NaN: true, Valid: true}, nil},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			var m model.Money
+			err := m.ScanNumeric(subtest.input)
+			require.Error(t, err)
+			if subtest.err != nil {
+				require.ErrorIs(t, err, subtest.err)
+			}
+			require.Zero(t, m)
+		})
+	}
+}
+
+func TestMoney_NumericValue(t *testing.T) {
+	n, err := model.Money{Amount: 1234, Currency: model.Currency{Code: "KWD", Exponent: 3}}.NumericValue()
+	require.NoError(t, err)
+	require.Equal(t, pgtype.Numeric{Int: big.NewInt(1234), Exp: -3, Valid: true}, n)
+}
@@ -0,0 +1,262 @@
+package model_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mustan989/wallet/model"
+	"github.com/mustan989/wallet/pkg/currency"
+)
+
+var (
+	usd = model.Currency{Code: "USD", Exponent: 2}
+	jpy = model.Currency{Code: "JPY", Exponent: 0}
+	kwd = model.Currency{Code: "KWD", Exponent: 3}
+	kzt = model.Currency{Code: "KZT", Exponent: 2}
+)
+
+func TestParseMoney(t *testing.T) {
+	subtests := [...]struct {
+		name     string
+		input    string
+		currency model.Currency
+		expect   int64
+	}{
+		{"Cents", "12.34", usd, 1234},
+		{"Short fraction", "12.5", usd, 1250},
+		{"Integer", "12", usd, 1200},
+		{"Negative", "-0.05", usd, -5},
+		{"Yen", "1500", jpy, 1500},
+		{"Yen with zero fraction", "1500.0000", jpy, 1500},
+		{"Fils", "1.234", kwd, 1234},
+		{"Fils with zero fraction", "1.2340", kwd, 1234},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			money, err := model.ParseMoney(subtest.input, subtest.currency)
+			require.NoError(t, err)
+			require.Equal(t, model.Money{Amount: subtest.expect, Currency: subtest.currency}, money)
+		})
+	}
+}
+
+func TestParseMoneyError(t *testing.T) {
+	subtests := [...]struct {
+		name     string
+		input    string
+		currency model.Currency
+		err      error
+	}{
+		{"Yen cents", "1500.5", jpy, model.ErrInexactAmount},
+		{"Too many digits", "1.2345", kwd, model.ErrInexactAmount},
+		{"Overflow", "92233720368547758.08", usd, model.ErrDecimalOverflow},
+		{"Empty", "", usd, nil},
+		{"Point only", "1.", usd, nil},
+		{"Letters", "1.2a", usd, nil},
+		{"Two points", "1.2.3", usd, nil},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			money, err := model.ParseMoney(subtest.input, subtest.currency)
+			require.Zero(t, money)
+			require.Error(t, err)
+			if subtest.err != nil {
+				require.ErrorIs(t, err, subtest.err)
+			}
+		})
+	}
+}
+
+func TestMoney_Number(t *testing.T) {
+	subtests := [...]struct {
+		name   string
+		input  model.Money
+		expect string
+	}{
+		{"Cents", model.Money{Amount: 1234, Currency: usd}, "12.34"},
+		{"Fraction only", model.Money{Amount: -5, Currency: usd}, "-0.05"},
+		{"Yen", model.Money{Amount: 1500, Currency: jpy}, "1500"},
+		{"Fils", model.Money{Amount: 1234, Currency: kwd}, "1.234"},
+		{"Zero fils", model.Money{Currency: kwd}, "0.000"},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			require.Equal(t, subtest.expect, subtest.input.Number())
+		})
+	}
+}
+
+func TestMoneyOf(t *testing.T) {
+	money, err := model.MoneyOf(150000, jpy)
+	require.NoError(t, err)
+	require.Equal(t, model.Money{Amount: 1500, Currency: jpy}, money)
+
+	money, err = model.MoneyOf(1234, kwd)
+	require.NoError(t, err)
+	require.Equal(t, model.Money{Amount: 12340, Currency: kwd}, money)
+
+	_, err = model.MoneyOf(150050, jpy)
+	require.ErrorIs(t, err, model.ErrInexactAmount)
+}
+
+func TestMoney_Decimal(t *testing.T) {
+	d, err := model.Money{Amount: 1235, Currency: kwd}.Decimal(model.HalfEven)
+	require.NoError(t, err)
+	require.Equal(t, model.Decimal(124), d)
+
+	d, err = model.Money{Amount: 1500, Currency: jpy}.Decimal(model.HalfEven)
+	require.NoError(t, err)
+	require.Equal(t, model.Decimal(150000), d)
+}
+
+func TestMoney_Add(t *testing.T) {
+	sum, err := model.Money{Amount: 1, Currency: kwd}.Add(model.Money{Amount: 2, Currency: kwd})
+	require.NoError(t, err)
+	require.Equal(t, model.Money{Amount: 3, Currency: kwd}, sum)
+
+	_, err = model.Money{Amount: 1, Currency: kwd}.Sub(model.Money{Amount: 2, Currency: usd})
+	require.ErrorIs(t, err, model.ErrCurrencyMismatch)
+}
+
+func TestMoney_In(t *testing.T) {
+	subtests := [...]struct {
+		name     string
+		input    model.Money
+		currency model.Currency
+		expect   model.Money
+	}{
+		{"Unbound to fils", model.Money{Amount: 12340, Currency: model.Unbound}, kwd, model.Money{Amount: 1234, Currency: kwd}},
+		{"Unbound to yen", model.Money{Amount: 15000000, Currency: model.Unbound}, jpy, model.Money{Amount: 1500, Currency: jpy}},
+		{"Cents to unbound", model.Money{Amount: -5, Currency: usd}, model.Unbound, model.Money{Amount: -500, Currency: model.Unbound}},
+		{"Same currency", model.Money{Amount: 1234, Currency: kwd}, kwd, model.Money{Amount: 1234, Currency: kwd}},
+		{"Zero", model.Money{}, jpy, model.Money{Currency: jpy}},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			money, err := subtest.input.In(subtest.currency)
+			require.NoError(t, err)
+			require.Equal(t, subtest.expect, money)
+		})
+	}
+}
+
+func TestMoney_InError(t *testing.T) {
+	subtests := [...]struct {
+		name     string
+		input    model.Money
+		currency model.Currency
+		err      error
+	}{
+		{"Yen cents", model.Money{Amount: 15005000, Currency: model.Unbound}, jpy, model.ErrInexactAmount},
+		{"Other currency", model.Money{Amount: 1234, Currency: kwd}, usd, model.ErrCurrencyMismatch},
+		{"Overflow", model.Money{Amount: math.MaxInt64, Currency: jpy}, model.Unbound, model.ErrDecimalOverflow},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			_, err := subtest.input.In(subtest.currency)
+			require.ErrorIs(t, err, subtest.err)
+		})
+	}
+}
+
+func TestBind(t *testing.T) {
+	amount, commission := model.Money{Amount: 12340, Currency: model.Unbound}, model.Money{}
+	require.NoError(t, model.Bind("KWD", &amount, &commission))
+	require.Equal(t, model.Money{Amount: 1234, Currency: kwd}, amount)
+	require.Equal(t, model.Money{Currency: kwd}, commission)
+
+	require.ErrorIs(t, model.Bind("XYZ", &amount), model.ErrInvalidCurrency)
+}
+
+func TestMoney_Cmp(t *testing.T) {
+	require.Equal(t, -1, model.Money{Amount: 1234, Currency: kwd}.Cmp(model.Money{Amount: 124, Currency: usd}))
+	require.Equal(t, 0, model.Money{Amount: 1000, Currency: kwd}.Cmp(model.Money{Amount: 1, Currency: jpy}))
+	require.Equal(t, 1, model.Money{Amount: 1, Currency: jpy}.Cmp(model.Money{Amount: -9999, Currency: model.Unbound}))
+}
+
+func TestMoney_Exchange(t *testing.T) {
+	subtests := [...]struct {
+		name   string
+		input  model.Money
+		rate   model.Rate
+		to     model.Currency
+		expect model.Money
+	}{
+		{"Dollars to yens", model.Money{Amount: 1050, Currency: usd}, 14012345678, jpy, model.Money{Amount: 1471, Currency: jpy}},
+		{"Yens to dinars", model.Money{Amount: 1000, Currency: jpy}, 203400, kwd, model.Money{Amount: 2034, Currency: kwd}},
+		{"Dinars to dollars", model.Money{Amount: 1235, Currency: kwd}, 325000000, usd, model.Money{Amount: 401, Currency: usd}},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			money, err := subtest.input.Exchange(subtest.rate, subtest.to, model.HalfUp)
+			require.NoError(t, err)
+			require.Equal(t, subtest.expect, money)
+		})
+	}
+
+	_, err := model.Money{Amount: math.MaxInt64, Currency: jpy}.Exchange(2*model.OneRate, jpy, model.HalfUp)
+	require.ErrorIs(t, err, model.ErrDecimalOverflow)
+}
+
+func TestMoney_JSON(t *testing.T) {
+	data, err := json.Marshal(struct {
+		Dinars model.Money `json:"dinars"`
+		Yens   model.Money `json:"yens"`
+	}{model.Money{Amount: 1234, Currency: kwd}, model.Money{Amount: -1500, Currency: jpy}})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"dinars":1.234,"yens":-1500}`, string(data))
+
+	subtests := [...]struct {
+		name   string
+		input  string
+		expect int64
+	}{
+		{"Number", `1.234`, 12340},
+		{"String", `"-1.5"`, -15000},
+		{"Grouped", `"+1,234.5678"`, 12345678},
+		{"Zero digits", `1.23400`, 12340},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			var money model.Money
+			require.NoError(t, json.Unmarshal([]byte(subtest.input), &money))
+			require.Equal(t, model.Money{Amount: subtest.expect, Currency: model.Unbound}, money)
+		})
+	}
+
+	var money model.Money
+	require.ErrorIs(t, json.Unmarshal([]byte(`1.23456`), &money), model.ErrInexactAmount)
+	require.ErrorIs(t, json.Unmarshal([]byte(`"1,2345"`), &money), model.ErrDecimalSyntax)
+	require.ErrorIs(t, money.UnmarshalText([]byte(`"1.5"`)), model.ErrDecimalSyntax)
+}
+
+func TestMoney_Format(t *testing.T) {
+	l, err := currency.LookupLocale("kk-KZ")
+	require.NoError(t, err)
+
+	require.Equal(t, "1 234,50 ₸", model.Money{Amount: 123450, Currency: kzt}.Format(l))
+
+	money, err := model.ParseLocalized("1 234,5 ₸", kzt, l)
+	require.NoError(t, err)
+	require.Equal(t, model.Money{Amount: 123450, Currency: kzt}, money)
+
+	_, err = model.ParseLocalized("1 234,505", kzt, l)
+	require.ErrorIs(t, err, currency.ErrSyntax)
+}
+
+func TestMoney_Allocate(t *testing.T) {
+	// yen have no cents to spread
+	parts, err := model.Money{Amount: 1000, Currency: jpy}.Allocate(1, 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, []model.Money{{Amount: 334, Currency: jpy}, {Amount: 333, Currency: jpy}, {Amount: 333, Currency: jpy}}, parts)
+}
@@ -0,0 +1,87 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Rate is an exchange rate with 8 fraction digits, e.g. Rate(150000000) is 1.5
+type Rate int64
+
+const (
+	rateDigits = 8
+	rateScale  = 100000000
+
+	// OneRate is the rate between the same currencies
+	OneRate Rate = rateScale
+)
+
+var ErrConversionOverflow = errors.New("conversion result is out of range")
+
+func (r Rate) String() string {
+	if r < 0 {
+		return fmt.Sprintf("-%d.%08d", -(r / rateScale), -(r % rateScale))
+	}
+	return fmt.Sprintf("%d.%08d", r/rateScale, r%rateScale)
+}
+
+func (r Rate) MarshalText() ([]byte, error) { return []byte(r.String()), nil }
+
+func (r *Rate) UnmarshalText(data []byte) error {
+	parts := strings.Split(string(data), ".")
+
+	if len(parts) > 2 {
+		return errors.New("rate must be delimited by one point only")
+	}
+
+	exp, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("rate exponent: %w", err)
+	}
+
+	var frac int64
+	if len(parts) == 2 {
+		if len(parts[1]) == 0 || len(parts[1]) > rateDigits || strings.Trim(parts[1], "0123456789") != "" {
+			return fmt.Errorf("rate fraction must be 1 to %d digits", rateDigits)
+		}
+		frac, _ = strconv.ParseInt(parts[1]+strings.Repeat("0", rateDigits-len(parts[1])), 10, 64)
+	}
+
+	const expMax = (1<<63 - 1) / rateScale
+	if exp > expMax-1 || exp < -expMax+1 {
+		return fmt.Errorf("rate exponent must be in range between %d and %d", -expMax+1, expMax-1)
+	}
+
+	if exp < 0 || exp == 0 && strings.HasPrefix(parts[0], "-") {
+		*r = Rate(exp*rateScale - frac)
+	} else {
+		*r = Rate(exp*rateScale + frac)
+	}
+
+	return nil
+}
+
+func (r Rate) MarshalJSON() ([]byte, error)     { return r.MarshalText() }
+func (r *Rate) UnmarshalJSON(data []byte) error { return r.UnmarshalText(data) }
+
+// RateOf returns the rate at which sent was exchanged to received, rounded half away from zero.
+// The rate is between major units, so the amounts may have different minor digits, e.g. 1.000 KWD to 1000 JPY is 1000
+func RateOf(sent, received Money) (Rate, error) {
+	if sent.Amount == 0 {
+		return 0, errors.New("rate of zero amount")
+	}
+	n := new(big.Int).Mul(big.NewInt(received.Amount), big.NewInt(rateScale))
+	n.Mul(n, big.NewInt(pow10(sent.Currency.Exponent)))
+	d := new(big.Int).Mul(big.NewInt(sent.Amount), big.NewInt(pow10(received.Currency.Exponent)))
+	return rateFromBig(roundQuo(n, d, HalfUp))
+}
+
+func rateFromBig(q *big.Int) (Rate, error) {
+	if !q.IsInt64() {
+		return 0, ErrConversionOverflow
+	}
+	return Rate(q.Int64()), nil
+}
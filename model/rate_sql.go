@@ -0,0 +1,67 @@
+package model
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ScanNumeric implements pgtype.NumericScanner, so NUMERIC columns are scanned as rates rather than hundred-millionths
+func (r *Rate) ScanNumeric(n pgtype.Numeric) error {
+	if !n.Valid {
+		return errors.New("cannot scan NULL into Rate")
+	}
+	if n.NaN || n.InfinityModifier != pgtype.Finite {
+		return errors.New("cannot scan NaN or infinity into Rate")
+	}
+
+	v := new(big.Int).Set(n.Int)
+	if exp := n.Exp + rateDigits; exp >= 0 {
+		v.Mul(v, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil))
+	} else {
+		var rem *big.Int
+		v, rem = v.QuoRem(v, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-exp)), nil), new(big.Int))
+		if rem.Sign() != 0 {
+			return fmt.Errorf("scan numeric %s: rate has more than %d fraction digits", numericString(n), rateDigits)
+		}
+	}
+
+	if !v.IsInt64() {
+		return fmt.Errorf("scan numeric %s: %w", numericString(n), ErrConversionOverflow)
+	}
+
+	*r = Rate(v.Int64())
+	return nil
+}
+
+// NumericValue implements pgtype.NumericValuer
+func (r Rate) NumericValue() (pgtype.Numeric, error) {
+	return pgtype.Numeric{Int: big.NewInt(int64(r)), Exp: -rateDigits, Valid: true}, nil
+}
+
+// Scan implements sql.Scanner for drivers which return NUMERIC as text or as an integer
+func (r *Rate) Scan(src any) error {
+	var n pgtype.Numeric
+	switch src := src.(type) {
+	case string:
+		if err := n.Scan(src); err != nil {
+			return err
+		}
+	case []byte:
+		if err := n.Scan(string(src)); err != nil {
+			return err
+		}
+	case int64:
+		n = pgtype.Numeric{Int: big.NewInt(src), Valid: true}
+	case nil:
+	default:
+		return fmt.Errorf("cannot scan %T into Rate", src)
+	}
+	return r.ScanNumeric(n)
+}
+
+// Value implements driver.Valuer
+func (r Rate) Value() (driver.Value, error) { return r.String(), nil }
@@ -0,0 +1,82 @@
+package model_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mustan989/wallet/model"
+)
+
+func TestRate_ScanNumeric(t *testing.T) {
+	subtests := [...]struct {
+		name   string
+		input  pgtype.Numeric
+		expect model.Rate
+	}{
+		{"Column default", pgtype.Numeric{Int: big.NewInt(1), Exp: 0, Valid: true}, model.OneRate},
+		{"Fraction", pgtype.Numeric{Int: big.NewInt(150000000), Exp: -8, Valid: true}, 150000000},
+		{"Short scale", pgtype.Numeric{Int: big.NewInt(15), Exp: -1, Valid: true}, 150000000},
+		{"Large", pgtype.Numeric{Int: big.NewInt(45012345678), Exp: -8, Valid: true}, 45012345678},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			var r model.Rate
+			require.NoError(t, r.ScanNumeric(subtest.input))
+			require.Equal(t, subtest.expect, r)
+		})
+	}
+}
+
+func TestRate_ScanNumericError(t *testing.T) {
+	subtests := [...]struct {
+		name  string
+		input pgtype.Numeric
+		err   error
+	}{
+		{"Too many fraction digits", pgtype.Numeric{Int: big.NewInt(1), Exp: -9, Valid: true}, nil},
+		{"Over int64", pgtype.Numeric{Int: big.NewInt(1), Exp: 11, Valid: true}, model.ErrConversionOverflow},
+		{"NULL", pgtype.Numeric{}, nil},
+		{"NaN", pgtype.Numeric{NaN: true, Valid: true}, nil},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			var r model.Rate
+			err := r.ScanNumeric(subtest.input)
+			require.Error(t, err)
+			if subtest.err != nil {
+				require.ErrorIs(t, err, subtest.err)
+			}
+			require.Zero(t, r)
+		})
+	}
+}
+
+func TestRate_NumericRoundTrip(t *testing.T) {
+	for _, input := range []model.Rate{model.OneRate, 150000000, 100000000000, 1} {
+		n, err := input.NumericValue()
+		require.NoError(t, err)
+
+		// through the text postgres keeps in a decimal(19, 8) column
+		value, err := n.Value()
+		require.NoError(t, err)
+
+		var r model.Rate
+		require.NoError(t, r.Scan(value))
+		require.Equal(t, input, r)
+	}
+}
+
+func TestRate_Value(t *testing.T) {
+	value, err := model.Rate(150000000).Value()
+	require.NoError(t, err)
+	require.Equal(t, "1.50000000", value)
+
+	var r model.Rate
+	require.NoError(t, r.Scan(value))
+	require.Equal(t, model.Rate(150000000), r)
+}
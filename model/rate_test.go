@@ -0,0 +1,98 @@
+package model_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mustan989/wallet/model"
+)
+
+func TestRate_String(t *testing.T) {
+	subtests := [...]struct {
+		name   string
+		input  model.Rate
+		expect string
+	}{
+		{"Zero", 0, `0.00000000`},
+		{"One", model.OneRate, `1.00000000`},
+		{"Fraction only", 213000, `0.00213000`},
+		{"Positive", 45012345678, `450.12345678`},
+		{"Negative fraction only", -50000000, `-0.50000000`},
+		{"Negative", -150000000, `-1.50000000`},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			require.Equal(t, subtest.expect, subtest.input.String())
+		})
+	}
+}
+
+func TestRate_UnmarshalJSONNoError(t *testing.T) {
+	subtests := [...]struct {
+		name   string
+		input  []byte
+		expect model.Rate
+	}{
+		{"Integer", []byte(`450`), 45000000000},
+		{"Short fraction", []byte(`1.5`), 150000000},
+		{"Leading zeros", []byte(`0.00213`), 213000},
+		{"Full fraction", []byte(`450.12345678`), 45012345678},
+		{"Negative fraction only", []byte(`-0.5`), -50000000},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			var val model.Rate
+			require.NoError(t, json.Unmarshal(subtest.input, &val))
+			require.Equal(t, subtest.expect, val)
+		})
+	}
+}
+
+func TestRate_UnmarshalJSONError(t *testing.T) {
+	subtests := [...]struct {
+		name  string
+		input []byte
+	}{
+		{"Two dots", []byte(`1.2.3`)},
+		{"Too many digits", []byte(`1.123456789`)},
+		{"Negative fraction", []byte(`1.-5`)},
+		{"Empty fraction", []byte(`1.`)},
+		{"String", []byte(`one`)},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			var val model.Rate
+			require.Error(t, json.Unmarshal(subtest.input, &val))
+		})
+	}
+}
+
+func TestRateOf(t *testing.T) {
+	subtests := [...]struct {
+		name           string
+		sent, received model.Money
+		expect         model.Rate
+	}{
+		{"Same", model.Money{Amount: 10000, Currency: usd}, model.Money{Amount: 10000, Currency: usd}, model.OneRate},
+		{"USD to KZT", model.Money{Amount: 10000, Currency: usd}, model.Money{Amount: 4501234, Currency: kzt}, 45012340000},
+		{"KZT to USD", model.Money{Amount: 4501234, Currency: kzt}, model.Money{Amount: 10000, Currency: usd}, 222161},
+		{"Round half up", model.Money{Amount: 30000, Currency: usd}, model.Money{Amount: 10000, Currency: usd}, 33333333},
+		{"KWD to JPY", model.Money{Amount: 1000, Currency: kwd}, model.Money{Amount: 491, Currency: jpy}, 49100000000},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			rate, err := model.RateOf(subtest.sent, subtest.received)
+			require.NoError(t, err)
+			require.Equal(t, subtest.expect, rate)
+		})
+	}
+
+	_, err := model.RateOf(model.Money{Currency: usd}, model.Money{Amount: 1, Currency: usd})
+	require.Error(t, err)
+}
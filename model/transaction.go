@@ -0,0 +1,57 @@
+package model
+
+import "time"
+
+type TransactionType string
+
+const (
+	Income  TransactionType = "income"
+	Expense TransactionType = "expense"
+)
+
+func (t TransactionType) Valid() bool { return t == Income || t == Expense }
+
+type Transaction struct {
+	ID          uint64          `json:"id"`
+	WalletID    uint64          `json:"wallet_id"`
+	CategoryID  *uint64         `json:"category_id"`
+	Type        TransactionType `json:"type"`
+	Amount      Money           `json:"amount"`
+	Description *string         `json:"description"`
+	Date        time.Time       `json:"date"`
+	TransferID  *uint64         `json:"transfer_id"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+// Delta returns the signed change the transaction makes to its wallet amount
+func (t Transaction) Delta() Money {
+	if t.Type == Expense {
+		return t.Amount.Neg()
+	}
+	return t.Amount
+}
+
+type TransactionFilter struct {
+	Filter
+	WalletID        *uint64         `query:"wallet_id"`
+	CategoryID      *uint64         `query:"category_id"`
+	Type            TransactionType `query:"type"`
+	DescriptionLike string          `query:"description_like"`
+	DateFrom        *time.Time      `query:"date_from"`
+	DateTo          *time.Time      `query:"date_to"`
+}
+
+// transactionsSort is the only order transactions are listed in
+const transactionsSort = "date desc"
+
+// Next returns the cursor of the page following the last transaction
+func (f *TransactionFilter) Next(last *Transaction) *Cursor {
+	return NewCursor(transactionsSort, last.Date, last.ID)
+}
+
+// After returns the date of the filter cursor
+func (f *TransactionFilter) After() (date time.Time, err error) {
+	err = f.Cursor.Decode(transactionsSort, &date)
+	return
+}
@@ -0,0 +1,34 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mustan989/wallet/model"
+)
+
+func TestTransaction_Delta(t *testing.T) {
+	subtests := [...]struct {
+		name   string
+		input  model.Transaction
+		expect model.Money
+	}{
+		{"Income", model.Transaction{Type: model.Income, Amount: model.Money{Amount: 9999, Currency: usd}}, model.Money{Amount: 9999, Currency: usd}},
+		{"Expense", model.Transaction{Type: model.Expense, Amount: model.Money{Amount: 9999, Currency: usd}}, model.Money{Amount: -9999, Currency: usd}},
+		{"Zero", model.Transaction{Type: model.Expense}, model.Money{}},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			require.Equal(t, subtest.expect, subtest.input.Delta())
+		})
+	}
+}
+
+func TestTransactionType_Valid(t *testing.T) {
+	require.True(t, model.Income.Valid())
+	require.True(t, model.Expense.Valid())
+	require.False(t, model.TransactionType("transfer").Valid())
+	require.False(t, model.TransactionType("").Valid())
+}
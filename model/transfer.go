@@ -0,0 +1,43 @@
+package model
+
+import "time"
+
+// Transfer moves Amount from one wallet to another, crediting ReceivedAmount to the destination.
+// Amounts differ only between wallets of different currencies, Rate is the effective exchange rate.
+// Commission, if any, is recorded as a separate expense of the source wallet and is in its currency like Amount
+type Transfer struct {
+	ID             uint64    `json:"id"`
+	FromWalletID   uint64    `json:"from_wallet_id"`
+	ToWalletID     uint64    `json:"to_wallet_id"`
+	Amount         Money     `json:"amount"`
+	ReceivedAmount Money     `json:"received_amount"`
+	Rate           Rate      `json:"rate"`
+	Commission     Money     `json:"commission"`
+	CommissionID   *uint64   `json:"commission_id"`
+	Description    *string   `json:"description"`
+	Date           time.Time `json:"date"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type TransferFilter struct {
+	Filter
+	WalletID        *uint64    `query:"wallet_id"`
+	DescriptionLike string     `query:"description_like"`
+	DateFrom        *time.Time `query:"date_from"`
+	DateTo          *time.Time `query:"date_to"`
+}
+
+// transfersSort is the only order transfers are listed in
+const transfersSort = "date desc"
+
+// Next returns the cursor of the page following the last transfer
+func (f *TransferFilter) Next(last *Transfer) *Cursor {
+	return NewCursor(transfersSort, last.Date, last.ID)
+}
+
+// After returns the date of the filter cursor
+func (f *TransferFilter) After() (date time.Time, err error) {
+	err = f.Cursor.Decode(transfersSort, &date)
+	return
+}
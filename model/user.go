@@ -0,0 +1,44 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+type User struct {
+	ID           uint64    `json:"id"`
+	Name         string    `json:"name"`
+	Email        string    `json:"email"`
+	PasswordHash []byte    `json:"-"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// Tokens are issued to the user on login
+type Tokens struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	// ExpiresIn is the lifetime of the access token in seconds
+	ExpiresIn int64 `json:"expires_in"`
+}
+
+// ErrNoUser is returned when the context does not carry the acting user
+var ErrNoUser = errors.New("no acting user")
+
+type userIDKey struct{}
+
+// WithUserID returns a copy of ctx carrying the id of the acting user
+func WithUserID(ctx context.Context, id uint64) context.Context {
+	return context.WithValue(ctx, userIDKey{}, id)
+}
+
+// UserID returns the id of the acting user carried by ctx
+func UserID(ctx context.Context) (uint64, error) {
+	id, ok := ctx.Value(userIDKey{}).(uint64)
+	if !ok || id == 0 {
+		return 0, ErrNoUser
+	}
+	return id, nil
+}
@@ -0,0 +1,31 @@
+package model_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mustan989/wallet/model"
+)
+
+func TestUserID(t *testing.T) {
+	subtests := [...]struct {
+		name   string
+		ctx    context.Context
+		expect uint64
+		err    error
+	}{
+		{"User", model.WithUserID(context.Background(), 7), 7, nil},
+		{"No user", context.Background(), 0, model.ErrNoUser},
+		{"Zero user", model.WithUserID(context.Background(), 0), 0, model.ErrNoUser},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			id, err := model.UserID(subtest.ctx)
+			require.Equal(t, subtest.expect, id)
+			require.Equal(t, subtest.err, err)
+		})
+	}
+}
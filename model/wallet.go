@@ -4,14 +4,17 @@ import "time"
 
 type Wallet struct {
 	ID          uint64     `json:"id"`
+	OwnerID     uint64     `json:"owner_id"`
 	Name        string     `json:"name"`
 	Description *string    `json:"description"`
 	Currency    string     `json:"currency"`
-	Amount      Decimal    `json:"amount"`
+	Amount      Money      `json:"amount"`
 	Personal    bool       `json:"personal"`
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
 	DeletedAt   *time.Time `json:"deleted_at"`
+	// Version increments on every write, updates are made against the version they were read at
+	Version uint64 `json:"version"`
 }
 
 func (w Wallet) Equals(wallet Wallet) bool {
@@ -23,10 +26,97 @@ func (w Wallet) Equals(wallet Wallet) bool {
 		(w.DeletedAt == nil && wallet.DeletedAt == nil || w.DeletedAt != nil && wallet.DeletedAt != nil && w.DeletedAt.Equal(*wallet.DeletedAt))
 }
 
+// WalletSort is a field wallets can be sorted by
+type WalletSort string
+
+const (
+	WalletSortID        WalletSort = "id"
+	WalletSortName      WalletSort = "name"
+	WalletSortCurrency  WalletSort = "currency"
+	WalletSortAmount    WalletSort = "amount"
+	WalletSortCreatedAt WalletSort = "created_at"
+	WalletSortUpdatedAt WalletSort = "updated_at"
+)
+
+func (s WalletSort) Valid() bool {
+	switch s {
+	case "", WalletSortID, WalletSortName, WalletSortCurrency, WalletSortAmount, WalletSortCreatedAt, WalletSortUpdatedAt:
+		return true
+	}
+	return false
+}
+
 type WalletFilter struct {
 	Filter
 	NameLike        string `query:"name_like"`
 	DescriptionLike string `query:"description_like"`
 	Currency        string `query:"currency"`
-	Personal        *bool  `query:"personal"`
+	// Currencies matches any of the codes, given as repeated parameters
+	Currencies  []string     `query:"currencies"`
+	Personal    *bool        `query:"personal"`
+	AmountMin   *Money       `query:"amount_min"`
+	AmountMax   *Money       `query:"amount_max"`
+	CreatedFrom *time.Time   `query:"created_from"`
+	CreatedTo   *time.Time   `query:"created_to"`
+	UpdatedFrom *time.Time   `query:"updated_from"`
+	UpdatedTo   *time.Time   `query:"updated_to"`
+	Deleted     DeletedState `query:"deleted"`
+	// Sort defaults to id descending, other fields default to ascending
+	Sort  WalletSort `query:"sort"`
+	Order SortOrder  `query:"order"`
+}
+
+// Ordering returns the sort the filter lists wallets in, id descending by default and ascending for other fields
+func (f *WalletFilter) Ordering() (WalletSort, SortOrder) {
+	sort, order := f.Sort, f.Order
+	if sort == "" || !sort.Valid() {
+		sort = WalletSortID
+	}
+	if order == "" {
+		order = Asc
+		if sort == WalletSortID {
+			order = Desc
+		}
+	}
+	return sort, order
+}
+
+func (f *WalletFilter) cursorSort() string {
+	sort, order := f.Ordering()
+	return string(sort) + " " + string(order)
+}
+
+// Next returns the cursor of the page following the last wallet
+func (f *WalletFilter) Next(last *Wallet) *Cursor {
+	var key any
+	switch sort, _ := f.Ordering(); sort {
+	case WalletSortName:
+		key = last.Name
+	case WalletSortCurrency:
+		key = last.Currency
+	case WalletSortAmount:
+		key = last.Amount
+	case WalletSortCreatedAt:
+		key = last.CreatedAt
+	case WalletSortUpdatedAt:
+		key = last.UpdatedAt
+	}
+	return NewCursor(f.cursorSort(), key, last.ID)
+}
+
+// After returns a pointer to the sort key of the filter cursor, it is nil when wallets are sorted by id
+func (f *WalletFilter) After() (any, error) {
+	var key any
+	switch sort, _ := f.Ordering(); sort {
+	case WalletSortName, WalletSortCurrency:
+		key = new(string)
+	case WalletSortAmount:
+		key = new(Money)
+	case WalletSortCreatedAt, WalletSortUpdatedAt:
+		key = new(time.Time)
+	}
+	if err := f.Cursor.Decode(f.cursorSort(), key); err != nil {
+		return nil, err
+	}
+	return key, nil
 }
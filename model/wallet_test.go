@@ -99,6 +99,6 @@ func TestWallet_Equals(t *testing.T) {
 func stringp(s string) *string     { return &s }
 func timep(t time.Time) *time.Time { return &t }
 
-func wallet(id uint64, name string, description *string, currency string, amount model.Decimal, personal bool, createdAt, updatedAt time.Time, deletedAt *time.Time) model.Wallet {
-	return model.Wallet{id, name, description, currency, amount, personal, createdAt, updatedAt, deletedAt}
+func wallet(id uint64, name string, description *string, currency string, amount int64, personal bool, createdAt, updatedAt time.Time, deletedAt *time.Time) model.Wallet {
+	return model.Wallet{id, 0, name, description, currency, model.Money{Amount: amount}, personal, createdAt, updatedAt, deletedAt, 1}
 }
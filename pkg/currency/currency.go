@@ -0,0 +1,116 @@
+package currency
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var (
+	ErrInvalid   = errors.New("invalid currency")
+	ErrDuplicate = errors.New("currency is already registered")
+	ErrUnknown   = errors.New("unknown currency")
+)
+
+// MaxMinorUnits is the largest number of minor digits among ISO 4217 currencies
+const MaxMinorUnits = 4
+
+// Currency is an ISO 4217 currency or a custom one, e.g. loyalty points
+type Currency struct {
+	Code string `json:"code"`
+	// Numeric is the three digit ISO 4217 code, custom currencies have none
+	Numeric    string `json:"numeric,omitempty"`
+	Name       string `json:"name"`
+	Symbol     string `json:"symbol"`
+	MinorUnits uint8  `json:"minor_units"`
+	Custom     bool   `json:"custom"`
+}
+
+func (c Currency) validate() error {
+	if len(c.Code) != 3 {
+		return fmt.Errorf("%w: code %q must be three upper case letters", ErrInvalid, c.Code)
+	}
+	for _, r := range c.Code {
+		if r < 'A' || 'Z' < r {
+			return fmt.Errorf("%w: code %q must be three upper case letters", ErrInvalid, c.Code)
+		}
+	}
+	if c.Name == "" {
+		return fmt.Errorf("%w: %s has no name", ErrInvalid, c.Code)
+	}
+	if c.MinorUnits > MaxMinorUnits {
+		return fmt.Errorf("%w: %s has more than %d minor digits", ErrInvalid, c.Code, MaxMinorUnits)
+	}
+	return nil
+}
+
+// Registry is a set of currencies safe for concurrent use
+type Registry struct {
+	mu         sync.RWMutex
+	currencies map[string]Currency
+}
+
+func NewRegistry(currencies ...Currency) (*Registry, error) {
+	r := &Registry{currencies: make(map[string]Currency, len(currencies))}
+	for _, c := range currencies {
+		if err := r.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Register adds the currency, codes cannot be redefined
+func (r *Registry) Register(c Currency) error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+	if c.Symbol == "" {
+		c.Symbol = c.Code
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.currencies[c.Code]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicate, c.Code)
+	}
+	r.currencies[c.Code] = c
+	return nil
+}
+
+func (r *Registry) Lookup(code string) (Currency, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c, ok := r.currencies[code]
+	if !ok {
+		return Currency{}, fmt.Errorf("%w: %q", ErrUnknown, code)
+	}
+	return c, nil
+}
+
+// All returns the currencies sorted by code
+func (r *Registry) All() []Currency {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	all := make([]Currency, 0, len(r.currencies))
+	for _, c := range r.currencies {
+		all = append(all, c)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Code < all[j].Code })
+	return all
+}
+
+var defaultRegistry = func() *Registry {
+	r, err := NewRegistry(iso4217...)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}()
+
+// Default is the registry of ISO 4217 currencies, custom currencies from the config are registered into it on start
+func Default() *Registry { return defaultRegistry }
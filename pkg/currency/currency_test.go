@@ -0,0 +1,56 @@
+package currency_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mustan989/wallet/pkg/currency"
+)
+
+func TestDefault(t *testing.T) {
+	usd, err := currency.Default().Lookup("USD")
+	require.NoError(t, err)
+	require.Equal(t, currency.Currency{Code: "USD", Numeric: "840", Name: "US Dollar", Symbol: "$", MinorUnits: 2}, usd)
+
+	kwd, err := currency.Default().Lookup("KWD")
+	require.NoError(t, err)
+	require.Equal(t, uint8(3), kwd.MinorUnits)
+
+	all := currency.Default().All()
+	require.Greater(t, len(all), 150)
+	for i := 1; i < len(all); i++ {
+		require.Less(t, all[i-1].Code, all[i].Code)
+	}
+}
+
+func TestRegistry_Register(t *testing.T) {
+	r, err := currency.NewRegistry(currency.Currency{Code: "USD", Numeric: "840", Name: "US Dollar", Symbol: "$", MinorUnits: 2})
+	require.NoError(t, err)
+
+	require.NoError(t, r.Register(currency.Currency{Code: "PTS", Name: "Loyalty points", Custom: true}))
+	points, err := r.Lookup("PTS")
+	require.NoError(t, err)
+	require.Equal(t, currency.Currency{Code: "PTS", Name: "Loyalty points", Symbol: "PTS", Custom: true}, points)
+
+	subtests := [...]struct {
+		name  string
+		input currency.Currency
+		err   error
+	}{
+		{"Duplicate", currency.Currency{Code: "USD", Name: "Dollar"}, currency.ErrDuplicate},
+		{"Lower case", currency.Currency{Code: "pts", Name: "Points"}, currency.ErrInvalid},
+		{"Long code", currency.Currency{Code: "POINT", Name: "Points"}, currency.ErrInvalid},
+		{"No name", currency.Currency{Code: "MLS"}, currency.ErrInvalid},
+		{"Minor units", currency.Currency{Code: "MLS", Name: "Miles", MinorUnits: 5}, currency.ErrInvalid},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			require.ErrorIs(t, r.Register(subtest.input), subtest.err)
+		})
+	}
+
+	_, err = r.Lookup("EUR")
+	require.ErrorIs(t, err, currency.ErrUnknown)
+}
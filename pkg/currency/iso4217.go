@@ -0,0 +1,163 @@
+package currency
+
+// iso4217 lists the active ISO 4217 currencies
+var iso4217 = []Currency{
+	{Code: "AED", Numeric: "784", Name: "UAE Dirham", Symbol: "د.إ", MinorUnits: 2},
+	{Code: "AFN", Numeric: "971", Name: "Afghani", Symbol: "؋", MinorUnits: 2},
+	{Code: "ALL", Numeric: "008", Name: "Lek", Symbol: "L", MinorUnits: 2},
+	{Code: "AMD", Numeric: "051", Name: "Armenian Dram", Symbol: "֏", MinorUnits: 2},
+	{Code: "ANG", Numeric: "532", Name: "Netherlands Antillean Guilder", Symbol: "ƒ", MinorUnits: 2},
+	{Code: "AOA", Numeric: "973", Name: "Kwanza", Symbol: "Kz", MinorUnits: 2},
+	{Code: "ARS", Numeric: "032", Name: "Argentine Peso", Symbol: "$", MinorUnits: 2},
+	{Code: "AUD", Numeric: "036", Name: "Australian Dollar", Symbol: "A$", MinorUnits: 2},
+	{Code: "AWG", Numeric: "533", Name: "Aruban Florin", Symbol: "ƒ", MinorUnits: 2},
+	{Code: "AZN", Numeric: "944", Name: "Azerbaijan Manat", Symbol: "₼", MinorUnits: 2},
+	{Code: "BAM", Numeric: "977", Name: "Convertible Mark", Symbol: "KM", MinorUnits: 2},
+	{Code: "BBD", Numeric: "052", Name: "Barbados Dollar", Symbol: "$", MinorUnits: 2},
+	{Code: "BDT", Numeric: "050", Name: "Taka", Symbol: "৳", MinorUnits: 2},
+	{Code: "BGN", Numeric: "975", Name: "Bulgarian Lev", Symbol: "лв", MinorUnits: 2},
+	{Code: "BHD", Numeric: "048", Name: "Bahraini Dinar", Symbol: ".د.ب", MinorUnits: 3},
+	{Code: "BIF", Numeric: "108", Name: "Burundi Franc", Symbol: "FBu", MinorUnits: 0},
+	{Code: "BMD", Numeric: "060", Name: "Bermudian Dollar", Symbol: "$", MinorUnits: 2},
+	{Code: "BND", Numeric: "096", Name: "Brunei Dollar", Symbol: "$", MinorUnits: 2},
+	{Code: "BOB", Numeric: "068", Name: "Boliviano", Symbol: "Bs", MinorUnits: 2},
+	{Code: "BRL", Numeric: "986", Name: "Brazilian Real", Symbol: "R$", MinorUnits: 2},
+	{Code: "BSD", Numeric: "044", Name: "Bahamian Dollar", Symbol: "$", MinorUnits: 2},
+	{Code: "BTN", Numeric: "064", Name: "Ngultrum", Symbol: "Nu.", MinorUnits: 2},
+	{Code: "BWP", Numeric: "072", Name: "Pula", Symbol: "P", MinorUnits: 2},
+	{Code: "BYN", Numeric: "933", Name: "Belarusian Ruble", Symbol: "Br", MinorUnits: 2},
+	{Code: "BZD", Numeric: "084", Name: "Belize Dollar", Symbol: "$", MinorUnits: 2},
+	{Code: "CAD", Numeric: "124", Name: "Canadian Dollar", Symbol: "CA$", MinorUnits: 2},
+	{Code: "CDF", Numeric: "976", Name: "Congolese Franc", Symbol: "FC", MinorUnits: 2},
+	{Code: "CHF", Numeric: "756", Name: "Swiss Franc", Symbol: "CHF", MinorUnits: 2},
+	{Code: "CLF", Numeric: "990", Name: "Unidad de Fomento", Symbol: "UF", MinorUnits: 4},
+	{Code: "CLP", Numeric: "152", Name: "Chilean Peso", Symbol: "$", MinorUnits: 0},
+	{Code: "CNY", Numeric: "156", Name: "Yuan Renminbi", Symbol: "¥", MinorUnits: 2},
+	{Code: "COP", Numeric: "170", Name: "Colombian Peso", Symbol: "$", MinorUnits: 2},
+	{Code: "CRC", Numeric: "188", Name: "Costa Rican Colon", Symbol: "₡", MinorUnits: 2},
+	{Code: "CUP", Numeric: "192", Name: "Cuban Peso", Symbol: "$", MinorUnits: 2},
+	{Code: "CVE", Numeric: "132", Name: "Cabo Verde Escudo", Symbol: "$", MinorUnits: 2},
+	{Code: "CZK", Numeric: "203", Name: "Czech Koruna", Symbol: "Kč", MinorUnits: 2},
+	{Code: "DJF", Numeric: "262", Name: "Djibouti Franc", Symbol: "Fdj", MinorUnits: 0},
+	{Code: "DKK", Numeric: "208", Name: "Danish Krone", Symbol: "kr", MinorUnits: 2},
+	{Code: "DOP", Numeric: "214", Name: "Dominican Peso", Symbol: "$", MinorUnits: 2},
+	{Code: "DZD", Numeric: "012", Name: "Algerian Dinar", Symbol: "د.ج", MinorUnits: 2},
+	{Code: "EGP", Numeric: "818", Name: "Egyptian Pound", Symbol: "E£", MinorUnits: 2},
+	{Code: "ERN", Numeric: "232", Name: "Nakfa", Symbol: "Nfk", MinorUnits: 2},
+	{Code: "ETB", Numeric: "230", Name: "Ethiopian Birr", Symbol: "Br", MinorUnits: 2},
+	{Code: "EUR", Numeric: "978", Name: "Euro", Symbol: "€", MinorUnits: 2},
+	{Code: "FJD", Numeric: "242", Name: "Fiji Dollar", Symbol: "$", MinorUnits: 2},
+	{Code: "FKP", Numeric: "238", Name: "Falkland Islands Pound", Symbol: "£", MinorUnits: 2},
+	{Code: "GBP", Numeric: "826", Name: "Pound Sterling", Symbol: "£", MinorUnits: 2},
+	{Code: "GEL", Numeric: "981", Name: "Lari", Symbol: "₾", MinorUnits: 2},
+	{Code: "GHS", Numeric: "936", Name: "Ghana Cedi", Symbol: "₵", MinorUnits: 2},
+	{Code: "GIP", Numeric: "292", Name: "Gibraltar Pound", Symbol: "£", MinorUnits: 2},
+	{Code: "GMD", Numeric: "270", Name: "Dalasi", Symbol: "D", MinorUnits: 2},
+	{Code: "GNF", Numeric: "324", Name: "Guinean Franc", Symbol: "FG", MinorUnits: 0},
+	{Code: "GTQ", Numeric: "320", Name: "Quetzal", Symbol: "Q", MinorUnits: 2},
+	{Code: "GYD", Numeric: "328", Name: "Guyana Dollar", Symbol: "$", MinorUnits: 2},
+	{Code: "HKD", Numeric: "344", Name: "Hong Kong Dollar", Symbol: "HK$", MinorUnits: 2},
+	{Code: "HNL", Numeric: "340", Name: "Lempira", Symbol: "L", MinorUnits: 2},
+	{Code: "HTG", Numeric: "332", Name: "Gourde", Symbol: "G", MinorUnits: 2},
+	{Code: "HUF", Numeric: "348", Name: "Forint", Symbol: "Ft", MinorUnits: 2},
+	{Code: "IDR", Numeric: "360", Name: "Rupiah", Symbol: "Rp", MinorUnits: 2},
+	{Code: "ILS", Numeric: "376", Name: "New Israeli Sheqel", Symbol: "₪", MinorUnits: 2},
+	{Code: "INR", Numeric: "356", Name: "Indian Rupee", Symbol: "₹", MinorUnits: 2},
+	{Code: "IQD", Numeric: "368", Name: "Iraqi Dinar", Symbol: "ع.د", MinorUnits: 3},
+	{Code: "IRR", Numeric: "364", Name: "Iranian Rial", Symbol: "﷼", MinorUnits: 2},
+	{Code: "ISK", Numeric: "352", Name: "Iceland Krona", Symbol: "kr", MinorUnits: 0},
+	{Code: "JMD", Numeric: "388", Name: "Jamaican Dollar", Symbol: "$", MinorUnits: 2},
+	{Code: "JOD", Numeric: "400", Name: "Jordanian Dinar", Symbol: "د.ا", MinorUnits: 3},
+	{Code: "JPY", Numeric: "392", Name: "Yen", Symbol: "¥", MinorUnits: 0},
+	{Code: "KES", Numeric: "404", Name: "Kenyan Shilling", Symbol: "KSh", MinorUnits: 2},
+	{Code: "KGS", Numeric: "417", Name: "Som", Symbol: "с", MinorUnits: 2},
+	{Code: "KHR", Numeric: "116", Name: "Riel", Symbol: "៛", MinorUnits: 2},
+	{Code: "KMF", Numeric: "174", Name: "Comorian Franc", Symbol: "CF", MinorUnits: 0},
+	{Code: "KPW", Numeric: "408", Name: "North Korean Won", Symbol: "₩", MinorUnits: 2},
+	{Code: "KRW", Numeric: "410", Name: "Won", Symbol: "₩", MinorUnits: 0},
+	{Code: "KWD", Numeric: "414", Name: "Kuwaiti Dinar", Symbol: "د.ك", MinorUnits: 3},
+	{Code: "KYD", Numeric: "136", Name: "Cayman Islands Dollar", Symbol: "$", MinorUnits: 2},
+	{Code: "KZT", Numeric: "398", Name: "Tenge", Symbol: "₸", MinorUnits: 2},
+	{Code: "LAK", Numeric: "418", Name: "Lao Kip", Symbol: "₭", MinorUnits: 2},
+	{Code: "LBP", Numeric: "422", Name: "Lebanese Pound", Symbol: "ل.ل", MinorUnits: 2},
+	{Code: "LKR", Numeric: "144", Name: "Sri Lanka Rupee", Symbol: "Rs", MinorUnits: 2},
+	{Code: "LRD", Numeric: "430", Name: "Liberian Dollar", Symbol: "$", MinorUnits: 2},
+	{Code: "LSL", Numeric: "426", Name: "Loti", Symbol: "L", MinorUnits: 2},
+	{Code: "LYD", Numeric: "434", Name: "Libyan Dinar", Symbol: "ل.د", MinorUnits: 3},
+	{Code: "MAD", Numeric: "504", Name: "Moroccan Dirham", Symbol: "د.م.", MinorUnits: 2},
+	{Code: "MDL", Numeric: "498", Name: "Moldovan Leu", Symbol: "L", MinorUnits: 2},
+	{Code: "MGA", Numeric: "969", Name: "Malagasy Ariary", Symbol: "Ar", MinorUnits: 2},
+	{Code: "MKD", Numeric: "807", Name: "Denar", Symbol: "ден", MinorUnits: 2},
+	{Code: "MMK", Numeric: "104", Name: "Kyat", Symbol: "K", MinorUnits: 2},
+	{Code: "MNT", Numeric: "496", Name: "Tugrik", Symbol: "₮", MinorUnits: 2},
+	{Code: "MOP", Numeric: "446", Name: "Pataca", Symbol: "MOP$", MinorUnits: 2},
+	{Code: "MRU", Numeric: "929", Name: "Ouguiya", Symbol: "UM", MinorUnits: 2},
+	{Code: "MUR", Numeric: "480", Name: "Mauritius Rupee", Symbol: "₨", MinorUnits: 2},
+	{Code: "MVR", Numeric: "462", Name: "Rufiyaa", Symbol: "Rf", MinorUnits: 2},
+	{Code: "MWK", Numeric: "454", Name: "Malawi Kwacha", Symbol: "MK", MinorUnits: 2},
+	{Code: "MXN", Numeric: "484", Name: "Mexican Peso", Symbol: "MX$", MinorUnits: 2},
+	{Code: "MYR", Numeric: "458", Name: "Malaysian Ringgit", Symbol: "RM", MinorUnits: 2},
+	{Code: "MZN", Numeric: "943", Name: "Mozambique Metical", Symbol: "MT", MinorUnits: 2},
+	{Code: "NAD", Numeric: "516", Name: "Namibia Dollar", Symbol: "$", MinorUnits: 2},
+	{Code: "NGN", Numeric: "566", Name: "Naira", Symbol: "₦", MinorUnits: 2},
+	{Code: "NIO", Numeric: "558", Name: "Cordoba Oro", Symbol: "C$", MinorUnits: 2},
+	{Code: "NOK", Numeric: "578", Name: "Norwegian Krone", Symbol: "kr", MinorUnits: 2},
+	{Code: "NPR", Numeric: "524", Name: "Nepalese Rupee", Symbol: "₨", MinorUnits: 2},
+	{Code: "NZD", Numeric: "554", Name: "New Zealand Dollar", Symbol: "NZ$", MinorUnits: 2},
+	{Code: "OMR", Numeric: "512", Name: "Rial Omani", Symbol: "ر.ع.", MinorUnits: 3},
+	{Code: "PAB", Numeric: "590", Name: "Balboa", Symbol: "B/.", MinorUnits: 2},
+	{Code: "PEN", Numeric: "604", Name: "Sol", Symbol: "S/", MinorUnits: 2},
+	{Code: "PGK", Numeric: "598", Name: "Kina", Symbol: "K", MinorUnits: 2},
+	{Code: "PHP", Numeric: "608", Name: "Philippine Peso", Symbol: "₱", MinorUnits: 2},
+	{Code: "PKR", Numeric: "586", Name: "Pakistan Rupee", Symbol: "₨", MinorUnits: 2},
+	{Code: "PLN", Numeric: "985", Name: "Zloty", Symbol: "zł", MinorUnits: 2},
+	{Code: "PYG", Numeric: "600", Name: "Guarani", Symbol: "₲", MinorUnits: 0},
+	{Code: "QAR", Numeric: "634", Name: "Qatari Rial", Symbol: "ر.ق", MinorUnits: 2},
+	{Code: "RON", Numeric: "946", Name: "Romanian Leu", Symbol: "lei", MinorUnits: 2},
+	{Code: "RSD", Numeric: "941", Name: "Serbian Dinar", Symbol: "дин.", MinorUnits: 2},
+	{Code: "RUB", Numeric: "643", Name: "Russian Ruble", Symbol: "₽", MinorUnits: 2},
+	{Code: "RWF", Numeric: "646", Name: "Rwanda Franc", Symbol: "FRw", MinorUnits: 0},
+	{Code: "SAR", Numeric: "682", Name: "Saudi Riyal", Symbol: "﷼", MinorUnits: 2},
+	{Code: "SBD", Numeric: "090", Name: "Solomon Islands Dollar", Symbol: "$", MinorUnits: 2},
+	{Code: "SCR", Numeric: "690", Name: "Seychelles Rupee", Symbol: "₨", MinorUnits: 2},
+	{Code: "SDG", Numeric: "938", Name: "Sudanese Pound", Symbol: "ج.س.", MinorUnits: 2},
+	{Code: "SEK", Numeric: "752", Name: "Swedish Krona", Symbol: "kr", MinorUnits: 2},
+	{Code: "SGD", Numeric: "702", Name: "Singapore Dollar", Symbol: "S$", MinorUnits: 2},
+	{Code: "SHP", Numeric: "654", Name: "Saint Helena Pound", Symbol: "£", MinorUnits: 2},
+	{Code: "SLE", Numeric: "925", Name: "Leone", Symbol: "Le", MinorUnits: 2},
+	{Code: "SOS", Numeric: "706", Name: "Somali Shilling", Symbol: "Sh", MinorUnits: 2},
+	{Code: "SRD", Numeric: "968", Name: "Surinam Dollar", Symbol: "$", MinorUnits: 2},
+	{Code: "SSP", Numeric: "728", Name: "South Sudanese Pound", Symbol: "£", MinorUnits: 2},
+	{Code: "STN", Numeric: "930", Name: "Dobra", Symbol: "Db", MinorUnits: 2},
+	{Code: "SVC", Numeric: "222", Name: "El Salvador Colon", Symbol: "₡", MinorUnits: 2},
+	{Code: "SYP", Numeric: "760", Name: "Syrian Pound", Symbol: "£S", MinorUnits: 2},
+	{Code: "SZL", Numeric: "748", Name: "Lilangeni", Symbol: "L", MinorUnits: 2},
+	{Code: "THB", Numeric: "764", Name: "Baht", Symbol: "฿", MinorUnits: 2},
+	{Code: "TJS", Numeric: "972", Name: "Somoni", Symbol: "SM", MinorUnits: 2},
+	{Code: "TMT", Numeric: "934", Name: "Turkmenistan New Manat", Symbol: "m", MinorUnits: 2},
+	{Code: "TND", Numeric: "788", Name: "Tunisian Dinar", Symbol: "د.ت", MinorUnits: 3},
+	{Code: "TOP", Numeric: "776", Name: "Pa'anga", Symbol: "T$", MinorUnits: 2},
+	{Code: "TRY", Numeric: "949", Name: "Turkish Lira", Symbol: "₺", MinorUnits: 2},
+	{Code: "TTD", Numeric: "780", Name: "Trinidad and Tobago Dollar", Symbol: "$", MinorUnits: 2},
+	{Code: "TWD", Numeric: "901", Name: "New Taiwan Dollar", Symbol: "NT$", MinorUnits: 2},
+	{Code: "TZS", Numeric: "834", Name: "Tanzanian Shilling", Symbol: "TSh", MinorUnits: 2},
+	{Code: "UAH", Numeric: "980", Name: "Hryvnia", Symbol: "₴", MinorUnits: 2},
+	{Code: "UGX", Numeric: "800", Name: "Uganda Shilling", Symbol: "USh", MinorUnits: 0},
+	{Code: "USD", Numeric: "840", Name: "US Dollar", Symbol: "$", MinorUnits: 2},
+	{Code: "UYI", Numeric: "940", Name: "Uruguay Peso en Unidades Indexadas", Symbol: "UYI", MinorUnits: 0},
+	{Code: "UYU", Numeric: "858", Name: "Peso Uruguayo", Symbol: "$U", MinorUnits: 2},
+	{Code: "UYW", Numeric: "927", Name: "Unidad Previsional", Symbol: "UYW", MinorUnits: 4},
+	{Code: "UZS", Numeric: "860", Name: "Uzbekistan Sum", Symbol: "сўм", MinorUnits: 2},
+	{Code: "VES", Numeric: "928", Name: "Bolívar Soberano", Symbol: "Bs.S", MinorUnits: 2},
+	{Code: "VND", Numeric: "704", Name: "Dong", Symbol: "₫", MinorUnits: 0},
+	{Code: "VUV", Numeric: "548", Name: "Vatu", Symbol: "VT", MinorUnits: 0},
+	{Code: "WST", Numeric: "882", Name: "Tala", Symbol: "WS$", MinorUnits: 2},
+	{Code: "XAF", Numeric: "950", Name: "CFA Franc BEAC", Symbol: "FCFA", MinorUnits: 0},
+	{Code: "XCD", Numeric: "951", Name: "East Caribbean Dollar", Symbol: "EC$", MinorUnits: 2},
+	{Code: "XOF", Numeric: "952", Name: "CFA Franc BCEAO", Symbol: "CFA", MinorUnits: 0},
+	{Code: "XPF", Numeric: "953", Name: "CFP Franc", Symbol: "₣", MinorUnits: 0},
+	{Code: "YER", Numeric: "886", Name: "Yemeni Rial", Symbol: "﷼", MinorUnits: 2},
+	{Code: "ZAR", Numeric: "710", Name: "Rand", Symbol: "R", MinorUnits: 2},
+	{Code: "ZMW", Numeric: "967", Name: "Zambian Kwacha", Symbol: "ZK", MinorUnits: 2},
+	{Code: "ZWL", Numeric: "932", Name: "Zimbabwe Dollar", Symbol: "Z$", MinorUnits: 2},
+}
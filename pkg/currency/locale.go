@@ -0,0 +1,189 @@
+package currency
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+var (
+	ErrUnknownLocale = errors.New("unknown locale")
+	ErrSyntax        = errors.New("invalid amount")
+)
+
+// NegativeStyle is how a locale marks negative amounts
+type NegativeStyle uint8
+
+const (
+	// MinusSign prefixes the amount with the symbol, e.g. -$12.00 or -12,00 €
+	MinusSign NegativeStyle = iota
+	// Parentheses wrap the amount as accountants do, e.g. ($12.00)
+	Parentheses
+)
+
+// Locale describes how amounts are written in a language and region
+type Locale struct {
+	Tag     string
+	Group   string
+	Decimal string
+	// SymbolFirst places the symbol before the number, SymbolSpace separates them with a space
+	SymbolFirst bool
+	SymbolSpace bool
+	Negative    NegativeStyle
+}
+
+// locales are known locales, the first one of a language is its default
+var locales = []Locale{
+	{Tag: "en-US", Group: ",", Decimal: ".", SymbolFirst: true},
+	{Tag: "en-GB", Group: ",", Decimal: ".", SymbolFirst: true},
+	{Tag: "ja-JP", Group: ",", Decimal: ".", SymbolFirst: true},
+	{Tag: "de-DE", Group: ".", Decimal: ",", SymbolSpace: true},
+	{Tag: "fr-FR", Group: " ", Decimal: ",", SymbolSpace: true},
+	{Tag: "ru-RU", Group: " ", Decimal: ",", SymbolSpace: true},
+	{Tag: "kk-KZ", Group: " ", Decimal: ",", SymbolSpace: true},
+}
+
+// LookupLocale returns the locale of the BCP 47 tag, e.g. en-US, falling back to the language only
+func LookupLocale(tag string) (Locale, error) {
+	for _, l := range locales {
+		if strings.EqualFold(l.Tag, tag) {
+			return l, nil
+		}
+	}
+	language, _, _ := strings.Cut(tag, "-")
+	for _, l := range locales {
+		if strings.HasPrefix(strings.ToLower(l.Tag), strings.ToLower(language)+"-") {
+			return l, nil
+		}
+	}
+	return Locale{}, fmt.Errorf("%w: %q", ErrUnknownLocale, tag)
+}
+
+// Format writes the amount in minor units of the currency, e.g. 123450 KZT in kk-KZ is "1 234,50 ₸"
+func (l Locale) Format(amount int64, c Currency) string {
+	digits := new(big.Int).Abs(big.NewInt(amount)).String()
+	exponent := int(c.MinorUnits)
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+	whole, frac := digits[:len(digits)-exponent], digits[len(digits)-exponent:]
+
+	var b strings.Builder
+	for i, r := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteString(l.Group)
+		}
+		b.WriteRune(r)
+	}
+	if frac != "" {
+		b.WriteString(l.Decimal)
+		b.WriteString(frac)
+	}
+
+	space := ""
+	if l.SymbolSpace {
+		space = " "
+	}
+	text := b.String() + space + c.Symbol
+	if l.SymbolFirst {
+		text = c.Symbol + space + b.String()
+	}
+
+	if amount >= 0 {
+		return text
+	}
+	if l.Negative == Parentheses {
+		return "(" + text + ")"
+	}
+	return "-" + text
+}
+
+// Parse reads the amount written in the locale into minor units of the currency.
+// The symbol and the code are optional, digits may be grouped by three, with non-breaking spaces as well
+func (l Locale) Parse(text string, c Currency) (int64, error) {
+	fail := func(reason string) (int64, error) { return 0, fmt.Errorf("%w %q: %s", ErrSyntax, text, reason) }
+
+	s := strings.TrimSpace(text)
+	negative := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		negative, s = true, s[1:len(s)-1]
+	}
+	if strings.HasPrefix(s, "-") {
+		if negative {
+			return fail("both parentheses and minus")
+		}
+		negative, s = true, s[1:]
+	}
+
+	for _, mark := range []string{c.Code, c.Symbol} {
+		if mark == "" {
+			continue
+		}
+		if trimmed := strings.TrimPrefix(s, mark); trimmed != s {
+			s = trimmed
+			break
+		}
+		if trimmed := strings.TrimSuffix(s, mark); trimmed != s {
+			s = trimmed
+			break
+		}
+	}
+	s = strings.TrimSpace(s)
+	// minus may also follow the symbol, e.g. $-12.00
+	if strings.HasPrefix(s, "-") && !negative {
+		negative, s = true, s[1:]
+	}
+
+	whole, frac := s, ""
+	if i := strings.LastIndex(s, l.Decimal); i >= 0 {
+		whole, frac = s[:i], s[i+len(l.Decimal):]
+	}
+	for _, space := range []string{" ", " ", " "} {
+		whole = strings.ReplaceAll(whole, space, l.Group)
+	}
+	whole, grouped := ungroup(whole, l.Group)
+	if !grouped {
+		return fail("digits must be grouped by three with " + l.Group)
+	}
+
+	if whole == "" || !isDigits(whole) || !isDigits(frac) {
+		return fail("must be digits with " + l.Decimal + " before the fraction")
+	}
+	if len(frac) > int(c.MinorUnits) {
+		return fail(fmt.Sprintf("%s has %d fraction digits", c.Code, c.MinorUnits))
+	}
+	frac += strings.Repeat("0", int(c.MinorUnits)-len(frac))
+
+	amount, _ := new(big.Int).SetString(whole+frac, 10)
+	if negative {
+		amount.Neg(amount)
+	}
+	if !amount.IsInt64() {
+		return fail("out of range")
+	}
+	return amount.Int64(), nil
+}
+
+// ungroup removes the group separators, which must split the digits by three, e.g. 1,234,567 but not 12,50
+func ungroup(s, separator string) (string, bool) {
+	groups := strings.Split(s, separator)
+	if len(groups) == 1 {
+		return s, true
+	}
+	for i, group := range groups {
+		if i == 0 && (len(group) == 0 || len(group) > 3) || i > 0 && len(group) != 3 {
+			return "", false
+		}
+	}
+	return strings.Join(groups, ""), true
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || '9' < r {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,115 @@
+package currency_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mustan989/wallet/pkg/currency"
+)
+
+func mustLookup(t *testing.T, code string) currency.Currency {
+	c, err := currency.Default().Lookup(code)
+	require.NoError(t, err)
+	return c
+}
+
+func mustLocale(t *testing.T, tag string) currency.Locale {
+	l, err := currency.LookupLocale(tag)
+	require.NoError(t, err)
+	return l
+}
+
+func TestLookupLocale(t *testing.T) {
+	require.Equal(t, "en-US", mustLocale(t, "en").Tag)
+	require.Equal(t, "kk-KZ", mustLocale(t, "KK-kz").Tag)
+
+	_, err := currency.LookupLocale("xx-YY")
+	require.ErrorIs(t, err, currency.ErrUnknownLocale)
+}
+
+func TestLocale_Format(t *testing.T) {
+	subtests := [...]struct {
+		name   string
+		locale string
+		code   string
+		amount int64
+		expect string
+	}{
+		{"Tenge", "kk-KZ", "KZT", 123450, "1 234,50 ₸"},
+		{"Dollar", "en-US", "USD", 123450, "$1,234.50"},
+		{"Negative euro", "en-US", "EUR", -1200, "-€12.00"},
+		{"Negative after number", "de-DE", "EUR", -123456789, "-1.234.567,89 €"},
+		{"Fraction only", "en-US", "USD", 5, "$0.05"},
+		{"Yen", "ja-JP", "JPY", 1234567, "¥1,234,567"},
+		{"Dinar", "en-US", "KWD", 1234, "د.ك1.234"},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			l := mustLocale(t, subtest.locale)
+			require.Equal(t, subtest.expect, l.Format(subtest.amount, mustLookup(t, subtest.code)))
+		})
+	}
+
+	accounting := mustLocale(t, "en-US")
+	accounting.Negative = currency.Parentheses
+	require.Equal(t, "($12.00)", accounting.Format(-1200, mustLookup(t, "USD")))
+}
+
+func TestLocale_Parse(t *testing.T) {
+	subtests := [...]struct {
+		name   string
+		locale string
+		code   string
+		input  string
+		expect int64
+	}{
+		{"Tenge", "kk-KZ", "KZT", "1 234,50 ₸", 123450},
+		{"Non-breaking spaces", "kk-KZ", "KZT", "1 234,5 ₸", 123450},
+		{"Dollar", "en-US", "USD", "$1,234.50", 123450},
+		{"Code", "en-US", "USD", "1,234.50 USD", 123450},
+		{"No symbol", "en-US", "USD", "1234", 123400},
+		{"Negative euro", "en-US", "EUR", "-€12.00", -1200},
+		{"Minus after symbol", "en-US", "EUR", "€-12", -1200},
+		{"Parentheses", "en-US", "USD", "($12.00)", -1200},
+		{"German", "de-DE", "EUR", "-1.234.567,89 €", -123456789},
+		{"Yen", "ja-JP", "JPY", "¥1,234,567", 1234567},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			amount, err := mustLocale(t, subtest.locale).Parse(subtest.input, mustLookup(t, subtest.code))
+			require.NoError(t, err)
+			require.Equal(t, subtest.expect, amount)
+		})
+	}
+}
+
+func TestLocale_ParseError(t *testing.T) {
+	subtests := [...]struct {
+		name   string
+		locale string
+		code   string
+		input  string
+	}{
+		{"Empty", "en-US", "USD", ""},
+		{"Letters", "en-US", "USD", "$12a"},
+		{"Other symbol", "en-US", "USD", "€12"},
+		{"Yen cents", "ja-JP", "JPY", "¥12.5"},
+		{"Too many digits", "en-US", "USD", "$12.505"},
+		{"Wrong decimal separator", "de-DE", "EUR", "12,50.00 €"},
+		{"Two minus signs", "en-US", "USD", "(-$12)"},
+		{"Point as German decimal separator", "de-DE", "EUR", "12.50"},
+		{"Comma as English decimal separator", "en-US", "USD", "1,5"},
+		{"Short group", "en-US", "USD", "$1,23,456.00"},
+		{"Empty group", "fr-FR", "EUR", "1  234,00 €"},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			_, err := mustLocale(t, subtest.locale).Parse(subtest.input, mustLookup(t, subtest.code))
+			require.ErrorIs(t, err, currency.ErrSyntax)
+		})
+	}
+}
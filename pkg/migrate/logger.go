@@ -0,0 +1,15 @@
+package migrate
+
+import "log"
+
+type Logger interface {
+	Infof(format string, a ...any)
+}
+
+var defaultLogger Logger = &logger{}
+
+type logger struct{}
+
+func (l *logger) Infof(format string, a ...any) {
+	log.Printf("INFO: "+format+"\n", a...)
+}
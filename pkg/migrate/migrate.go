@@ -0,0 +1,301 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrBehind         = errors.New("database schema is behind")
+	ErrUnknownVersion = errors.New("unknown migration version")
+	ErrIrreversible   = errors.New("migration has no down file")
+	ErrMissing        = errors.New("applied migration is missing")
+)
+
+// Migration is a pair of <version>_<name>.up.sql and <version>_<name>.down.sql files
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      string
+	Down    string
+}
+
+var fileName = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// Load reads the migrations from the root of fsys sorted by version
+func Load(fsys fs.FS) ([]*Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[uint64]*Migration{}
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("%s: version %d is already used by %s", entry.Name(), version, m.Name)
+		}
+
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		if match[3] == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", m.Version, m.Name)
+		}
+		migrations = append(migrations, m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// Status of a migration, migrations applied to the database but unknown to the binary are Missing
+type Status struct {
+	Version   uint64     `json:"version"`
+	Name      string     `json:"name"`
+	AppliedAt *time.Time `json:"applied_at"`
+	Missing   bool       `json:"missing"`
+}
+
+const table = "schema_migrations"
+
+// database is what the migrator needs from the database it migrates
+type database interface {
+	// lock makes other migrators of the database wait until unlock
+	lock(ctx context.Context) error
+	unlock(ctx context.Context) error
+	// createTable creates the table of the applied migrations if it does not exist
+	createTable(ctx context.Context) error
+	// applied returns the versions recorded in the database, none if the table does not exist yet
+	applied(ctx context.Context) (map[uint64]time.Time, error)
+	// apply runs the migration sql, if any, and the record statement in one transaction
+	apply(ctx context.Context, sql, record string, args ...any) error
+}
+
+// Migrator applies migrations and tracks their versions in the schema_migrations table
+type Migrator struct {
+	db         database
+	migrations []*Migration
+	logger     Logger
+}
+
+func newMigrator(db database, migrations []*Migration, opts ...Option) *Migrator {
+	m := &Migrator{
+		db:         db,
+		migrations: migrations,
+		logger:     defaultLogger,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Up applies all pending migrations
+func (m *Migrator) Up(ctx context.Context) error {
+	if len(m.migrations) == 0 {
+		return nil
+	}
+	return m.To(ctx, m.migrations[len(m.migrations)-1].Version)
+}
+
+// Down rolls back the last applied migration
+func (m *Migrator) Down(ctx context.Context) error {
+	return m.locked(ctx, func(applied map[uint64]time.Time) error {
+		var last uint64
+		for version := range applied {
+			if version > last {
+				last = version
+			}
+		}
+		if last == 0 {
+			return nil
+		}
+
+		var previous uint64
+		for version := range applied {
+			if version < last && version > previous {
+				previous = version
+			}
+		}
+
+		return m.to(ctx, applied, previous)
+	})
+}
+
+// To applies the migrations up to the version and rolls back the ones after it, zero rolls back everything
+func (m *Migrator) To(ctx context.Context, version uint64) error {
+	if version != 0 && m.find(version) == nil {
+		return fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+
+	return m.locked(ctx, func(applied map[uint64]time.Time) error {
+		return m.to(ctx, applied, version)
+	})
+}
+
+// Baseline records the migrations up to the version as applied without running them,
+// for databases whose schema was created before the migrator tracked it
+func (m *Migrator) Baseline(ctx context.Context, version uint64) error {
+	if m.find(version) == nil {
+		return fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+
+	return m.locked(ctx, func(applied map[uint64]time.Time) error {
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok || migration.Version > version {
+				continue
+			}
+
+			m.logger.Infof("Recording migration %d_%s as applied", migration.Version, migration.Name)
+			if err := m.db.apply(ctx, "", "INSERT INTO "+table+" (version, name) VALUES ($1, $2)", migration.Version, migration.Name); err != nil {
+				return fmt.Errorf("record %d_%s: %w", migration.Version, migration.Name, err)
+			}
+		}
+		return nil
+	})
+}
+
+// Status lists the known and the applied migrations sorted by version
+func (m *Migrator) Status(ctx context.Context) ([]*Status, error) {
+	applied, err := m.db.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]*Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := &Status{Version: migration.Version, Name: migration.Name}
+		if at, ok := applied[migration.Version]; ok {
+			status.AppliedAt = &at
+		}
+		statuses = append(statuses, status)
+	}
+	for version, at := range applied {
+		if m.find(version) == nil {
+			at := at
+			statuses = append(statuses, &Status{Version: version, AppliedAt: &at, Missing: true})
+		}
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+
+	return statuses, nil
+}
+
+// Check returns ErrBehind if any known migration is not applied
+func (m *Migrator) Check(ctx context.Context) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	pending := 0
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			pending++
+		}
+	}
+	if pending > 0 {
+		return fmt.Errorf("%w: %d pending migrations", ErrBehind, pending)
+	}
+	return nil
+}
+
+func (m *Migrator) to(ctx context.Context, applied map[uint64]time.Time, version uint64) error {
+	for v := range applied {
+		if v > version && m.find(v) == nil {
+			return fmt.Errorf("%w: %d", ErrMissing, v)
+		}
+	}
+
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok || migration.Version <= version {
+			continue
+		}
+		if migration.Down == "" {
+			return fmt.Errorf("%w: %d_%s", ErrIrreversible, migration.Version, migration.Name)
+		}
+
+		m.logger.Infof("Rolling back migration %d_%s", migration.Version, migration.Name)
+		if err := m.db.apply(ctx, migration.Down, "DELETE FROM "+table+" WHERE version = $1", migration.Version); err != nil {
+			return fmt.Errorf("roll back %d_%s: %w", migration.Version, migration.Name, err)
+		}
+	}
+
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok || migration.Version > version {
+			continue
+		}
+
+		m.logger.Infof("Applying migration %d_%s", migration.Version, migration.Name)
+		if err := m.db.apply(ctx, migration.Up, "INSERT INTO "+table+" (version, name) VALUES ($1, $2)", migration.Version, migration.Name); err != nil {
+			return fmt.Errorf("apply %d_%s: %w", migration.Version, migration.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// locked runs fn holding the lock, so concurrent instances migrate one after another
+func (m *Migrator) locked(ctx context.Context, fn func(applied map[uint64]time.Time) error) (err error) {
+	if err = m.db.lock(ctx); err != nil {
+		return err
+	}
+	defer func() {
+		// the context may be already canceled, the lock must be released anyway
+		if unlockErr := m.db.unlock(context.Background()); unlockErr != nil && err == nil {
+			err = unlockErr
+		}
+	}()
+
+	if err = m.db.createTable(ctx); err != nil {
+		return err
+	}
+
+	applied, err := m.db.applied(ctx)
+	if err != nil {
+		return err
+	}
+
+	return fn(applied)
+}
+
+func (m *Migrator) find(version uint64) *Migration {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration
+		}
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package migrate_test
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mustan989/wallet/pkg/migrate"
+)
+
+var migrations = []*migrate.Migration{
+	{Version: 1, Name: "create_a", Up: "create table a ();", Down: "drop table a;"},
+	{Version: 2, Name: "create_b", Up: "create table b ();", Down: "drop table b;"},
+}
+
+type discard struct{}
+
+func (discard) Infof(string, ...any) {}
+
+func newMigrator(t *testing.T) (*migrate.Migrator, pgxmock.PgxConnIface) {
+	conn, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, conn.ExpectationsWereMet()) })
+	return migrate.New(conn, migrations, migrate.WithLogger(discard{})), conn
+}
+
+func expectLocked(conn pgxmock.PgxConnIface, applied ...uint64) {
+	conn.ExpectExec("SELECT pg_advisory_lock").WillReturnResult(pgxmock.NewResult("SELECT", 1))
+	conn.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(pgxmock.NewResult("CREATE", 0))
+	expectApplied(conn, applied...)
+}
+
+func expectApplied(conn pgxmock.PgxConnIface, applied ...uint64) {
+	rows := pgxmock.NewRows([]string{"version", "applied_at"})
+	for _, version := range applied {
+		rows.AddRow(version, time.Now())
+	}
+	conn.ExpectQuery("SELECT version, applied_at FROM schema_migrations").WillReturnRows(rows)
+}
+
+func expectUnlock(conn pgxmock.PgxConnIface) {
+	conn.ExpectExec("SELECT pg_advisory_unlock").WillReturnResult(pgxmock.NewResult("SELECT", 1))
+}
+
+func TestLoad(t *testing.T) {
+	loaded, err := migrate.Load(fstest.MapFS{
+		"2_create_b.up.sql":   {Data: []byte("create table b ();")},
+		"2_create_b.down.sql": {Data: []byte("drop table b;")},
+		"1_create_a.up.sql":   {Data: []byte("create table a ();")},
+		"1_create_a.down.sql": {Data: []byte("drop table a;")},
+		"migrations.go":       {Data: []byte("package migrations")},
+	})
+	require.NoError(t, err)
+	require.Equal(t, migrations, loaded)
+}
+
+func TestLoadError(t *testing.T) {
+	subtests := [...]struct {
+		name string
+		fsys fstest.MapFS
+	}{
+		{"No up", fstest.MapFS{"1_create_a.down.sql": {Data: []byte("drop table a;")}}},
+		{"Same version", fstest.MapFS{
+			"1_create_a.up.sql": {Data: []byte("create table a ();")},
+			"1_create_b.up.sql": {Data: []byte("create table b ();")},
+		}},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			loaded, err := migrate.Load(subtest.fsys)
+			require.Zero(t, loaded)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestMigrator_Up(t *testing.T) {
+	m, conn := newMigrator(t)
+
+	expectLocked(conn, 1)
+	conn.ExpectBegin()
+	conn.ExpectExec("create table b").WillReturnResult(pgxmock.NewResult("CREATE", 0))
+	conn.ExpectExec("INSERT INTO schema_migrations").WithArgs(uint64(2), "create_b").WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	conn.ExpectCommit()
+	expectUnlock(conn)
+
+	require.NoError(t, m.Up(context.Background()))
+}
+
+func TestMigrator_UpError(t *testing.T) {
+	m, conn := newMigrator(t)
+
+	expectLocked(conn)
+	conn.ExpectBegin()
+	conn.ExpectExec("create table a").WillReturnError(&pgconn.PgError{Code: pgerrcode.SyntaxError})
+	conn.ExpectRollback()
+	expectUnlock(conn)
+
+	require.Error(t, m.Up(context.Background()))
+}
+
+func TestMigrator_Down(t *testing.T) {
+	m, conn := newMigrator(t)
+
+	expectLocked(conn, 1, 2)
+	conn.ExpectBegin()
+	conn.ExpectExec("drop table b").WillReturnResult(pgxmock.NewResult("DROP", 0))
+	conn.ExpectExec("DELETE FROM schema_migrations").WithArgs(uint64(2)).WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	conn.ExpectCommit()
+	expectUnlock(conn)
+
+	require.NoError(t, m.Down(context.Background()))
+}
+
+func TestMigrator_To(t *testing.T) {
+	t.Run("Unknown version", func(t *testing.T) {
+		m, _ := newMigrator(t)
+		require.ErrorIs(t, m.To(context.Background(), 3), migrate.ErrUnknownVersion)
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		m, conn := newMigrator(t)
+
+		expectLocked(conn, 1, 2, 3)
+		expectUnlock(conn)
+
+		require.ErrorIs(t, m.To(context.Background(), 1), migrate.ErrMissing)
+	})
+}
+
+func TestMigrator_Baseline(t *testing.T) {
+	t.Run("Recorded", func(t *testing.T) {
+		m, conn := newMigrator(t)
+
+		// only the record is written, create table a is not run
+		expectLocked(conn)
+		conn.ExpectBegin()
+		conn.ExpectExec("INSERT INTO schema_migrations").WithArgs(uint64(1), "create_a").WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		conn.ExpectCommit()
+		expectUnlock(conn)
+
+		require.NoError(t, m.Baseline(context.Background(), 1))
+	})
+
+	t.Run("Already applied", func(t *testing.T) {
+		m, conn := newMigrator(t)
+
+		expectLocked(conn, 1)
+		conn.ExpectBegin()
+		conn.ExpectExec("INSERT INTO schema_migrations").WithArgs(uint64(2), "create_b").WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		conn.ExpectCommit()
+		expectUnlock(conn)
+
+		require.NoError(t, m.Baseline(context.Background(), 2))
+	})
+
+	t.Run("Unknown version", func(t *testing.T) {
+		m, _ := newMigrator(t)
+		require.ErrorIs(t, m.Baseline(context.Background(), 3), migrate.ErrUnknownVersion)
+	})
+}
+
+func TestMigrator_Check(t *testing.T) {
+	t.Run("Behind", func(t *testing.T) {
+		m, conn := newMigrator(t)
+		expectApplied(conn, 1)
+		require.ErrorIs(t, m.Check(context.Background()), migrate.ErrBehind)
+	})
+
+	t.Run("No table", func(t *testing.T) {
+		m, conn := newMigrator(t)
+		conn.ExpectQuery("SELECT version").WillReturnError(&pgconn.PgError{Code: pgerrcode.UndefinedTable})
+		require.ErrorIs(t, m.Check(context.Background()), migrate.ErrBehind)
+	})
+
+	t.Run("Up to date", func(t *testing.T) {
+		m, conn := newMigrator(t)
+		expectApplied(conn, 1, 2)
+		require.NoError(t, m.Check(context.Background()))
+	})
+}
+
+func TestMigrator_Status(t *testing.T) {
+	m, conn := newMigrator(t)
+
+	expectApplied(conn, 1, 3)
+
+	statuses, err := m.Status(context.Background())
+	require.NoError(t, err)
+	require.Len(t, statuses, 3)
+	require.NotNil(t, statuses[0].AppliedAt)
+	require.Equal(t, "create_b", statuses[1].Name)
+	require.Nil(t, statuses[1].AppliedAt)
+	require.True(t, statuses[2].Missing)
+}
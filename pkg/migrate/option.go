@@ -0,0 +1,5 @@
+package migrate
+
+type Option func(m *Migrator)
+
+func WithLogger(logger Logger) Option { return func(m *Migrator) { m.logger = logger } }
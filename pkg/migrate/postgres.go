@@ -0,0 +1,99 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Conn is a single database connection, e.g. acquired from a pool.
+// The advisory lock is held by the session, so every call must go through the same connection
+type Conn interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// New returns the migrator of the postgres database behind conn
+func New(conn Conn, migrations []*Migration, opts ...Option) *Migrator {
+	return newMigrator(&postgres{conn}, migrations, opts...)
+}
+
+// lock is the key of the advisory lock held while migrating
+const lock = "hashtext('" + table + "')"
+
+type postgres struct{ conn Conn }
+
+func (p *postgres) lock(ctx context.Context) error {
+	_, err := p.conn.Exec(ctx, "SELECT pg_advisory_lock("+lock+")")
+	return err
+}
+
+func (p *postgres) unlock(ctx context.Context) error {
+	_, err := p.conn.Exec(ctx, "SELECT pg_advisory_unlock("+lock+")")
+	return err
+}
+
+func (p *postgres) createTable(ctx context.Context) error {
+	_, err := p.conn.Exec(ctx, "CREATE TABLE IF NOT EXISTS "+table+
+		" (version bigint PRIMARY KEY, name varchar(255) NOT NULL, applied_at timestamptz NOT NULL DEFAULT now())",
+	)
+	return err
+}
+
+func (p *postgres) applied(ctx context.Context) (map[uint64]time.Time, error) {
+	rows, err := p.conn.Query(ctx, "SELECT version, applied_at FROM "+table)
+	if undefinedTable(err) {
+		return map[uint64]time.Time{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[uint64]time.Time{}
+	for rows.Next() {
+		var (
+			version uint64
+			at      time.Time
+		)
+		if err = rows.Scan(&version, &at); err != nil {
+			return nil, err
+		}
+		applied[version] = at
+	}
+
+	if err = rows.Err(); undefinedTable(err) {
+		return map[uint64]time.Time{}, nil
+	}
+
+	return applied, err
+}
+
+func (p *postgres) apply(ctx context.Context, sql, record string, args ...any) error {
+	tx, err := p.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if sql != "" {
+		if _, err = tx.Exec(ctx, sql); err != nil {
+			return err
+		}
+	}
+	if _, err = tx.Exec(ctx, record, args...); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func undefinedTable(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UndefinedTable
+}
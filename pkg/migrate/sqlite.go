@@ -0,0 +1,73 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// NewSQLite returns the migrator of the sqlite database.
+// SQLite has no advisory locks, a migration applied twice fails on its record and is rolled back as a whole
+func NewSQLite(db *sql.DB, migrations []*Migration, opts ...Option) *Migrator {
+	return newMigrator(&sqlite{db}, migrations, opts...)
+}
+
+type sqlite struct{ db *sql.DB }
+
+func (s *sqlite) lock(context.Context) error   { return nil }
+func (s *sqlite) unlock(context.Context) error { return nil }
+
+func (s *sqlite) createTable(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS "+table+
+		" (version integer PRIMARY KEY, name varchar(255) NOT NULL, applied_at datetime NOT NULL DEFAULT CURRENT_TIMESTAMP)",
+	)
+	return err
+}
+
+func (s *sqlite) applied(ctx context.Context) (map[uint64]time.Time, error) {
+	var exists bool
+	if err := s.db.QueryRowContext(ctx,
+		"SELECT EXISTS (SELECT 1 FROM sqlite_master WHERE type = 'table' AND name = '"+table+"')",
+	).Scan(&exists); err != nil || !exists {
+		return map[uint64]time.Time{}, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, "SELECT version, applied_at FROM "+table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[uint64]time.Time{}
+	for rows.Next() {
+		var (
+			version uint64
+			at      time.Time
+		)
+		if err = rows.Scan(&version, &at); err != nil {
+			return nil, err
+		}
+		applied[version] = at
+	}
+
+	return applied, rows.Err()
+}
+
+func (s *sqlite) apply(ctx context.Context, sql, record string, args ...any) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if sql != "" {
+		if _, err = tx.ExecContext(ctx, sql); err != nil {
+			return err
+		}
+	}
+	if _, err = tx.ExecContext(ctx, record, args...); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
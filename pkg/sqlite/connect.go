@@ -0,0 +1,34 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"net/url"
+
+	// registers the pure go "sqlite" driver, so no C toolchain is needed to cross compile
+	_ "modernc.org/sqlite"
+
+	"github.com/mustan989/wallet/app/config"
+)
+
+// Connect opens the database file at database.Name, ":memory:" keeps the database in process.
+// SQLite allows one writer at a time, so the database is used through a single connection
+func Connect(database *config.Database) (*sql.DB, error) {
+	if database.Name == "" {
+		return nil, errors.New("database name is empty, it is the path of the database file")
+	}
+
+	params := url.Values{}
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", "busy_timeout(5000)")
+	// LIKE is case sensitive in postgres
+	params.Add("_pragma", "case_sensitive_like(1)")
+
+	db, err := sql.Open("sqlite", "file:"+database.Name+"?"+params.Encode())
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+
+	return db, nil
+}
@@ -0,0 +1,109 @@
+package token
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrMalformed = errors.New("malformed token")
+	ErrSignature = errors.New("invalid token signature")
+	ErrExpired   = errors.New("token is expired")
+)
+
+// Claims of the token, see RFC 7519
+type Claims struct {
+	ID        string `json:"jti"`
+	Subject   string `json:"sub"`
+	Type      string `json:"typ"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// header is the only header the Signer produces and accepts
+const header = `{"alg":"HS256","typ":"JWT"}`
+
+var encoding = base64.RawURLEncoding
+
+type Option func(s *Signer)
+
+// WithClock replaces time.Now used to check expiration
+func WithClock(now func() time.Time) Option { return func(s *Signer) { s.now = now } }
+
+// Signer signs and verifies JSON Web Tokens with HMAC SHA-256
+type Signer struct {
+	secret []byte
+	now    func() time.Time
+}
+
+func NewSigner(secret []byte, options ...Option) *Signer {
+	s := &Signer{
+		secret: secret,
+		now:    time.Now,
+	}
+
+	for _, option := range options {
+		option(s)
+	}
+
+	return s
+}
+
+func (s *Signer) Sign(claims *Claims) (string, error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := encoding.EncodeToString([]byte(header)) + "." + encoding.EncodeToString(payload)
+
+	return unsigned + "." + encoding.EncodeToString(s.signature(unsigned)), nil
+}
+
+// Parse verifies the signature and expiration of the token and returns its claims
+func (s *Signer) Parse(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformed
+	}
+
+	rawHeader, err := encoding.DecodeString(parts[0])
+	if err != nil || string(rawHeader) != header {
+		return nil, ErrMalformed
+	}
+
+	signature, err := encoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	if !hmac.Equal(signature, s.signature(parts[0]+"."+parts[1])) {
+		return nil, ErrSignature
+	}
+
+	payload, err := encoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+
+	claims := &Claims{}
+	if err = json.Unmarshal(payload, claims); err != nil {
+		return nil, ErrMalformed
+	}
+
+	if !s.now().Before(time.Unix(claims.ExpiresAt, 0)) {
+		return nil, ErrExpired
+	}
+
+	return claims, nil
+}
+
+func (s *Signer) signature(unsigned string) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(unsigned))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,48 @@
+package token_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mustan989/wallet/pkg/token"
+)
+
+func TestSigner(t *testing.T) {
+	now := time.Date(1999, 2, 23, 4, 36, 0, 0, time.UTC)
+	clock := token.WithClock(func() time.Time { return now })
+
+	signer := token.NewSigner([]byte("secret"), clock)
+	claims := &token.Claims{ID: "id", Subject: "1", Type: "access", IssuedAt: now.Unix(), ExpiresAt: now.Add(time.Minute).Unix()}
+
+	signed, err := signer.Sign(claims)
+	require.NoError(t, err)
+
+	parts := strings.Split(signed, ".")
+
+	subtests := [...]struct {
+		name   string
+		signer *token.Signer
+		token  string
+		err    error
+	}{
+		{"Valid", signer, signed, nil},
+		{"Other secret", token.NewSigner([]byte("other"), clock), signed, token.ErrSignature},
+		{"Tampered payload", signer, parts[0] + "." + parts[0] + "." + parts[2], token.ErrSignature},
+		{"Other header", signer, "eyJhbGciOiJub25lIn0." + parts[1] + "." + parts[2], token.ErrMalformed},
+		{"Two parts", signer, parts[0] + "." + parts[1], token.ErrMalformed},
+		{"Expired", token.NewSigner([]byte("secret"), token.WithClock(func() time.Time { return now.Add(time.Minute) })), signed, token.ErrExpired},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			parsed, err := subtest.signer.Parse(subtest.token)
+			require.Equal(t, subtest.err, err)
+			if subtest.err == nil {
+				require.Equal(t, claims, parsed)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/mustan989/wallet/model"
+)
+
+var (
+	ErrCategoryNotFound = errors.New("category not found")
+	ErrCategoryConflict = errors.New("category already exists")
+	ErrCategoryInUse    = errors.New("category is used by transactions, reassign them or archive the category")
+)
+
+// Category repository interface, every user has own categories
+type Category interface {
+	CountAll(ctx context.Context, filter *model.CategoryFilter) (count uint64, err error)
+	FindAll(ctx context.Context, filter *model.CategoryFilter) (data []*model.Category, err error)
+	FindByID(ctx context.Context, id uint64) (data *model.Category, err error)
+	Create(ctx context.Context, data *model.Category) error
+	// Update keeps the archive time of the category, it only changes through Archive
+	Update(ctx context.Context, data *model.Category) error
+	// Archive marks the category archived at the time, archived categories take no new transactions
+	Archive(ctx context.Context, id uint64, at time.Time) (archived *model.Category, err error)
+	// Used reports whether the category has children or transactions
+	Used(ctx context.Context, id uint64) (used bool, err error)
+	// DeleteByID moves the transactions of the category to reassignTo, if set, and its children to its parent.
+	// Returns ErrCategoryInUse if the category still has transactions
+	DeleteByID(ctx context.Context, id uint64, reassignTo *uint64) (deleted *model.Category, err error)
+}
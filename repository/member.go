@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mustan989/wallet/model"
+)
+
+var ErrMemberNotFound = errors.New("member not found")
+
+// Member repository interface.
+// Only members of the wallets visible to the acting user are visible, roles are not enforced
+type Member interface {
+	FindAll(ctx context.Context, walletID uint64) (data []*model.Member, err error)
+	// FindRole returns the role of the acting user in the wallet, ErrWalletNotFound if the wallet is not visible to them
+	FindRole(ctx context.Context, walletID uint64) (role model.Role, err error)
+	// Save adds the member to the wallet or changes its role
+	Save(ctx context.Context, data *model.Member) error
+	DeleteByID(ctx context.Context, walletID, userID uint64) (deleted *model.Member, err error)
+}
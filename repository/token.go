@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// Token repository interface, stores ids of the tokens revoked before their expiration
+type Token interface {
+	// Revoke returns false if the token has already been revoked
+	Revoke(ctx context.Context, id string, expiresAt time.Time) (revoked bool, err error)
+	IsRevoked(ctx context.Context, id string) (revoked bool, err error)
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mustan989/wallet/model"
+)
+
+var (
+	ErrTransactionNotFound = errors.New("transaction not found")
+	ErrTransactionConflict = errors.New("transaction already exists")
+	ErrTransactionLinked   = errors.New("transaction is managed by a transfer")
+)
+
+// Transaction repository interface.
+// Create, Update and DeleteByID keep the amount of the affected wallets in sync with the transaction.
+// Only transactions of the wallets accessible to the acting user are visible, roles are enforced by the service
+type Transaction interface {
+	CountAll(ctx context.Context, filter *model.TransactionFilter) (count uint64, err error)
+	FindAll(ctx context.Context, filter *model.TransactionFilter) (data []*model.Transaction, err error)
+	FindByID(ctx context.Context, id uint64) (data *model.Transaction, err error)
+	Create(ctx context.Context, data *model.Transaction) error
+	Update(ctx context.Context, data *model.Transaction) error
+	DeleteByID(ctx context.Context, id uint64) (deleted *model.Transaction, err error)
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mustan989/wallet/model"
+)
+
+var (
+	ErrTransferNotFound = errors.New("transfer not found")
+	ErrTransferConflict = errors.New("transfer already exists")
+)
+
+// Transfer repository interface.
+// Create, Update and DeleteByID move the amounts between the wallets and record the commission in one transaction.
+// Only transfers from or to the wallets accessible to the acting user are visible, roles are enforced by the service
+type Transfer interface {
+	CountAll(ctx context.Context, filter *model.TransferFilter) (count uint64, err error)
+	FindAll(ctx context.Context, filter *model.TransferFilter) (data []*model.Transfer, err error)
+	FindByID(ctx context.Context, id uint64) (data *model.Transfer, err error)
+	Create(ctx context.Context, data *model.Transfer) error
+	Update(ctx context.Context, data *model.Transfer) error
+	DeleteByID(ctx context.Context, id uint64) (deleted *model.Transfer, err error)
+}
@@ -0,0 +1,10 @@
+package repository
+
+import "context"
+
+// TxManager runs functions in a database transaction carried by the context.
+// Repositories called with that context take part in the transaction, nested calls run in savepoints
+type TxManager interface {
+	// InTx commits when fn returns nil and rolls back when it returns an error or panics
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
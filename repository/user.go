@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mustan989/wallet/model"
+)
+
+var (
+	ErrUserNotFound = errors.New("user not found")
+	ErrUserConflict = errors.New("user already exists")
+)
+
+// User repository interface
+type User interface {
+	FindByID(ctx context.Context, id uint64) (data *model.User, err error)
+	FindByEmail(ctx context.Context, email string) (data *model.User, err error)
+	Create(ctx context.Context, data *model.User) error
+	Update(ctx context.Context, data *model.User) error
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/mustan989/wallet/model"
 )
@@ -10,14 +11,23 @@ import (
 var (
 	ErrWalletNotFound = errors.New("wallet not found")
 	ErrWalletConflict = errors.New("wallet already exists")
+	// ErrWalletStale is returned when the wallet was written since the version an update was made against
+	ErrWalletStale = errors.New("wallet was changed since it was read")
 )
 
-// Wallet repository interface
+// Wallet repository interface.
+// Every call is scoped to the wallets owned by the acting user of the context or shared with them,
+// roles of the members are enforced by the service
 type Wallet interface {
 	CountAll(ctx context.Context, filter *model.WalletFilter) (count uint64, err error)
 	FindAll(ctx context.Context, filter *model.WalletFilter) (data []*model.Wallet, err error)
 	FindByID(ctx context.Context, id uint64) (data *model.Wallet, err error)
 	Create(ctx context.Context, data *model.Wallet) error
 	Update(ctx context.Context, data *model.Wallet) error
+	// DeleteByID permanently deletes the wallet along with its transfers,
+	// taking the transfers off the amounts of the wallets on their other side
 	DeleteByID(ctx context.Context, id uint64) (deleted *model.Wallet, err error)
+	// Purge permanently deletes the wallets soft deleted before the time, reverting their transfers like DeleteByID.
+	// Unlike the other calls it is not scoped to the acting user, it is meant for background cleanup
+	Purge(ctx context.Context, before time.Time) (count uint64, err error)
 }
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+
+	"github.com/mustan989/wallet/model"
+)
+
+// Auth service interface.
+// Login and Refresh issue a pair of tokens, Authenticate resolves the user of an access token
+type Auth interface {
+	Register(ctx context.Context, request *AuthRegisterRequest) (*AuthRegisterResponse, error)
+	Login(ctx context.Context, request *AuthLoginRequest) (*AuthLoginResponse, error)
+	Refresh(ctx context.Context, request *AuthRefreshRequest) (*AuthRefreshResponse, error)
+	Logout(ctx context.Context, request *AuthLogoutRequest) (*AuthLogoutResponse, error)
+	Authenticate(ctx context.Context, request *AuthAuthenticateRequest) (*AuthAuthenticateResponse, error)
+}
+
+type AuthRegisterRequest struct {
+	Data     *model.User
+	Password string
+}
+
+type AuthRegisterResponse struct {
+	Data *model.User `json:"data"`
+}
+
+type AuthLoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type AuthLoginResponse struct {
+	Data *model.Tokens `json:"data"`
+}
+
+type AuthRefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type AuthRefreshResponse struct {
+	Data *model.Tokens `json:"data"`
+}
+
+// AuthLogoutRequest revokes the given tokens, the refresh token alone logs out when the access token has expired
+type AuthLogoutRequest struct {
+	AccessToken  string
+	RefreshToken string `json:"refresh_token"`
+}
+
+type AuthLogoutResponse struct{}
+
+type AuthAuthenticateRequest struct {
+	AccessToken string
+}
+
+type AuthAuthenticateResponse struct {
+	UserID uint64
+}
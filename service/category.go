@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+
+	"github.com/mustan989/wallet/model"
+)
+
+type Category interface {
+	Count(ctx context.Context, request *CategoryCountRequest) (*CategoryCountResponse, error)
+	GetAll(ctx context.Context, request *CategoryGetAllRequest) (*CategoryGetAllResponse, error)
+	GetByID(ctx context.Context, request *CategoryGetByIDRequest) (*CategoryGetByIDResponse, error)
+	Create(ctx context.Context, request *CategoryCreateRequest) (*CategoryCreateResponse, error)
+	Update(ctx context.Context, request *CategoryUpdateRequest) (*CategoryUpdateResponse, error)
+	DeleteByID(ctx context.Context, request *CategoryDeleteByIDRequest) (*CategoryDeleteByIDResponse, error)
+}
+
+type CategoryCountRequest struct {
+	Filter *model.CategoryFilter
+}
+
+type CategoryCountResponse struct {
+	Count uint64 `json:"count"`
+}
+
+type CategoryGetAllRequest struct {
+	Filter *model.CategoryFilter
+}
+
+type CategoryGetAllResponse struct {
+	Data  []*model.Category `json:"data"`
+	Total uint64            `json:"total"`
+	Next  *model.Cursor     `json:"next,omitempty"`
+}
+
+type CategoryGetByIDRequest struct {
+	ID uint64
+}
+
+type CategoryGetByIDResponse struct {
+	Data *model.Category `json:"data"`
+}
+
+type CategoryCreateRequest struct {
+	Data *model.Category
+}
+
+type CategoryCreateResponse struct {
+	Data *model.Category `json:"data"`
+}
+
+type CategoryUpdateRequest struct {
+	Data *model.Category
+}
+
+type CategoryUpdateResponse struct {
+	Data *model.Category `json:"data"`
+}
+
+// CategoryDeleteByIDRequest deletes the category.
+// A category used by transactions is deleted only with ReassignTo set, otherwise it can be archived with Archive
+type CategoryDeleteByIDRequest struct {
+	ID         uint64
+	ReassignTo *uint64
+	Archive    bool
+}
+
+type CategoryDeleteByIDResponse struct {
+	Data *model.Category `json:"data"`
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+
+	"github.com/mustan989/wallet/pkg/currency"
+)
+
+// Currency service interface, lists the currencies wallets can be kept in
+type Currency interface {
+	GetAll(ctx context.Context, request *CurrencyGetAllRequest) (*CurrencyGetAllResponse, error)
+}
+
+type CurrencyGetAllRequest struct{}
+
+type CurrencyGetAllResponse struct {
+	Data []currency.Currency `json:"data"`
+}
@@ -0,0 +1,12 @@
+package service
+
+import "errors"
+
+// ErrInvalidArgument is returned when a request does not pass validation
+var ErrInvalidArgument = errors.New("invalid argument")
+
+// ErrUnauthenticated is returned when credentials or a token are not valid
+var ErrUnauthenticated = errors.New("unauthenticated")
+
+// ErrForbidden is returned when the acting user lacks the permission for the call
+var ErrForbidden = errors.New("permission denied")
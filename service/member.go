@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+
+	"github.com/mustan989/wallet/model"
+)
+
+// Member service shares wallets with other users, only owners of the wallet may change its members
+type Member interface {
+	GetAll(ctx context.Context, request *MemberGetAllRequest) (*MemberGetAllResponse, error)
+	Put(ctx context.Context, request *MemberPutRequest) (*MemberPutResponse, error)
+	DeleteByID(ctx context.Context, request *MemberDeleteByIDRequest) (*MemberDeleteByIDResponse, error)
+}
+
+type MemberGetAllRequest struct {
+	WalletID uint64
+}
+
+type MemberGetAllResponse struct {
+	Data []*model.Member `json:"data"`
+}
+
+type MemberPutRequest struct {
+	Data *model.Member
+}
+
+type MemberPutResponse struct {
+	Data *model.Member `json:"data"`
+}
+
+type MemberDeleteByIDRequest struct {
+	WalletID uint64
+	UserID   uint64
+}
+
+type MemberDeleteByIDResponse struct {
+	Data *model.Member `json:"data"`
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+
+	"github.com/mustan989/wallet/model"
+)
+
+type Transaction interface {
+	Count(ctx context.Context, request *TransactionCountRequest) (*TransactionCountResponse, error)
+	GetAll(ctx context.Context, request *TransactionGetAllRequest) (*TransactionGetAllResponse, error)
+	GetByID(ctx context.Context, request *TransactionGetByIDRequest) (*TransactionGetByIDResponse, error)
+	Create(ctx context.Context, request *TransactionCreateRequest) (*TransactionCreateResponse, error)
+	Update(ctx context.Context, request *TransactionUpdateRequest) (*TransactionUpdateResponse, error)
+	DeleteByID(ctx context.Context, request *TransactionDeleteByIDRequest) (*TransactionDeleteByIDResponse, error)
+}
+
+type TransactionCountRequest struct {
+	Filter *model.TransactionFilter
+}
+
+type TransactionCountResponse struct {
+	Count uint64 `json:"count"`
+}
+
+type TransactionGetAllRequest struct {
+	Filter *model.TransactionFilter
+}
+
+type TransactionGetAllResponse struct {
+	Data  []*model.Transaction `json:"data"`
+	Total uint64               `json:"total"`
+	Next  *model.Cursor        `json:"next,omitempty"`
+}
+
+type TransactionGetByIDRequest struct {
+	ID uint64
+}
+
+type TransactionGetByIDResponse struct {
+	Data *model.Transaction `json:"data"`
+}
+
+type TransactionCreateRequest struct {
+	Data *model.Transaction
+}
+
+type TransactionCreateResponse struct {
+	Data *model.Transaction `json:"data"`
+}
+
+type TransactionUpdateRequest struct {
+	Data *model.Transaction
+}
+
+type TransactionUpdateResponse struct {
+	Data *model.Transaction `json:"data"`
+}
+
+type TransactionDeleteByIDRequest struct {
+	ID uint64
+}
+
+type TransactionDeleteByIDResponse struct {
+	Data *model.Transaction `json:"data"`
+}
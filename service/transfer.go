@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+
+	"github.com/mustan989/wallet/model"
+)
+
+type Transfer interface {
+	Count(ctx context.Context, request *TransferCountRequest) (*TransferCountResponse, error)
+	GetAll(ctx context.Context, request *TransferGetAllRequest) (*TransferGetAllResponse, error)
+	GetByID(ctx context.Context, request *TransferGetByIDRequest) (*TransferGetByIDResponse, error)
+	Create(ctx context.Context, request *TransferCreateRequest) (*TransferCreateResponse, error)
+	Update(ctx context.Context, request *TransferUpdateRequest) (*TransferUpdateResponse, error)
+	DeleteByID(ctx context.Context, request *TransferDeleteByIDRequest) (*TransferDeleteByIDResponse, error)
+}
+
+type TransferCountRequest struct {
+	Filter *model.TransferFilter
+}
+
+type TransferCountResponse struct {
+	Count uint64 `json:"count"`
+}
+
+type TransferGetAllRequest struct {
+	Filter *model.TransferFilter
+}
+
+type TransferGetAllResponse struct {
+	Data  []*model.Transfer `json:"data"`
+	Total uint64            `json:"total"`
+	Next  *model.Cursor     `json:"next,omitempty"`
+}
+
+type TransferGetByIDRequest struct {
+	ID uint64
+}
+
+type TransferGetByIDResponse struct {
+	Data *model.Transfer `json:"data"`
+}
+
+type TransferCreateRequest struct {
+	Data *model.Transfer
+}
+
+type TransferCreateResponse struct {
+	Data *model.Transfer `json:"data"`
+}
+
+type TransferUpdateRequest struct {
+	Data *model.Transfer
+}
+
+type TransferUpdateResponse struct {
+	Data *model.Transfer `json:"data"`
+}
+
+type TransferDeleteByIDRequest struct {
+	ID uint64
+}
+
+type TransferDeleteByIDResponse struct {
+	Data *model.Transfer `json:"data"`
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+
+	"github.com/mustan989/wallet/model"
+)
+
+// User service interface, acts on the user of the context.
+// Users are created by Auth.Register
+type User interface {
+	Get(ctx context.Context, request *UserGetRequest) (*UserGetResponse, error)
+	Update(ctx context.Context, request *UserUpdateRequest) (*UserUpdateResponse, error)
+}
+
+type UserGetRequest struct{}
+
+type UserGetResponse struct {
+	Data *model.User `json:"data"`
+}
+
+type UserUpdateRequest struct {
+	Data *model.User
+}
+
+type UserUpdateResponse struct {
+	Data *model.User `json:"data"`
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/mustan989/wallet/model"
 )
@@ -13,6 +14,10 @@ type Wallet interface {
 	Create(ctx context.Context, request *WalletCreateRequest) (*WalletCreateResponse, error)
 	Update(ctx context.Context, request *WalletUpdateRequest) (*WalletUpdateResponse, error)
 	DeleteByID(ctx context.Context, request *WalletDeleteByIDRequest) (*WalletDeleteByIDResponse, error)
+	// Restore undoes the soft delete of a wallet
+	Restore(ctx context.Context, request *WalletRestoreRequest) (*WalletRestoreResponse, error)
+	// Purge permanently deletes the wallets of every user that stayed deleted longer than the retention
+	Purge(ctx context.Context, request *WalletPurgeRequest) (*WalletPurgeResponse, error)
 }
 
 type WalletCountRequest struct {
@@ -20,7 +25,7 @@ type WalletCountRequest struct {
 }
 
 type WalletCountResponse struct {
-	Count uint64
+	Count uint64 `json:"count"`
 }
 
 type WalletGetAllRequest struct {
@@ -28,8 +33,9 @@ type WalletGetAllRequest struct {
 }
 
 type WalletGetAllResponse struct {
-	Data  []*model.Wallet
-	Total uint64
+	Data  []*model.Wallet `json:"data"`
+	Total uint64          `json:"total"`
+	Next  *model.Cursor   `json:"next,omitempty"`
 }
 
 type WalletGetByIDRequest struct {
@@ -37,7 +43,7 @@ type WalletGetByIDRequest struct {
 }
 
 type WalletGetByIDResponse struct {
-	Data *model.Wallet
+	Data *model.Wallet `json:"data"`
 }
 
 type WalletCreateRequest struct {
@@ -45,7 +51,7 @@ type WalletCreateRequest struct {
 }
 
 type WalletCreateResponse struct {
-	Data *model.Wallet
+	Data *model.Wallet `json:"data"`
 }
 
 type WalletUpdateRequest struct {
@@ -53,7 +59,7 @@ type WalletUpdateRequest struct {
 }
 
 type WalletUpdateResponse struct {
-	Data *model.Wallet
+	Data *model.Wallet `json:"data"`
 }
 
 type WalletDeleteByIDRequest struct {
@@ -61,5 +67,21 @@ type WalletDeleteByIDRequest struct {
 }
 
 type WalletDeleteByIDResponse struct {
-	Data *model.Wallet
+	Data *model.Wallet `json:"data"`
+}
+
+type WalletRestoreRequest struct {
+	ID uint64
+}
+
+type WalletRestoreResponse struct {
+	Data *model.Wallet `json:"data"`
+}
+
+type WalletPurgeRequest struct {
+	Retention time.Duration
+}
+
+type WalletPurgeResponse struct {
+	Count uint64 `json:"count"`
 }